@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gin"
+	"log"
+	"os"
+)
+
+// Generates the OpenAPI specification from registered routes. With -check it fails when the committed specification
+// is out of date, which is meant to run in CI from the module root.
+func main() {
+	out := flag.String("out", gin.OpenApiSpecPath, "output file")
+	check := flag.Bool("check", false, "fail if the output file differs from the generated specification")
+	flag.Parse()
+
+	if err := run(*out, *check); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(out string, check bool) error {
+	spec, err := gin.GenerateOpenApiSpec()
+	if err != nil {
+		return err
+	}
+
+	if !check {
+		return os.WriteFile(out, spec, 0644)
+	}
+
+	current, err := os.ReadFile(out)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(current, spec) {
+		return fmt.Errorf("%s is out of date, run go run ./cmd/openapi", out)
+	}
+
+	return nil
+}
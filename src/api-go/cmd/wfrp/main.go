@@ -5,10 +5,16 @@ import (
 	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gin"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/golangjwt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/goldmark"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gooidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/graphqlgo"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mailjet"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mockcaptcha"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mongodb"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/validator"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/http"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/services"
 	mock "github.com/jmilosze/wfrp-hammergen-go/test/mock_data"
@@ -28,21 +34,71 @@ func run() error {
 
 	cfg := config.NewConfig()
 
-	val := validator.NewValidator()
-	jwtService := golangjwt.NewHmacService(cfg.Jwt.HmacSecret, cfg.Jwt.AccessExpiry, cfg.Jwt.ResetExpiry)
+	enumRegistry := warhammer.NewWhEnumRegistry()
+	val := validator.NewValidator(enumRegistry)
+	markdownRenderer := goldmark.NewMarkdownRenderer()
 	emailService := mailjet.NewEmailService(cfg.Email.FromAddress, cfg.Email.PublicApiKey, cfg.Email.PrivateApiKey)
 	captchaService := mockcaptcha.NewCaptchaService()
 	mongoDbService := mongodb.NewDbService(cfg.MongoDb.Uri, cfg.MongoDb.DbName)
 	defer mongoDbService.Disconnect()
 
-	userDbService := mongodb.NewUserDbService(mongoDbService, cfg.MongoDb.CreateUserIndexes)
-	userService := services.NewUserService(&cfg.UserService, userDbService, emailService, jwtService, val)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.RequestTimeout)
+	defer cancel()
+
+	jwtKeyDbService := mongodb.NewJwtKeyDbService(mongoDbService)
+	jwtService, err := golangjwt.NewJwtService(ctx, &cfg.Jwt, jwtKeyDbService)
+	if err != nil {
+		return err
+	}
 
+	auditDbService := mongodb.NewAuditDbService(mongoDbService, cfg.MongoDb.CreateAuditIndexes)
+	auditService := services.NewAuditService(auditDbService)
+
+	userDbService := mongodb.NewUserDbService(mongoDbService, cfg.MongoDb.CreateUserIndexes)
+	if cfg.MongoDb.MigrateEmailVerified {
+		if err := userDbService.MigrateEmailVerified(context.Background()); err != nil {
+			return err
+		}
+	}
+	if cfg.MongoDb.MigrateUserRoles {
+		if err := userDbService.MigrateUserRoles(context.Background()); err != nil {
+			return err
+		}
+	}
 	whDbService := mongodb.NewWhDbService(mongoDbService)
-	whService := services.NewWhService(val, whDbService)
+	if cfg.MongoDb.MigrateLegacySpecies {
+		if err := whDbService.MigrateLegacySpecies(context.Background()); err != nil {
+			return err
+		}
+	}
+	if cfg.MongoDb.MigrateWhTimestamps {
+		if err := whDbService.MigrateWhTimestamps(context.Background()); err != nil {
+			return err
+		}
+	}
+	whRevisionDbService := mongodb.NewWhRevisionDbService(mongoDbService, cfg.MongoDb.CreateRevisionIndexes)
+	txService := mongodb.NewTxService(mongoDbService)
+	webhookDbService := mongodb.NewWebhookDbService(mongoDbService, cfg.MongoDb.CreateWebhookIndexes)
+	webhookDeliveryDbService := mongodb.NewWebhookDeliveryDbService(mongoDbService, cfg.MongoDb.CreateWebhookIndexes)
+	webhookService := services.NewWebhookService(&cfg.WebhookService, val, webhookDbService, webhookDeliveryDbService, userDbService)
+	sessionDbService := mongodb.NewSessionDbService(mongoDbService, cfg.MongoDb.CreateSessionIndexes)
+	patDbService := mongodb.NewPatDbService(mongoDbService, cfg.MongoDb.CreatePatIndexes)
+	oidcLinkDbService := mongodb.NewOidcLinkDbService(mongoDbService, cfg.MongoDb.CreateOidcIndexes)
+	userService := services.NewUserService(&cfg.UserService, userDbService, emailService, jwtService, val, auditService, webhookService, txService, whDbService, whRevisionDbService, sessionDbService, patDbService, oidcLinkDbService)
+	sessionService := services.NewSessionService(&cfg.Jwt, jwtService, sessionDbService, userDbService)
+	attemptDbService := mongodb.NewAttemptDbService(mongoDbService, cfg.MongoDb.CreateAttemptIndexes)
+	lockoutService := services.NewLockoutService(&cfg.Lockout, attemptDbService, userDbService, emailService)
+	patService := services.NewPatService(&cfg.Pat, val, patDbService, userDbService)
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.RequestTimeout)
-	defer cancel()
+	oidcProviders := map[string]oidc.Provider{}
+	if cfg.Oidc.GoogleClientId != "" {
+		oidcProviders["google"] = gooidc.NewProvider("https://accounts.google.com", cfg.Oidc.GoogleClientId, cfg.Oidc.GoogleClientSecret, cfg.Oidc.RedirectUrl)
+	}
+	oidcLoginStateDbService := mongodb.NewOidcLoginStateDbService(mongoDbService, cfg.MongoDb.CreateOidcIndexes)
+	oidcService := services.NewOidcService(&cfg.Oidc, oidcProviders, oidcLinkDbService, oidcLoginStateDbService, userDbService, userService)
+
+	whService := services.NewWhService(&cfg.WhService, val, enumRegistry, markdownRenderer, whDbService, whRevisionDbService, auditService, webhookService)
+	graphqlService := graphqlgo.NewGraphqlService(whService)
 
 	if cfg.UserService.CreateMockUsers {
 		mock.InitUser(ctx, userDbService, userService.BcryptCost)
@@ -52,18 +108,40 @@ func run() error {
 		mock.InitWh(ctx, whDbService)
 	}
 
-	router := gin.NewRouter(cfg.Server.RequestTimeout)
-	gin.RegisterUserRoutes(router, userService, jwtService, captchaService)
-	gin.RegisterAuthRoutes(router, userService, jwtService)
-	gin.RegisterWhRoutes(router, whService, jwtService)
+	if whErr := whService.LoadEnums(ctx); whErr != nil {
+		return whErr
+	}
+
+	router := gin.NewRouter(cfg.Server.RequestTimeout, cfg.Server.TrustedProxies)
+	gin.RegisterUserRoutes(router, userService, sessionService, captchaService)
+	gin.RegisterAuthRoutes(router, userService, sessionService, sessionService, lockoutService)
+	gin.RegisterWhRoutes(router, whService, sessionService, patService)
+	gin.RegisterGraphqlRoutes(router, graphqlService, sessionService, patService)
+	gin.RegisterAuditRoutes(router, auditService, sessionService)
+	gin.RegisterWebhookRoutes(router, webhookService, sessionService)
+	gin.RegisterPatRoutes(router, patService, sessionService)
+	gin.RegisterOidcRoutes(router, oidcService, sessionService, sessionService, lockoutService)
+	gin.RegisterJwksRoutes(router, jwtService)
+	gin.RegisterOpenApiRoutes(router)
 
 	server := http.NewServer(&cfg.Server, router)
+	grpcServer := grpc.NewServer(&cfg.Server, userService, whService, sessionService, sessionService, lockoutService)
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 
+	jobCtx, jobCancel := context.WithCancel(context.Background())
+	defer jobCancel()
+	whService.StartTrashPurge(jobCtx, cfg.WhService.TrashPurgeInterval)
+	whService.StartEnumRefresh(jobCtx, cfg.WhService.EnumRefreshInterval)
+	webhookService.StartDeliveries(jobCtx, cfg.WebhookService.PollInterval)
+	jwtService.StartRotation(jobCtx, cfg.Jwt.KeyRefreshInterval)
+	lockoutService.StartPruning(jobCtx, cfg.Lockout.PruneInterval)
+
 	server.Start()
+	grpcServer.Start()
 	<-done
+	grpcServer.Stop()
 	server.Stop()
 
 	return nil
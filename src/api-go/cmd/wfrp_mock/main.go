@@ -5,10 +5,17 @@ import (
 	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gin"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/golangjwt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/goldmark"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gooidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/graphqlgo"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/memdb"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mockcaptcha"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mockemail"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mockoidc"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/validator"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/http"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/services"
 	mock "github.com/jmilosze/wfrp-hammergen-go/test/mock_data"
@@ -27,19 +34,49 @@ func main() {
 func run() error {
 	cfg := config.NewConfig()
 
-	val := validator.NewValidator()
+	enumRegistry := warhammer.NewWhEnumRegistry()
+	val := validator.NewValidator(enumRegistry)
+	markdownRenderer := goldmark.NewMarkdownRenderer()
 
-	jwtService := golangjwt.NewHmacService(cfg.Jwt.HmacSecret, cfg.Jwt.AccessExpiry, cfg.Jwt.ResetExpiry)
 	emailService := mockemail.NewEmailService(cfg.Email.FromAddress)
 	captchaService := mockcaptcha.NewCaptchaService()
-	userDbService := memdb.NewUserDbService()
-	userService := services.NewUserService(&cfg.UserService, userDbService, emailService, jwtService, val)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.RequestTimeout)
+	defer cancel()
 
+	jwtKeyDbService := memdb.NewJwtKeyDbService()
+	jwtService, err := golangjwt.NewJwtService(ctx, &cfg.Jwt, jwtKeyDbService)
+	if err != nil {
+		return err
+	}
+
+	auditDbService := memdb.NewAuditDbService()
+	auditService := services.NewAuditService(auditDbService)
+
+	txService := memdb.NewTxService()
+	userDbService := memdb.NewUserDbService()
 	whDbService := memdb.NewWhDbService()
-	whService := services.NewWhService(val, whDbService)
+	whRevisionDbService := memdb.NewWhRevisionDbService()
+	webhookDbService := memdb.NewWebhookDbService()
+	webhookDeliveryDbService := memdb.NewWebhookDeliveryDbService()
+	webhookService := services.NewWebhookService(&cfg.WebhookService, val, webhookDbService, webhookDeliveryDbService, userDbService)
+	sessionDbService := memdb.NewSessionDbService()
+	patDbService := memdb.NewPatDbService()
+	oidcLinkDbService := memdb.NewOidcLinkDbService()
+	userService := services.NewUserService(&cfg.UserService, userDbService, emailService, jwtService, val, auditService, webhookService, txService, whDbService, whRevisionDbService, sessionDbService, patDbService, oidcLinkDbService)
+	sessionService := services.NewSessionService(&cfg.Jwt, jwtService, sessionDbService, userDbService)
+	attemptDbService := memdb.NewAttemptDbService()
+	lockoutService := services.NewLockoutService(&cfg.Lockout, attemptDbService, userDbService, emailService)
+	patService := services.NewPatService(&cfg.Pat, val, patDbService, userDbService)
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.RequestTimeout)
-	defer cancel()
+	mockOidcServer := mockoidc.NewServer(cfg.Oidc.MockProviderPort, "hammergen", "mock secret")
+	oidcProviders := map[string]oidc.Provider{
+		"mock": gooidc.NewProvider(mockOidcServer.Issuer, mockOidcServer.ClientId, mockOidcServer.ClientSecret, cfg.Oidc.RedirectUrl),
+	}
+	oidcLoginStateDbService := memdb.NewOidcLoginStateDbService()
+	oidcService := services.NewOidcService(&cfg.Oidc, oidcProviders, oidcLinkDbService, oidcLoginStateDbService, userDbService, userService)
+
+	whService := services.NewWhService(&cfg.WhService, val, enumRegistry, markdownRenderer, whDbService, whRevisionDbService, auditService, webhookService)
+	graphqlService := graphqlgo.NewGraphqlService(whService)
 
 	if cfg.UserService.CreateMockUsers {
 		mock.InitUser(ctx, userDbService, userService.BcryptCost)
@@ -49,19 +86,43 @@ func run() error {
 		mock.InitWh(ctx, whDbService)
 	}
 
-	router := gin.NewRouter(cfg.Server.RequestTimeout)
-	gin.RegisterUserRoutes(router, userService, jwtService, captchaService)
-	gin.RegisterAuthRoutes(router, userService, jwtService)
-	gin.RegisterWhRoutes(router, whService, jwtService)
+	if whErr := whService.LoadEnums(ctx); whErr != nil {
+		return whErr
+	}
+
+	router := gin.NewRouter(cfg.Server.RequestTimeout, cfg.Server.TrustedProxies)
+	gin.RegisterUserRoutes(router, userService, sessionService, captchaService)
+	gin.RegisterAuthRoutes(router, userService, sessionService, sessionService, lockoutService)
+	gin.RegisterWhRoutes(router, whService, sessionService, patService)
+	gin.RegisterGraphqlRoutes(router, graphqlService, sessionService, patService)
+	gin.RegisterAuditRoutes(router, auditService, sessionService)
+	gin.RegisterWebhookRoutes(router, webhookService, sessionService)
+	gin.RegisterPatRoutes(router, patService, sessionService)
+	gin.RegisterOidcRoutes(router, oidcService, sessionService, sessionService, lockoutService)
+	gin.RegisterJwksRoutes(router, jwtService)
+	gin.RegisterOpenApiRoutes(router)
 
 	server := http.NewServer(&cfg.Server, router)
+	grpcServer := grpc.NewServer(&cfg.Server, userService, whService, sessionService, sessionService, lockoutService)
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 
+	jobCtx, jobCancel := context.WithCancel(context.Background())
+	defer jobCancel()
+	whService.StartTrashPurge(jobCtx, cfg.WhService.TrashPurgeInterval)
+	whService.StartEnumRefresh(jobCtx, cfg.WhService.EnumRefreshInterval)
+	webhookService.StartDeliveries(jobCtx, cfg.WebhookService.PollInterval)
+	jwtService.StartRotation(jobCtx, cfg.Jwt.KeyRefreshInterval)
+	lockoutService.StartPruning(jobCtx, cfg.Lockout.PruneInterval)
+
+	mockOidcServer.Start()
 	server.Start()
+	grpcServer.Start()
 	<-done
+	grpcServer.Stop()
 	server.Stop()
+	mockOidcServer.Stop()
 
 	return nil
 }
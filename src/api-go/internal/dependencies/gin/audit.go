@@ -0,0 +1,78 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"strconv"
+	"time"
+)
+
+func RegisterAuditRoutes(router *gin.Engine, as audit.AuditService, js domain.JwtService) {
+	router.GET("api/audit", RequireJwt(js), auditListHandler(as))
+}
+
+func auditListHandler(as audit.AuditService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		query := audit.Query{
+			ActorId:    c.Query("actorId"),
+			ObjectType: c.Query("objectType"),
+			ObjectId:   c.Query("objectId"),
+			OwnerId:    c.Query("ownerId"),
+		}
+
+		if since := c.Query("since"); since != "" {
+			sinceTime, err := time.Parse(time.RFC3339, since)
+			if err != nil {
+				c.JSON(BadRequestErrResp("invalid since, expected RFC3339 timestamp"))
+				return
+			}
+			query.Since = sinceTime
+		}
+
+		if limit := c.Query("limit"); limit != "" {
+			limitInt, err := strconv.Atoi(limit)
+			if err != nil {
+				c.JSON(BadRequestErrResp("invalid limit"))
+				return
+			}
+			query.Limit = limitInt
+		}
+
+		events, aErr := as.Get(c.Request.Context(), claims, &query)
+		if aErr != nil {
+			switch aErr.Type {
+			case audit.AuditUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			case audit.AuditInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(aErr.Error()))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(auditEventsToListOfMaps(events)))
+	}
+}
+
+func auditEventsToListOfMaps(events []*audit.Event) []map[string]any {
+	list := make([]map[string]any, len(events))
+
+	for i, v := range events {
+		list[i] = gin.H{
+			"id":         v.Id,
+			"actorId":    v.ActorId,
+			"timestamp":  v.Timestamp,
+			"type":       v.Type,
+			"objectType": v.ObjectType,
+			"objectId":   v.ObjectId,
+			"ownerId":    v.OwnerId,
+			"changes":    v.Changes,
+		}
+	}
+
+	return list
+}
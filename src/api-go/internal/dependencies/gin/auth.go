@@ -1,80 +1,276 @@
 package gin
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/text/language"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
-func RegisterAuthRoutes(router *gin.Engine, us user.UserService, js domain.JwtService) {
-	router.POST("api/token", tokenHandler(us, js))
+func RegisterAuthRoutes(router *gin.Engine, us user.UserService, ss session.SessionService, js domain.JwtService, ls lockout.LockoutService) {
+	router.POST("api/token", tokenHandler(us, ss, ls))
+	router.POST("api/token/refresh", tokenRefreshHandler(ss))
+	router.POST("api/token/logout", RequireJwt(js), tokenLogoutHandler(ss))
 }
 
-func tokenHandler(us user.UserService, js domain.JwtService) func(*gin.Context) {
+// tokenHandler responds the same way to unknown users and wrong passwords, so that it can not be used to find out
+// which accounts exist.
+func tokenHandler(us user.UserService, ss session.SessionService, ls lockout.LockoutService) func(*gin.Context) {
 	return func(c *gin.Context) {
 		username := c.PostForm("username")
 		password := c.PostForm("password")
+		totpCode := c.PostForm("totp_code")
+		address := c.ClientIP()
 
-		u, uErr := us.Authenticate(c.Request.Context(), username, password)
+		if !beginLockout(c, ls, username, address) {
+			return
+		}
+
+		u, uErr := us.Authenticate(c.Request.Context(), username, password, totpCode)
 
 		if uErr != nil {
 			switch uErr.Type {
-			case user.UserNotFoundError:
-				c.JSON(http.StatusNotFound, gin.H{"code": http.StatusNotFound, "message": "user not found"})
-			case user.UserIncorrectPasswordError:
-				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": "invalid password"})
+			case user.UserNotFoundError, user.UserIncorrectPasswordError:
+				failLockout(c, ls, username, address)
+				c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "invalid username or password"})
+			case user.UserIncorrectTotpError:
+				failLockout(c, ls, username, address)
+				totpErrResp(c, uErr)
+			case user.UserTotpRequiredError:
+				releaseLockout(c, ls, username, address)
+				totpErrResp(c, uErr)
 			default:
+				releaseLockout(c, ls, username, address)
 				c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "internal server error"})
 			}
 			return
 		}
 
-		claims := domain.Claims{Id: u.Id, Admin: u.Admin, SharedAccounts: u.SharedAccountIds, ResetPassword: false}
-		token, err := js.GenerateAccessToken(&claims)
+		if lErr := ls.Succeed(c.Request.Context(), username, address); lErr != nil {
+			log.Printf("error resetting sign-in attempts: %s", lErr)
+		}
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "error generating token"})
+		createSession(c, ss, u)
+	}
+}
+
+// beginLockout records the sign-in attempt of username from address, it responds with 429 and returns false if the
+// attempt has to wait.
+func beginLockout(c *gin.Context, ls lockout.LockoutService, username string, address string) bool {
+	wait, lErr := ls.Begin(c.Request.Context(), username, address)
+	if lErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "internal server error"})
+		return false
+	}
+
+	if wait > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"code": http.StatusTooManyRequests, "message": "too many failed attempts, try again later"})
+		return false
+	}
+
+	return true
+}
+
+func failLockout(c *gin.Context, ls lockout.LockoutService, username string, address string) {
+	if lErr := ls.Fail(c.Request.Context(), username, address); lErr != nil {
+		log.Printf("error recording failed sign-in attempt: %s", lErr)
+	}
+}
+
+func releaseLockout(c *gin.Context, ls lockout.LockoutService, username string, address string) {
+	if lErr := ls.Release(c.Request.Context(), username, address); lErr != nil {
+		log.Printf("error releasing sign-in attempt: %s", lErr)
+	}
+}
+
+// totpErrResp asks the client for the second step, repeating the request with totp_code.
+func totpErrResp(c *gin.Context, uErr *user.UserError) {
+	if uErr.Type == user.UserTotpRequiredError {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "totp code required", "totp_required": true})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": "invalid totp code", "totp_required": true})
+}
+
+// createSession responds with the tokens of a new session of u.
+func createSession(c *gin.Context, ss session.SessionService, u *user.User) {
+	claims := domain.Claims{Id: u.Id, Roles: u.EffectiveRoles(), SharedAccounts: u.SharedAccountIds, ResetPassword: false, Locale: u.Locale}
+	tokens, sErr := ss.Create(c.Request.Context(), &claims)
+
+	if sErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "error generating token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokensToMap(tokens))
+}
+
+func tokenRefreshHandler(ss session.SessionService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		tokens, sErr := ss.Refresh(c.Request.Context(), c.PostForm("refresh_token"))
+
+		if sErr != nil {
+			switch sErr.Type {
+			case session.SessionInvalidTokenError, session.SessionReuseDetectedError:
+				c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "invalid refresh token"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "internal server error"})
+			}
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "access_token": token, "token_type": "bearer"})
+		c.JSON(http.StatusOK, tokensToMap(tokens))
 	}
 }
 
-func RequireJwt(js domain.JwtService) gin.HandlerFunc {
+// tokenLogoutHandler revokes the session of the refresh token in the form or, if there is none, of the access token.
+func tokenLogoutHandler(ss session.SessionService) func(*gin.Context) {
 	return func(c *gin.Context) {
-		authHeader := c.Request.Header.Get("Authorization")
+		sErr := ss.Revoke(c.Request.Context(), getUserClaims(c), c.PostForm("refresh_token"))
+
+		if sErr != nil {
+			switch sErr.Type {
+			case session.SessionInvalidTokenError, session.SessionUnauthorizedError:
+				c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "invalid token"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "internal server error"})
+			}
+			return
+		}
 
-		token, err := parseAuthHeader(authHeader)
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "logged out"})
+	}
+}
+
+func tokensToMap(tokens *session.Tokens) map[string]any {
+	return gin.H{
+		"code":          http.StatusOK,
+		"access_token":  tokens.AccessToken,
+		"token_type":    "bearer",
+		"expires_in":    int(tokens.ExpiresIn.Seconds()),
+		"refresh_token": tokens.RefreshToken,
+	}
+}
+
+func RequireJwt(js domain.JwtService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := parseAuthHeader(c.Request.Header.Get("Authorization"))
 		if err != nil {
 			setAnonymous(c)
 			return
 		}
 
-		claims, err := js.ParseToken(token)
+		claims := authenticateJwt(c.Request.Context(), js, token)
+		if claims == nil {
+			setAnonymous(c)
+			return
+		}
+
+		setClaims(c, claims)
+	}
+}
+
+// RequireJwtOrPat is RequireJwt that also accepts personal access tokens. It is meant only for routes whose services
+// enforce token scopes, other routes treat personal access tokens as anonymous.
+func RequireJwtOrPat(js domain.JwtService, ps pat.PatService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := parseAuthHeader(c.Request.Header.Get("Authorization"))
 		if err != nil {
 			setAnonymous(c)
 			return
 		}
 
-		if claims.ResetPassword {
+		claims := authenticateJwtOrPat(c.Request.Context(), js, ps, token)
+		if claims == nil {
 			setAnonymous(c)
 			return
 		}
 
-		c.Set("ClaimsId", claims.Id)
-		c.Set("ClaimsAdmin", claims.Admin)
-		c.Set("ClaimsSharedAccounts", claims.SharedAccounts)
+		setClaims(c, claims)
 	}
 }
 
+// authenticateJwt returns nil if the token is invalid, was issued for other purpose than access or its session was
+// revoked.
+func authenticateJwt(ctx context.Context, js domain.JwtService, token string) *domain.Claims {
+	claims, err := js.ParseToken(token)
+	if err != nil {
+		return nil
+	}
+
+	if claims.ResetPassword || claims.VerifyEmail != "" {
+		return nil
+	}
+
+	if rc, ok := js.(domain.TokenRevocationChecker); ok && rc.IsRevoked(ctx, claims) {
+		return nil
+	}
+
+	return claims
+}
+
+func authenticateJwtOrPat(ctx context.Context, js domain.JwtService, ps pat.PatService, token string) *domain.Claims {
+	if !strings.HasPrefix(token, pat.SecretPrefix) {
+		return authenticateJwt(ctx, js, token)
+	}
+
+	claims, pErr := ps.Authenticate(ctx, token)
+	if pErr != nil {
+		if pErr.Type == pat.PatInternalError {
+			log.Printf("error authenticating personal access token: %s", pErr)
+		}
+		return nil
+	}
+
+	return claims
+}
+
+func setClaims(c *gin.Context, claims *domain.Claims) {
+	c.Set("ClaimsId", claims.Id)
+	c.Set("ClaimsRoles", claims.Roles)
+	c.Set("ClaimsSharedAccounts", claims.SharedAccounts)
+	c.Set("ClaimsSessionId", claims.SessionId)
+	c.Set("ClaimsScopes", claims.Scopes)
+	c.Set("ClaimsExpiresAt", claims.ExpiresAt)
+	setLocale(c, claims.Locale)
+}
+
 func setAnonymous(c *gin.Context) {
 	c.Set("ClaimsId", "anonymous")
-	c.Set("ClaimsAdmin", false)
+	c.Set("ClaimsSessionId", "")
+	c.Set("ClaimsRoles", []string{})
 	c.Set("ClaimsSharedAccounts", []string{})
+	c.Set("ClaimsScopes", []string(nil))
+	c.Set("ClaimsExpiresAt", time.Time{})
+	setLocale(c, "")
+}
+
+// setLocale prefers the locale chosen in user settings over the one requested by the Accept-Language header.
+func setLocale(c *gin.Context, preferred string) {
+	if preferred != "" {
+		c.Set("ClaimsLocale", preferred)
+		return
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		c.Set("ClaimsLocale", "")
+		return
+	}
+
+	c.Set("ClaimsLocale", tags[0].String())
 }
 
 func parseAuthHeader(authHeader string) (string, error) {
@@ -0,0 +1,31 @@
+package gin
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"net/http"
+)
+
+func RegisterGraphqlRoutes(router *gin.Engine, gs domain.GraphqlService, js domain.JwtService, ps pat.PatService) {
+	router.POST("api/graphql", RequireJwtOrPat(js, ps), graphqlHandler(gs))
+}
+
+func graphqlHandler(gs domain.GraphqlService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		reqData, err := c.GetRawData()
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		var req domain.GraphqlRequest
+		if err = json.Unmarshal(reqData, &req); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, gs.Execute(c.Request.Context(), &req, getUserClaims(c)))
+	}
+}
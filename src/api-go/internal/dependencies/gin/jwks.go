@@ -0,0 +1,17 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"net/http"
+)
+
+func RegisterJwksRoutes(router *gin.Engine, jp domain.JwksProvider) {
+	router.GET(".well-known/jwks.json", jwksHandler(jp))
+}
+
+func jwksHandler(jp domain.JwksProvider) func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"keys": jp.PublicKeys()})
+	}
+}
@@ -0,0 +1,164 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"log"
+	"net/http"
+)
+
+func RegisterOidcRoutes(router *gin.Engine, ois oidc.OidcService, ss session.SessionService, js domain.JwtService, ls lockout.LockoutService) {
+	router.GET("api/oidc/providers", oidcProvidersHandler(ois))
+	router.GET("api/oidc/:provider/authorize", oidcAuthorizeHandler(ois))
+	router.POST("api/oidc/:provider/token", oidcTokenHandler(ois, ss, ls))
+	router.POST("api/oidc/:provider/link", RequireJwt(js), oidcLinkHandler(ois))
+	router.GET("api/oidc/link", RequireJwt(js), oidcListLinksHandler(ois))
+	router.DELETE("api/oidc/link/:provider", RequireJwt(js), oidcUnlinkHandler(ois))
+}
+
+func oidcProvidersHandler(ois oidc.OidcService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.JSON(OkResp(map[string]any{"providers": ois.Providers()}))
+	}
+}
+
+// oidcAuthorizeHandler starts a login. The client navigates to authorizationUrl and, once the provider redirects
+// back, posts the code and state to the token or link endpoint.
+func oidcAuthorizeHandler(ois oidc.OidcService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		authUrl, state, oErr := ois.Authorize(c.Request.Context(), c.Param("provider"), c.Query("login_hint"))
+		if oErr != nil {
+			switch oErr.Type {
+			case oidc.OidcProviderNotFoundError:
+				c.JSON(NotFoundErrResp("unknown provider"))
+			case oidc.OidcInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(oErr.Error()))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(map[string]any{"authorizationUrl": authUrl, "state": state}))
+	}
+}
+
+// oidcTokenHandler limits attempts per client address only, the account is not known before the code is exchanged.
+// Only wrong TOTP codes count as failures.
+func oidcTokenHandler(ois oidc.OidcService, ss session.SessionService, ls lockout.LockoutService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		address := c.ClientIP()
+		if !beginLockout(c, ls, "", address) {
+			return
+		}
+
+		u, oErr := ois.Login(c.Request.Context(), c.Param("provider"), c.PostForm("code"), c.PostForm("state"), c.PostForm("totp_code"))
+		if oErr != nil {
+			switch oErr.Type {
+			case oidc.OidcProviderNotFoundError:
+				releaseLockout(c, ls, "", address)
+				c.JSON(http.StatusNotFound, gin.H{"code": http.StatusNotFound, "message": "unknown provider"})
+			case oidc.OidcInvalidStateError, oidc.OidcInvalidArgumentsError, oidc.OidcAlreadyLinkedError:
+				releaseLockout(c, ls, "", address)
+				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": oErr.Error()})
+			case oidc.OidcTotpRequiredError:
+				releaseLockout(c, ls, "", address)
+				c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "totp code required", "totp_required": true})
+			case oidc.OidcIncorrectTotpError:
+				failLockout(c, ls, "", address)
+				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": "invalid totp code", "totp_required": true})
+			default:
+				releaseLockout(c, ls, "", address)
+				c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": "internal server error"})
+			}
+			return
+		}
+
+		if lErr := ls.Succeed(c.Request.Context(), "", address); lErr != nil {
+			log.Printf("error resetting sign-in attempts: %s", lErr)
+		}
+		createSession(c, ss, u)
+	}
+}
+
+type OidcCode struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+func oidcLinkHandler(ois oidc.OidcService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var codeData OidcCode
+		if err := c.ShouldBindJSON(&codeData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		link, oErr := ois.Link(c.Request.Context(), getUserClaims(c), c.Param("provider"), codeData.Code, codeData.State)
+		if oErr != nil {
+			switch oErr.Type {
+			case oidc.OidcUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			case oidc.OidcProviderNotFoundError:
+				c.JSON(NotFoundErrResp("unknown provider"))
+			case oidc.OidcInvalidStateError, oidc.OidcInvalidArgumentsError, oidc.OidcAlreadyLinkedError:
+				c.JSON(BadRequestErrResp(oErr.Error()))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(oidcLinkToMap(link)))
+	}
+}
+
+func oidcLinkToMap(l *oidc.Link) map[string]any {
+	return map[string]any{
+		"provider":  l.Provider,
+		"email":     l.Email,
+		"createdAt": l.CreatedAt,
+	}
+}
+
+func oidcListLinksHandler(ois oidc.OidcService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		links, oErr := ois.ListLinks(c.Request.Context(), getUserClaims(c))
+		if oErr != nil {
+			switch oErr.Type {
+			case oidc.OidcUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		list := make([]map[string]any, len(links))
+		for i, l := range links {
+			list[i] = oidcLinkToMap(l)
+		}
+		c.JSON(OkResp(list))
+	}
+}
+
+func oidcUnlinkHandler(ois oidc.OidcService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if oErr := ois.Unlink(c.Request.Context(), getUserClaims(c), c.Param("provider")); oErr != nil {
+			switch oErr.Type {
+			case oidc.OidcUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			case oidc.OidcNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
@@ -0,0 +1,347 @@
+package gin
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/validator"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/openapi"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenApiSpecPath is the location of the generated specification relative to the module root.
+const OpenApiSpecPath = "internal/dependencies/gin/openapi.json"
+
+//go:embed openapi.json
+var openApiSpec []byte
+
+func RegisterOpenApiRoutes(router *gin.Engine) {
+	router.GET("api/openapi.json", openApiHandler())
+}
+
+func openApiHandler() func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", openApiSpec)
+	}
+}
+
+// GenerateOpenApiSpec registers all routes without services and generates the specification from them.
+func GenerateOpenApiSpec() ([]byte, error) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewRouter(time.Second, nil)
+	RegisterUserRoutes(router, nil, nil, nil)
+	RegisterAuthRoutes(router, nil, nil, nil, nil)
+	RegisterWhRoutes(router, nil, nil, nil)
+	RegisterGraphqlRoutes(router, nil, nil, nil)
+	RegisterAuditRoutes(router, nil, nil)
+	RegisterWebhookRoutes(router, nil, nil)
+	RegisterPatRoutes(router, nil, nil)
+	RegisterOidcRoutes(router, nil, nil, nil, nil)
+	RegisterJwksRoutes(router, nil)
+	RegisterOpenApiRoutes(router)
+
+	routes := make([]openapi.Route, 0)
+	for _, r := range router.Routes() {
+		routes = append(routes, openapi.Route{Method: r.Method, Path: strings.TrimPrefix(r.Path, "/")})
+	}
+
+	spec, err := openapi.Generate("WFRP Hammergen API", "1.0.0", routes, OpenApiOperations(), validator.Aliases())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(spec, '\n'), nil
+}
+
+// The types below document responses that handlers build as maps.
+
+type userDoc struct {
+	Id              string    `json:"id"`
+	Username        string    `json:"username"`
+	EmailVerified   bool      `json:"emailVerified"`
+	PendingUsername string    `json:"pendingUsername"`
+	SharedAccounts  []string  `json:"sharedAccounts"`
+	Locale          string    `json:"locale"`
+	Admin           bool      `json:"admin"`
+	Roles           []string  `json:"roles"`
+	CreatedOn       time.Time `json:"createdOn"`
+	LastAuthOn      time.Time `json:"lastAuthOn"`
+	TotpEnabled     bool      `json:"totpEnabled"`
+	TotpRequired    bool      `json:"totpRequired"`
+}
+
+type userExportDoc struct {
+	User            userDoc                       `json:"user"`
+	Wh              map[string][]warhammer.Wh     `json:"wh"`
+	Trash           map[string][]warhammer.Wh     `json:"trash"`
+	GenerationProps []warhammer.WhGenerationProps `json:"generationProps"`
+	ExportedOn      time.Time                     `json:"exportedOn"`
+}
+
+type totpEnrollmentDoc struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+type totpRecoveryCodesDoc struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type userExistsDoc struct {
+	Exists bool `json:"exists"`
+}
+
+type tokenFormDoc struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	TotpCode string `json:"totp_code"`
+}
+
+type tokenDoc struct {
+	Code         int    `json:"code"`
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type jwksDoc struct {
+	Keys []domain.Jwk `json:"keys"`
+}
+
+type tokenRefreshFormDoc struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenLogoutDoc struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type auditEventDoc struct {
+	Id         string               `json:"id"`
+	ActorId    string               `json:"actorId"`
+	Timestamp  time.Time            `json:"timestamp"`
+	Type       string               `json:"type"`
+	ObjectType string               `json:"objectType"`
+	ObjectId   string               `json:"objectId"`
+	OwnerId    string               `json:"ownerId"`
+	Changes    []domain.FieldChange `json:"changes"`
+}
+
+type whRevisionDoc struct {
+	WhId       string    `json:"whId"`
+	Rev        int       `json:"rev"`
+	OwnerId    string    `json:"ownerId"`
+	ReplacedOn time.Time `json:"replacedOn"`
+	ReplacedBy string    `json:"replacedBy"`
+	Object     any       `json:"object,omitempty"`
+}
+
+type whRevisionDiffDoc struct {
+	Rev     int                  `json:"rev"`
+	Against string               `json:"against"`
+	Changes []domain.FieldChange `json:"changes"`
+}
+
+type whTombstoneDoc struct {
+	Id             string
+	OwnerId        string
+	Version        int
+	UpdatedAt      time.Time
+	LastModifiedBy string
+	DeletedAt      *time.Time
+}
+
+type whChangeSetDoc struct {
+	Updated []warhammer.Wh   `json:"updated"`
+	Deleted []whTombstoneDoc `json:"deleted"`
+	Removed []string         `json:"removed"`
+}
+
+type graphqlResponseDoc struct {
+	Data   map[string]any   `json:"data"`
+	Errors []map[string]any `json:"errors,omitempty"`
+}
+
+type whTranslationsResultDoc struct {
+	Updated  int      `json:"updated"`
+	NotFound []string `json:"notFound"`
+}
+
+type oidcProvidersDoc struct {
+	Providers []string `json:"providers"`
+}
+
+type oidcAuthorizeDoc struct {
+	AuthorizationUrl string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+type oidcTokenFormDoc struct {
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	TotpCode string `json:"totp_code"`
+}
+
+type oidcLinkDoc struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type webhookDoc struct {
+	Id         string    `json:"id"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Secret     string    `json:"secret,omitempty"`
+}
+
+type webhookDeliveryDoc struct {
+	Id             string    `json:"id"`
+	EventId        string    `json:"eventId"`
+	EventType      string    `json:"eventType"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	CreatedAt      time.Time `json:"createdAt"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+	LastAttemptAt  time.Time `json:"lastAttemptAt"`
+	ResponseStatus int       `json:"responseStatus"`
+	Error          string    `json:"error"`
+	Payload        string    `json:"payload"`
+}
+
+type patDoc struct {
+	Id         string    `json:"id"`
+	Name       string    `json:"name"`
+	Scopes     []string  `json:"scopes"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Token      string    `json:"token,omitempty"`
+}
+
+// OpenApiOperations documents every route registered by the Register functions of this package.
+func OpenApiOperations() map[string]*openapi.Operation {
+	ifMatch := openapi.Param{Name: "If-Match", In: "header", Description: "expected version as returned in ETag"}
+	acceptLanguage := openapi.Param{Name: "Accept-Language", In: "header", Description: "locale of translated texts, objects the caller can edit are returned untranslated"}
+	full := openapi.Param{Name: "full", In: "query", Description: "true to resolve referenced objects"}
+	render := openapi.Param{Name: "render", In: "query", Description: "html to render markdown fields"}
+	genName := openapi.Param{Name: "name", In: "query", Description: "rule set name, default if empty"}
+
+	ops := map[string]*openapi.Operation{
+		"POST api/token":         {Summary: "Issue access and refresh token, users with two-factor authentication repeat the request with totp_code. Repeated failures are delayed and locked out with 429", Tag: "auth", Form: tokenFormDoc{}, Response: tokenDoc{}, RawResponse: true},
+		"POST api/token/refresh": {Summary: "Exchange refresh token, reusing one revokes its session", Tag: "auth", Form: tokenRefreshFormDoc{}, Response: tokenDoc{}, RawResponse: true},
+		"POST api/token/logout":  {Summary: "Revoke session of refresh token or of access token", Tag: "auth", Auth: true, Form: tokenRefreshFormDoc{}, Response: tokenLogoutDoc{}, RawResponse: true},
+
+		"GET .well-known/jwks.json": {Summary: "Get public keys that tokens are signed with", Tag: "auth", Response: jwksDoc{}, RawResponse: true},
+
+		"GET api/oidc/providers": {Summary: "List identity providers", Tag: "oidc", Response: oidcProvidersDoc{}},
+		"GET api/oidc/:provider/authorize": {Summary: "Start login with identity provider", Tag: "oidc", Response: oidcAuthorizeDoc{},
+			Params: []openapi.Param{{Name: "login_hint", In: "query", Description: "optional email passed to the provider"}}},
+		"POST api/oidc/:provider/token":  {Summary: "Issue access and refresh token for authorization code, creates user on first login", Tag: "oidc", Form: oidcTokenFormDoc{}, Response: tokenDoc{}, RawResponse: true},
+		"POST api/oidc/:provider/link":   {Summary: "Link identity of authorization code to current user", Tag: "oidc", Auth: true, Request: OidcCode{}, Response: oidcLinkDoc{}},
+		"GET api/oidc/link":              {Summary: "List linked identities", Tag: "oidc", Auth: true, Response: openapi.Array{Items: oidcLinkDoc{}}},
+		"DELETE api/oidc/link/:provider": {Summary: "Unlink identity", Tag: "oidc", Auth: true, Response: ""},
+
+		"POST api/user":                      {Summary: "Create user", Tag: "user", Request: UserCreate{}, Response: userDoc{}},
+		"GET api/user":                       {Summary: "Get current user", Tag: "user", Auth: true, Response: userDoc{}},
+		"GET api/user/:userId":               {Summary: "Get user", Tag: "user", Auth: true, Response: userDoc{}},
+		"GET api/user/exists/:userName":      {Summary: "Check whether user exists", Tag: "user", Auth: true, Response: userExistsDoc{}},
+		"GET api/user/list":                  {Summary: "List users", Tag: "user", Auth: true, Response: openapi.Array{Items: userDoc{}}},
+		"PUT api/user/:userId":               {Summary: "Update user", Tag: "user", Auth: true, Request: UserUpdate{}, Response: userDoc{}},
+		"PUT api/user/credentials/:userId":   {Summary: "Update user credentials, a new username is pending until verified", Tag: "user", Auth: true, Request: UserCredentials{}, Response: userDoc{}},
+		"PUT api/user/claims/:userId":        {Summary: "Update user claims", Tag: "user", Auth: true, Request: UserClaims{}, Response: userDoc{}},
+		"DELETE api/user/:userId":            {Summary: "Delete user together with owned content and webhooks", Tag: "user", Auth: true, Response: ""},
+		"GET api/user/:userId/export":        {Summary: "Export profile and content of user", Tag: "user", Auth: true, Response: userExportDoc{}},
+		"POST api/user/send_reset_password":  {Summary: "Send password reset email", Tag: "user", Request: UserSendResetPassword{}, Response: ""},
+		"POST api/user/reset_password":       {Summary: "Reset password", Tag: "user", Request: UserResetPassword{}, Response: ""},
+		"POST api/user/send_verification":    {Summary: "Send email verification to the pending or unverified username", Tag: "user", Auth: true, Response: ""},
+		"POST api/user/verify_email":         {Summary: "Verify email, a pending username replaces the current one", Tag: "user", Request: UserVerifyEmail{}, Response: userDoc{}},
+		"POST api/user/totp/enroll":          {Summary: "Start two-factor enrollment, replacing an unconfirmed one", Tag: "user", Auth: true, Response: totpEnrollmentDoc{}},
+		"POST api/user/totp/confirm":         {Summary: "Enable two-factor authentication, the response contains recovery codes", Tag: "user", Auth: true, Request: UserTotpCode{}, Response: totpRecoveryCodesDoc{}},
+		"POST api/user/totp/disable/:userId": {Summary: "Disable two-factor authentication, admins disable it for other users without code", Tag: "user", Auth: true, Request: UserTotpCode{}, Response: ""},
+
+		"GET api/audit": {Summary: "List audit events", Tag: "audit", Auth: true, Response: openapi.Array{Items: auditEventDoc{}}, Params: []openapi.Param{
+			{Name: "actorId", In: "query"}, {Name: "objectType", In: "query"}, {Name: "objectId", In: "query"},
+			{Name: "ownerId", In: "query"}, {Name: "since", In: "query", Description: "RFC3339 timestamp"}, {Name: "limit", In: "query"},
+		}},
+
+		"POST api/webhook":                      {Summary: "Create webhook, the response contains the signing secret", Tag: "webhook", Auth: true, Request: WebhookWrite{}, Response: webhookDoc{}},
+		"GET api/webhook":                       {Summary: "List webhooks", Tag: "webhook", Auth: true, Response: openapi.Array{Items: webhookDoc{}}},
+		"GET api/webhook/:webhookId":            {Summary: "Get webhook", Tag: "webhook", Auth: true, Response: webhookDoc{}},
+		"PUT api/webhook/:webhookId":            {Summary: "Update webhook", Tag: "webhook", Auth: true, Request: WebhookWrite{}, Response: webhookDoc{}},
+		"DELETE api/webhook/:webhookId":         {Summary: "Delete webhook and its delivery log", Tag: "webhook", Auth: true, Response: ""},
+		"GET api/webhook/:webhookId/deliveries": {Summary: "List deliveries of webhook", Tag: "webhook", Auth: true, Response: openapi.Array{Items: webhookDeliveryDoc{}}, Params: []openapi.Param{{Name: "limit", In: "query"}}},
+
+		"POST api/pat":          {Summary: "Create personal access token with scopes such as character:read or item:write, the response contains the token", Tag: "pat", Auth: true, Request: PatCreate{}, Response: patDoc{}},
+		"GET api/pat":           {Summary: "List personal access tokens", Tag: "pat", Auth: true, Response: openapi.Array{Items: patDoc{}}},
+		"DELETE api/pat/:patId": {Summary: "Revoke personal access token", Tag: "pat", Auth: true, Response: ""},
+
+		"GET api/openapi.json": {Summary: "Get OpenAPI specification", Tag: "meta", RawResponse: true},
+
+		"GET api/wh/trash": {Summary: "List deleted objects", Tag: "wh", Auth: true, Response: openapi.Object{Values: openapi.Array{Items: warhammer.Wh{}}}},
+		"GET api/wh/changes": {Summary: "List objects changed since a point in time, removed lists ids of objects no longer visible. A since older than the trash retention is rejected with 410, fetch all objects again", Tag: "wh", Auth: true,
+			Response: openapi.WithField{Base: openapi.Object{Values: whChangeSetDoc{}}, Field: "asOf", Value: time.Time{}},
+			Params:   []openapi.Param{{Name: "since", In: "query", Description: "RFC3339 timestamp"}}},
+
+		"GET api/wh/generation":         {Summary: "Get generation rule set", Tag: "generation", Auth: true, Params: []openapi.Param{genName}, Response: warhammer.WhGenerationProps{}},
+		"GET api/wh/generation/list":    {Summary: "List generation rule sets", Tag: "generation", Auth: true, Response: openapi.Array{Items: warhammer.WhGenerationProps{}}},
+		"POST api/wh/generation":        {Summary: "Create generation rule set", Tag: "generation", Auth: true, Request: warhammer.WhGenerationProps{}, Response: warhammer.WhGenerationProps{}},
+		"PUT api/wh/generation":         {Summary: "Update generation rule set", Tag: "generation", Auth: true, Request: warhammer.WhGenerationProps{}, Response: warhammer.WhGenerationProps{}},
+		"DELETE api/wh/generation":      {Summary: "Delete generation rule set", Tag: "generation", Auth: true, Params: []openapi.Param{genName}, Response: ""},
+		"GET api/wh/generation/history": {Summary: "List previous versions of generation rule set", Tag: "generation", Auth: true, Params: []openapi.Param{genName}, Response: openapi.Array{Items: warhammer.WhGenerationProps{}}},
+
+		"POST api/wh/translations": {Summary: "Submit translations in bulk", Tag: "wh", Auth: true, Request: openapi.Array{Items: warhammer.WhTranslationSubmission{}}, Response: whTranslationsResultDoc{}},
+
+		"POST api/graphql": {Summary: "Query warhammer content with GraphQL", Tag: "wh", Auth: true, Request: domain.GraphqlRequest{}, Response: graphqlResponseDoc{}, RawResponse: true},
+
+		"GET api/wh/events": {Summary: "Subscribe to changes of objects over WebSocket", Tag: "wh", Auth: true, Request: WhEventsRequest{}, RawResponse: true,
+			Params: []openapi.Param{{Name: "token", In: "query", Description: "access token for clients that cannot set the Authorization header"}}},
+
+		"GET api/wh/enums":       {Summary: "List enumerations", Tag: "enum", Auth: true, Response: openapi.Array{Items: warhammer.WhEnum{}}},
+		"PUT api/wh/enums/:name": {Summary: "Update enumeration", Tag: "enum", Auth: true, Request: warhammer.WhEnum{}, Response: warhammer.WhEnum{}},
+	}
+
+	for _, t := range warhammer.WhApiTypes {
+		wh, err := warhammer.NewApiWh(t)
+		if err != nil {
+			panic(err)
+		}
+
+		object := wh.Object.InitAndCopy()
+		typed := openapi.WithField{Base: warhammer.Wh{}, Field: "Object", Value: object}
+		tag := string(t)
+		path := fmt.Sprintf("api/wh/%s", t)
+
+		ops["POST "+path] = &openapi.Operation{Summary: fmt.Sprintf("Create %s", t), Tag: tag, Auth: true, Request: object, Response: typed}
+		ops["GET "+path] = &openapi.Operation{Summary: fmt.Sprintf("List %s", t), Tag: tag, Auth: true, Response: openapi.Array{Items: typed},
+			Params: []openapi.Param{{Name: "id", In: "query", Description: "repeat to select multiple ids"}, full, render, acceptLanguage}}
+		ops["GET "+path+"/:whId"] = &openapi.Operation{Summary: fmt.Sprintf("Get %s", t), Tag: tag, Auth: true, Response: typed,
+			Params: []openapi.Param{full, render, acceptLanguage}}
+		ops["PUT "+path+"/:whId"] = &openapi.Operation{Summary: fmt.Sprintf("Update %s", t), Tag: tag, Auth: true, Request: object, Response: typed,
+			Params: []openapi.Param{ifMatch}}
+		ops["DELETE "+path+"/:whId"] = &openapi.Operation{Summary: fmt.Sprintf("Delete %s", t), Tag: tag, Auth: true, Response: ""}
+		ops["POST "+path+"/:whId/restore"] = &openapi.Operation{Summary: fmt.Sprintf("Restore deleted %s", t), Tag: tag, Auth: true, Response: typed}
+		ops["POST "+path+"/:whId/hide"] = &openapi.Operation{Summary: fmt.Sprintf("Hide shared %s, requires moderator role", t), Tag: tag, Auth: true,
+			Request: WhHide{}, Response: typed}
+		ops["POST "+path+"/:whId/unhide"] = &openapi.Operation{Summary: fmt.Sprintf("Unhide %s, requires moderator role", t), Tag: tag, Auth: true, Response: typed}
+		ops["GET "+path+"/:whId/revisions"] = &openapi.Operation{Summary: fmt.Sprintf("List %s revisions", t), Tag: tag, Auth: true,
+			Response: openapi.Array{Items: whRevisionDoc{}}}
+		ops["GET "+path+"/:whId/revisions/:rev"] = &openapi.Operation{Summary: fmt.Sprintf("Get %s revision", t), Tag: tag, Auth: true,
+			Response: openapi.WithField{Base: whRevisionDoc{}, Field: "object", Value: object}}
+		ops["GET "+path+"/:whId/revisions/:rev/diff"] = &openapi.Operation{Summary: fmt.Sprintf("Compare %s revision", t), Tag: tag, Auth: true,
+			Response: whRevisionDiffDoc{}, Params: []openapi.Param{{Name: "against", In: "query", Description: "revision number or current"}}}
+		ops["POST "+path+"/:whId/revisions/:rev/restore"] = &openapi.Operation{Summary: fmt.Sprintf("Restore %s revision", t), Tag: tag, Auth: true,
+			Response: typed}
+	}
+
+	return ops
+}
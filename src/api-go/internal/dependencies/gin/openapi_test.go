@@ -0,0 +1,17 @@
+package gin
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOpenApiSpecIsUpToDate(t *testing.T) {
+	spec, err := GenerateOpenApiSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(openApiSpec, spec) {
+		t.Fatalf("%s is out of date, run go run ./cmd/openapi", OpenApiSpecPath)
+	}
+}
@@ -0,0 +1,98 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"time"
+)
+
+func RegisterPatRoutes(router *gin.Engine, ps pat.PatService, js domain.JwtService) {
+	router.POST("api/pat", RequireJwt(js), patCreateHandler(ps))
+	router.GET("api/pat", RequireJwt(js), patListHandler(ps))
+	router.DELETE("api/pat/:patId", RequireJwt(js), patRevokeHandler(ps))
+}
+
+type PatCreate struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func patCreateHandler(ps pat.PatService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var patData PatCreate
+		if err := c.BindJSON(&patData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		t := pat.Token{Name: patData.Name, Scopes: patData.Scopes}
+		if patData.ExpiresAt != nil {
+			t.ExpiresAt = *patData.ExpiresAt
+		}
+
+		createdToken, secret, pErr := ps.Create(c.Request.Context(), getUserClaims(c), &t)
+		if pErr != nil {
+			patErrResp(c, pErr)
+			return
+		}
+
+		// The secret is only revealed once, only its hash is stored.
+		patMap := patToMap(createdToken)
+		patMap["token"] = secret
+		c.JSON(OkResp(patMap))
+	}
+}
+
+func patListHandler(ps pat.PatService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		tokens, pErr := ps.List(c.Request.Context(), getUserClaims(c))
+		if pErr != nil {
+			patErrResp(c, pErr)
+			return
+		}
+
+		list := make([]map[string]any, len(tokens))
+		for i, t := range tokens {
+			list[i] = patToMap(t)
+		}
+
+		c.JSON(OkResp(list))
+	}
+}
+
+func patRevokeHandler(ps pat.PatService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if pErr := ps.Revoke(c.Request.Context(), getUserClaims(c), c.Param("patId")); pErr != nil {
+			patErrResp(c, pErr)
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
+
+func patToMap(t *pat.Token) map[string]any {
+	return gin.H{
+		"id":         t.Id,
+		"name":       t.Name,
+		"scopes":     t.Scopes,
+		"createdAt":  t.CreatedAt,
+		"lastUsedAt": t.LastUsedAt,
+		"expiresAt":  t.ExpiresAt,
+	}
+}
+
+func patErrResp(c *gin.Context, pErr *pat.PatError) {
+	switch pErr.Type {
+	case pat.PatUnauthorizedError, pat.PatInvalidTokenError:
+		c.JSON(UnauthorizedErrResp(""))
+	case pat.PatNotFoundError:
+		c.JSON(NotFoundErrResp(""))
+	case pat.PatInvalidArgumentsError:
+		c.JSON(BadRequestErrResp(pErr.Error()))
+	default:
+		c.JSON(ServerErrResp(""))
+	}
+}
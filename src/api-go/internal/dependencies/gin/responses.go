@@ -20,6 +20,18 @@ func BadRequestErrResp(details string) (int, *map[string]any) {
 	return http.StatusNotFound, &map[string]any{"message": "bad request", "details": details}
 }
 
+func PreconditionFailedErrResp(details string) (int, *map[string]any) {
+	return http.StatusPreconditionFailed, &map[string]any{"message": "precondition failed", "details": details}
+}
+
+func GoneErrResp(details string) (int, *map[string]any) {
+	return http.StatusGone, &map[string]any{"message": "gone", "details": details}
+}
+
+func PreconditionRequiredErrResp(details string) (int, *map[string]any) {
+	return http.StatusPreconditionRequired, &map[string]any{"message": "precondition required", "details": details}
+}
+
 func OkResp[M map[string]any | []map[string]any | string](data M) (int, *map[string]any) {
 	return http.StatusOK, &map[string]any{"data": data}
 }
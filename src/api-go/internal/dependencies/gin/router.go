@@ -7,16 +7,31 @@ import (
 	"time"
 )
 
-func NewRouter(requestTimeout time.Duration) *gin.Engine {
+// NewRouter returns a router that takes the client address from the X-Forwarded-For header only for requests coming
+// from trustedProxies.
+func NewRouter(requestTimeout time.Duration, trustedProxies []string) *gin.Engine {
 	router := gin.New()
 
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		panic(err)
+	}
+
 	router.Use(gin.Recovery())
 
-	router.Use(timeout.Timeout(
+	requestTimeoutHandler := timeout.Timeout(
 		timeout.WithTimeout(requestTimeout),
 		timeout.WithErrorHttpCode(http.StatusServiceUnavailable),
 		timeout.WithDefaultMsg(`{"message":"internal server timeout", "details": ""}`),
-	))
+	)
+
+	// WebSocket connections are long-lived and need the connection to be hijacked, which the timeout writer prevents.
+	router.Use(func(c *gin.Context) {
+		if c.IsWebsocket() {
+			c.Next()
+			return
+		}
+		requestTimeoutHandler(c)
+	})
 
 	return router
 }
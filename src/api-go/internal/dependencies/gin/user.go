@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/exp/slices"
 	"time"
 )
 
@@ -17,8 +18,14 @@ func RegisterUserRoutes(router *gin.Engine, us user.UserService, js domain.JwtSe
 	router.PUT("api/user/credentials/:userId", RequireJwt(js), userUpdateCredentialsHandler(us))
 	router.PUT("api/user/claims/:userId", RequireJwt(js), userUpdateClaimsHandler(us))
 	router.DELETE("api/user/:userId", RequireJwt(js), userDeleteHandler(us))
+	router.GET("api/user/:userId/export", RequireJwt(js), userExportHandler(us))
 	router.POST("api/user/send_reset_password", resetSendPasswordHandler(us, cs))
 	router.POST("api/user/reset_password", resetPasswordHandler(us))
+	router.POST("api/user/send_verification", RequireJwt(js), sendVerifyEmailHandler(us))
+	router.POST("api/user/verify_email", verifyEmailHandler(us))
+	router.POST("api/user/totp/enroll", RequireJwt(js), totpEnrollHandler(us))
+	router.POST("api/user/totp/confirm", RequireJwt(js), totpConfirmHandler(us))
+	router.POST("api/user/totp/disable/:userId", RequireJwt(js), totpDisableHandler(us))
 }
 
 type UserCreate struct {
@@ -54,7 +61,7 @@ func userCreateHandler(us user.UserService, cs domain.CaptchaService) func(*gin.
 			switch uErr.Type {
 			case user.UserAlreadyExistsError:
 				c.JSON(BadRequestErrResp("user already exists"))
-			case user.UserInvalidArgumentsError:
+			case user.UserInvalidArgumentsError, user.UserEmailNotVerifiedError:
 				c.JSON(BadRequestErrResp(uErr.Error()))
 			default:
 				c.JSON(ServerErrResp(""))
@@ -68,12 +75,18 @@ func userCreateHandler(us user.UserService, cs domain.CaptchaService) func(*gin.
 
 func userToMap(u *user.User) map[string]any {
 	return map[string]any{
-		"id":             u.Id,
-		"username":       u.Username,
-		"sharedAccounts": u.SharedAccountNames,
-		"admin":          u.Admin,
-		"createdOn":      u.CreatedOn,
-		"lastAuthOn":     u.LastAuthOn,
+		"id":              u.Id,
+		"username":        u.Username,
+		"emailVerified":   u.EmailVerified,
+		"pendingUsername": u.PendingUsername,
+		"sharedAccounts":  u.SharedAccountNames,
+		"locale":          u.Locale,
+		"roles":           u.Roles,
+		"admin":           slices.Contains(u.Roles, domain.RoleAdmin),
+		"createdOn":       u.CreatedOn,
+		"lastAuthOn":      u.LastAuthOn,
+		"totpEnabled":     u.Totp.Enabled,
+		"totpRequired":    u.Totp.Required,
 	}
 }
 
@@ -104,14 +117,75 @@ func userGetHandler(us user.UserService) func(*gin.Context) {
 	}
 }
 
+func userExportHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		userId := c.Param("userId")
+		claims := getUserClaims(c)
+
+		export, uErr := us.Export(c.Request.Context(), claims, userId)
+		if uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		whData := make(map[string]any, len(export.Wh))
+		trashData := make(map[string]any, len(export.Trash))
+		for t, whs := range export.Wh {
+			list, err := whListToListMap(whs)
+			if err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+			whData[string(t)] = list
+		}
+		for t, whs := range export.Trash {
+			list, err := whListToListMap(whs)
+			if err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+			trashData[string(t)] = list
+		}
+
+		generationProps, err := generationPropsListToListMap(export.GenerationProps)
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.Header("Content-Disposition", "attachment; filename=\"hammergen-export.json\"")
+		c.JSON(OkResp(map[string]any{
+			"user":            userToMap(export.User),
+			"wh":              whData,
+			"trash":           trashData,
+			"generationProps": generationProps,
+			"exportedOn":      export.ExportedOn,
+		}))
+	}
+}
+
 func getUserClaims(c *gin.Context) *domain.Claims {
 	var claims domain.Claims
 
 	claims.Id = c.GetString("ClaimsId")
-	claims.Admin = c.GetBool("ClaimsAdmin")
+	rolesRaw, _ := c.Get("ClaimsRoles")
+	claims.Roles, _ = rolesRaw.([]string)
 
 	sharedAccountsRaw, _ := c.Get("ClaimsSharedAccounts")
 	claims.SharedAccounts, _ = sharedAccountsRaw.([]string)
+	claims.Locale = c.GetString("ClaimsLocale")
+	claims.SessionId = c.GetString("ClaimsSessionId")
+
+	scopesRaw, _ := c.Get("ClaimsScopes")
+	claims.Scopes, _ = scopesRaw.([]string)
+	claims.ExpiresAt = c.GetTime("ClaimsExpiresAt")
 
 	return &claims
 }
@@ -152,12 +226,18 @@ func usersToListOfMaps(users []*user.User) []map[string]any {
 
 	for i, v := range users {
 		list[i] = gin.H{
-			"id":             v.Id,
-			"username":       v.Username,
-			"sharedAccounts": v.SharedAccountNames,
-			"admin":          v.Admin,
-			"createdOn":      v.CreatedOn,
-			"lastAuthOn":     v.LastAuthOn,
+			"id":              v.Id,
+			"username":        v.Username,
+			"emailVerified":   v.EmailVerified,
+			"pendingUsername": v.PendingUsername,
+			"sharedAccounts":  v.SharedAccountNames,
+			"locale":          v.Locale,
+			"roles":           v.Roles,
+			"admin":           slices.Contains(v.Roles, domain.RoleAdmin),
+			"createdOn":       v.CreatedOn,
+			"lastAuthOn":      v.LastAuthOn,
+			"totpEnabled":     v.Totp.Enabled,
+			"totpRequired":    v.Totp.Required,
 		}
 	}
 
@@ -166,6 +246,7 @@ func usersToListOfMaps(users []*user.User) []map[string]any {
 
 type UserUpdate struct {
 	SharedAccounts []string `json:"sharedAccounts"`
+	Locale         string   `json:"locale"`
 }
 
 func userUpdateHandler(users user.UserService) func(*gin.Context) {
@@ -182,13 +263,14 @@ func userUpdateHandler(users user.UserService) func(*gin.Context) {
 		u := user.EmptyUser()
 		u.Id = userId
 		u.SharedAccountNames = userData.SharedAccounts
+		u.Locale = userData.Locale
 
 		userRead, uErr := users.Update(c.Request.Context(), claims, &u)
 		if uErr != nil {
 			switch uErr.Type {
 			case user.UserNotFoundError:
 				c.JSON(NotFoundErrResp(""))
-			case user.UserInvalidArgumentsError:
+			case user.UserInvalidArgumentsError, user.UserEmailNotVerifiedError:
 				c.JSON(BadRequestErrResp(uErr.Error()))
 			case user.UserUnauthorizedError:
 				c.JSON(UnauthorizedErrResp(""))
@@ -233,6 +315,8 @@ func userUpdateCredentialsHandler(us user.UserService) func(*gin.Context) {
 				c.JSON(BadRequestErrResp(uErr.Error()))
 			case user.UserIncorrectPasswordError:
 				c.JSON(BadRequestErrResp("incorrect password"))
+			case user.UserAlreadyExistsError:
+				c.JSON(BadRequestErrResp("user already exists"))
 			case user.UserUnauthorizedError:
 				c.JSON(UnauthorizedErrResp(""))
 			default:
@@ -245,8 +329,10 @@ func userUpdateCredentialsHandler(us user.UserService) func(*gin.Context) {
 	}
 }
 
+// UserClaims replaces the roles and the TOTP requirement of a user, each is left unchanged if missing.
 type UserClaims struct {
-	Admin bool `json:"admin"`
+	Roles        []string `json:"roles"`
+	TotpRequired *bool    `json:"totpRequired"`
 }
 
 func userUpdateClaimsHandler(us user.UserService) func(*gin.Context) {
@@ -262,9 +348,9 @@ func userUpdateClaimsHandler(us user.UserService) func(*gin.Context) {
 
 		u := user.EmptyUser()
 		u.Id = userId
-		u.Admin = userData.Admin
+		u.Roles = userData.Roles
 
-		userRead, uErr := us.UpdateClaims(c.Request.Context(), claims, &u)
+		userRead, uErr := us.UpdateClaims(c.Request.Context(), claims, &u, userData.TotpRequired)
 		if uErr != nil {
 			switch uErr.Type {
 			case user.UserNotFoundError:
@@ -329,6 +415,8 @@ func resetSendPasswordHandler(us user.UserService, cs domain.CaptchaService) fun
 				c.JSON(BadRequestErrResp(uErr.Error()))
 			case user.UserNotFoundError:
 				c.JSON(NotFoundErrResp(""))
+			case user.UserEmailNotVerifiedError:
+				c.JSON(BadRequestErrResp("email not verified"))
 			case user.UserSendEmailError:
 				c.JSON(ServerErrResp(""))
 			default:
@@ -367,3 +455,134 @@ func resetPasswordHandler(us user.UserService) func(*gin.Context) {
 	}
 
 }
+
+func sendVerifyEmailHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if uErr := us.SendVerifyEmail(c.Request.Context(), getUserClaims(c)); uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(uErr.Error()))
+			case user.UserUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
+
+type UserVerifyEmail struct {
+	Token string `json:"token"`
+}
+
+func verifyEmailHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var tokenData UserVerifyEmail
+		if err := c.ShouldBindJSON(&tokenData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		userRead, uErr := us.VerifyEmail(c.Request.Context(), tokenData.Token)
+		if uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(uErr.Error()))
+			case user.UserAlreadyExistsError:
+				c.JSON(BadRequestErrResp("user already exists"))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(userToMap(userRead)))
+	}
+}
+
+func totpEnrollHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		enrollment, uErr := us.EnrollTotp(c.Request.Context(), getUserClaims(c))
+		if uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(uErr.Error()))
+			case user.UserUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(map[string]any{"secret": enrollment.Secret, "uri": enrollment.Uri}))
+	}
+}
+
+type UserTotpCode struct {
+	Code string `json:"code"`
+}
+
+func totpConfirmHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var codeData UserTotpCode
+		if err := c.ShouldBindJSON(&codeData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		recoveryCodes, uErr := us.ConfirmTotp(c.Request.Context(), getUserClaims(c), codeData.Code)
+		if uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserInvalidArgumentsError:
+				c.JSON(BadRequestErrResp(uErr.Error()))
+			case user.UserIncorrectTotpError:
+				c.JSON(BadRequestErrResp("incorrect totp code"))
+			case user.UserUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(map[string]any{"recoveryCodes": recoveryCodes}))
+	}
+}
+
+func totpDisableHandler(us user.UserService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var codeData UserTotpCode
+		if err := c.ShouldBindJSON(&codeData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		if uErr := us.DisableTotp(c.Request.Context(), getUserClaims(c), c.Param("userId"), codeData.Code); uErr != nil {
+			switch uErr.Type {
+			case user.UserNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case user.UserIncorrectTotpError:
+				c.JSON(BadRequestErrResp("incorrect totp code"))
+			case user.UserUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
@@ -0,0 +1,163 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"strconv"
+)
+
+func RegisterWebhookRoutes(router *gin.Engine, ws webhook.WebhookService, js domain.JwtService) {
+	router.POST("api/webhook", RequireJwt(js), webhookCreateOrUpdateHandler(true, ws))
+	router.GET("api/webhook", RequireJwt(js), webhookListHandler(ws))
+	router.GET("api/webhook/:webhookId", RequireJwt(js), webhookGetHandler(ws))
+	router.PUT("api/webhook/:webhookId", RequireJwt(js), webhookCreateOrUpdateHandler(false, ws))
+	router.DELETE("api/webhook/:webhookId", RequireJwt(js), webhookDeleteHandler(ws))
+	router.GET("api/webhook/:webhookId/deliveries", RequireJwt(js), webhookDeliveriesHandler(ws))
+}
+
+type WebhookWrite struct {
+	Url        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Active     *bool    `json:"active"`
+}
+
+func webhookCreateOrUpdateHandler(isCreate bool, ws webhook.WebhookService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var webhookData WebhookWrite
+		if err := c.BindJSON(&webhookData); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		w := webhook.Webhook{
+			Url:        webhookData.Url,
+			EventTypes: webhookData.EventTypes,
+			Active:     webhookData.Active == nil || *webhookData.Active,
+		}
+		claims := getUserClaims(c)
+
+		var webhookRead *webhook.Webhook
+		var wErr *webhook.WebhookError
+		if isCreate {
+			webhookRead, wErr = ws.Create(c.Request.Context(), claims, &w)
+		} else {
+			w.Id = c.Param("webhookId")
+			webhookRead, wErr = ws.Update(c.Request.Context(), claims, &w)
+		}
+
+		if wErr != nil {
+			webhookErrResp(c, wErr)
+			return
+		}
+
+		// The secret is only revealed once, receivers need it to verify signatures.
+		c.JSON(OkResp(webhookToMap(webhookRead, isCreate)))
+	}
+}
+
+func webhookGetHandler(ws webhook.WebhookService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		w, wErr := ws.Get(c.Request.Context(), getUserClaims(c), c.Param("webhookId"))
+		if wErr != nil {
+			webhookErrResp(c, wErr)
+			return
+		}
+
+		c.JSON(OkResp(webhookToMap(w, false)))
+	}
+}
+
+func webhookListHandler(ws webhook.WebhookService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		webhooks, wErr := ws.List(c.Request.Context(), getUserClaims(c))
+		if wErr != nil {
+			webhookErrResp(c, wErr)
+			return
+		}
+
+		list := make([]map[string]any, len(webhooks))
+		for i, w := range webhooks {
+			list[i] = webhookToMap(w, false)
+		}
+
+		c.JSON(OkResp(list))
+	}
+}
+
+func webhookDeleteHandler(ws webhook.WebhookService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if wErr := ws.Delete(c.Request.Context(), getUserClaims(c), c.Param("webhookId")); wErr != nil {
+			webhookErrResp(c, wErr)
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
+
+func webhookDeliveriesHandler(ws webhook.WebhookService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		var limit int
+		if limitStr := c.Query("limit"); limitStr != "" {
+			var err error
+			if limit, err = strconv.Atoi(limitStr); err != nil {
+				c.JSON(BadRequestErrResp("invalid limit"))
+				return
+			}
+		}
+
+		deliveries, wErr := ws.ListDeliveries(c.Request.Context(), getUserClaims(c), c.Param("webhookId"), limit)
+		if wErr != nil {
+			webhookErrResp(c, wErr)
+			return
+		}
+
+		list := make([]map[string]any, len(deliveries))
+		for i, d := range deliveries {
+			list[i] = gin.H{
+				"id":             d.Id,
+				"eventId":        d.EventId,
+				"eventType":      d.EventType,
+				"status":         d.Status,
+				"attempts":       d.Attempts,
+				"createdAt":      d.CreatedAt,
+				"nextAttemptAt":  d.NextAttemptAt,
+				"lastAttemptAt":  d.LastAttemptAt,
+				"responseStatus": d.ResponseStatus,
+				"error":          d.Error,
+				"payload":        d.Payload,
+			}
+		}
+
+		c.JSON(OkResp(list))
+	}
+}
+
+func webhookToMap(w *webhook.Webhook, withSecret bool) map[string]any {
+	webhookMap := gin.H{
+		"id":         w.Id,
+		"url":        w.Url,
+		"eventTypes": w.EventTypes,
+		"active":     w.Active,
+		"createdAt":  w.CreatedAt,
+		"updatedAt":  w.UpdatedAt,
+	}
+	if withSecret {
+		webhookMap["secret"] = w.Secret
+	}
+	return webhookMap
+}
+
+func webhookErrResp(c *gin.Context, wErr *webhook.WebhookError) {
+	switch wErr.Type {
+	case webhook.WebhookUnauthorizedError:
+		c.JSON(UnauthorizedErrResp(""))
+	case webhook.WebhookNotFoundError:
+		c.JSON(NotFoundErrResp(""))
+	case webhook.WebhookInvalidArgumentsError:
+		c.JSON(BadRequestErrResp(wErr.Error()))
+	default:
+		c.JSON(ServerErrResp(""))
+	}
+}
@@ -2,23 +2,51 @@ package gin
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
 	"golang.org/x/exp/slices"
+	"strconv"
+	"strings"
 )
 
-func RegisterWhRoutes(router *gin.Engine, ms warhammer.WhService, js domain.JwtService) {
+func RegisterWhRoutes(router *gin.Engine, ms warhammer.WhService, js domain.JwtService, ps pat.PatService) {
+	auth := RequireJwtOrPat(js, ps)
+
 	for _, v := range warhammer.WhApiTypes {
-		router.POST(fmt.Sprintf("api/wh/%s", v), RequireJwt(js), whCreateOrUpdateHandler(true, ms, v))
-		router.GET(fmt.Sprintf("api/wh/%s/:whId", v), RequireJwt(js), whGetHandler(ms, v))
-		router.PUT(fmt.Sprintf("api/wh/%s/:whId", v), RequireJwt(js), whCreateOrUpdateHandler(false, ms, v))
-		router.DELETE(fmt.Sprintf("api/wh/%s/:whId", v), RequireJwt(js), whDeleteHandler(ms, v))
-		router.GET(fmt.Sprintf("api/wh/%s", v), RequireJwt(js), whListHandler(ms, v))
+		router.POST(fmt.Sprintf("api/wh/%s", v), auth, whCreateOrUpdateHandler(true, ms, v))
+		router.GET(fmt.Sprintf("api/wh/%s/:whId", v), auth, whGetHandler(ms, v))
+		router.PUT(fmt.Sprintf("api/wh/%s/:whId", v), auth, whCreateOrUpdateHandler(false, ms, v))
+		router.DELETE(fmt.Sprintf("api/wh/%s/:whId", v), auth, whDeleteHandler(ms, v))
+		router.GET(fmt.Sprintf("api/wh/%s", v), auth, whListHandler(ms, v))
+		router.GET(fmt.Sprintf("api/wh/%s/:whId/revisions", v), auth, whRevisionListHandler(ms, v))
+		router.GET(fmt.Sprintf("api/wh/%s/:whId/revisions/:rev", v), auth, whRevisionGetHandler(ms, v))
+		router.GET(fmt.Sprintf("api/wh/%s/:whId/revisions/:rev/diff", v), auth, whRevisionDiffHandler(ms, v))
+		router.POST(fmt.Sprintf("api/wh/%s/:whId/revisions/:rev/restore", v), auth, whRevisionRestoreHandler(ms, v))
+		router.POST(fmt.Sprintf("api/wh/%s/:whId/restore", v), auth, whRestoreHandler(ms, v))
+		router.POST(fmt.Sprintf("api/wh/%s/:whId/hide", v), auth, whHideHandler(ms, v, true))
+		router.POST(fmt.Sprintf("api/wh/%s/:whId/unhide", v), auth, whHideHandler(ms, v, false))
 	}
 
-	router.GET("api/wh/generation", whGenerationPropsHandler(ms))
+	router.GET("api/wh/trash", auth, whTrashHandler(ms))
+	router.GET("api/wh/changes", auth, whChangesHandler(ms))
+
+	router.GET("api/wh/generation", auth, whGenerationPropsHandler(ms))
+	router.GET("api/wh/generation/list", auth, whGenerationPropsListHandler(ms))
+	router.POST("api/wh/generation", auth, whGenerationPropsCreateOrUpdateHandler(true, ms))
+	router.PUT("api/wh/generation", auth, whGenerationPropsCreateOrUpdateHandler(false, ms))
+	router.DELETE("api/wh/generation", auth, whGenerationPropsDeleteHandler(ms))
+	router.GET("api/wh/generation/history", auth, whGenerationPropsHistoryHandler(ms))
+
+	router.POST("api/wh/translations", auth, whTranslationsSubmitHandler(ms))
+
+	router.GET("api/wh/enums", auth, whEnumListHandler(ms))
+	router.PUT("api/wh/enums/:name", auth, whEnumUpdateHandler(ms))
+
+	router.GET("api/wh/events", tokenFromQuery(), auth, whEventsHandler(ms, js, ps))
 }
 
 func whCreateOrUpdateHandler(isCreate bool, s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
@@ -47,21 +75,23 @@ func whCreateOrUpdateHandler(isCreate bool, s warhammer.WhService, t warhammer.W
 		if isCreate {
 			whRead, whErr = s.Create(c.Request.Context(), t, &whWrite, claims)
 		} else {
+			version, ok, err := requestedVersion(c, reqData)
+			if err != nil {
+				c.JSON(BadRequestErrResp(err.Error()))
+				return
+			}
+			if !ok {
+				c.JSON(PreconditionRequiredErrResp("If-Match header or version is required"))
+				return
+			}
+
 			whWrite.Id = c.Param("whId")
+			whWrite.Version = version
 			whRead, whErr = s.Update(c.Request.Context(), t, &whWrite, claims)
 		}
 
 		if whErr != nil {
-			switch whErr.ErrType {
-			case warhammer.WhInvalidArgumentsError:
-				c.JSON(BadRequestErrResp(whErr.Error()))
-			case warhammer.WhUnauthorizedError:
-				c.JSON(UnauthorizedErrResp(""))
-			case warhammer.WhNotFoundError:
-				c.JSON(NotFoundErrResp(""))
-			default:
-				c.JSON(ServerErrResp(""))
-			}
+			whErrResp(c, whErr)
 			return
 		}
 
@@ -71,6 +101,7 @@ func whCreateOrUpdateHandler(isCreate bool, s warhammer.WhService, t warhammer.W
 			return
 		}
 
+		c.Header("ETag", whRead.ETag())
 		c.JSON(OkResp(returnData))
 	}
 }
@@ -85,28 +116,79 @@ func whGetHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context)
 			full = true
 		}
 
+		render, err := renderRequested(c)
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
 		wh, whErr := s.Get(c.Request.Context(), t, claims, full, []string{whId})
 
 		if whErr != nil {
 			switch whErr.ErrType {
 			case warhammer.WhNotFoundError:
 				c.JSON(NotFoundErrResp(""))
+			case warhammer.WhUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
 			default:
 				c.JSON(ServerErrResp(""))
 			}
 			return
 		}
 
+		if render {
+			if whErr = s.Render(c.Request.Context(), wh, claims); whErr != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+		}
+
 		returnData, err := wh[0].ToMap()
 		if err != nil {
 			c.JSON(ServerErrResp(""))
 			return
 		}
 
+		c.Header("ETag", wh[0].ETag())
 		c.JSON(OkResp(returnData))
 	}
 }
 
+// renderRequested reports whether markdown fields should be returned rendered as HTML.
+func renderRequested(c *gin.Context) (bool, error) {
+	switch c.Query("render") {
+	case "":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, errors.New("invalid render format")
+	}
+}
+
+// requestedVersion reads the expected version from the If-Match header, falling back to a version field in the body.
+func requestedVersion(c *gin.Context, reqData []byte) (int, bool, error) {
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+		if err != nil {
+			return 0, false, errors.New("invalid If-Match header")
+		}
+		return version, true, nil
+	}
+
+	var body struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(reqData, &body); err != nil {
+		return 0, false, err
+	}
+	if body.Version == nil {
+		return 0, false, nil
+	}
+
+	return *body.Version, true, nil
+}
+
 func whListToListMap(whs []*warhammer.Wh) ([]map[string]any, error) {
 	list := make([]map[string]any, len(whs))
 
@@ -129,12 +211,7 @@ func whDeleteHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Contex
 		whErr := s.Delete(c.Request.Context(), t, whId, claims)
 
 		if whErr != nil {
-			switch whErr.ErrType {
-			case warhammer.WhUnauthorizedError:
-				c.JSON(UnauthorizedErrResp(""))
-			default:
-				c.JSON(ServerErrResp(""))
-			}
+			whErrResp(c, whErr)
 			return
 		}
 
@@ -152,43 +229,34 @@ func whListHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context)
 			full = true
 		}
 
+		render, err := renderRequested(c)
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
 		whs, whErr := s.Get(c.Request.Context(), t, claims, full, ids)
 
 		if whErr != nil {
 			switch whErr.ErrType {
 			case warhammer.WhNotFoundError:
 				c.JSON(NotFoundErrResp(""))
+			case warhammer.WhUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
 			default:
 				c.JSON(ServerErrResp(""))
 			}
 			return
 		}
 
-		returnData, err := whListToListMap(whs)
-		if err != nil {
-			c.JSON(ServerErrResp(""))
-			return
-		}
-
-		c.JSON(OkResp(returnData))
-	}
-}
-
-func whGenerationPropsHandler(s warhammer.WhService) func(*gin.Context) {
-	return func(c *gin.Context) {
-		generationPropsMap, whErr := s.GetGenerationProps(c.Request.Context())
-
-		if whErr != nil {
-			switch whErr.ErrType {
-			case warhammer.WhNotFoundError:
-				c.JSON(NotFoundErrResp(""))
-			default:
+		if render {
+			if whErr = s.Render(c.Request.Context(), whs, claims); whErr != nil {
 				c.JSON(ServerErrResp(""))
+				return
 			}
-			return
 		}
 
-		returnData, err := generationPropsMap.ToMap()
+		returnData, err := whListToListMap(whs)
 		if err != nil {
 			c.JSON(ServerErrResp(""))
 			return
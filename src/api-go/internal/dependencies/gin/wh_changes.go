@@ -0,0 +1,65 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"time"
+)
+
+func whChangesHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		var since time.Time
+		if sinceStr := c.Query("since"); sinceStr != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339Nano, sinceStr); err != nil {
+				c.JSON(BadRequestErrResp("invalid since, expected RFC3339 timestamp"))
+				return
+			}
+		}
+
+		// asOf is taken before querying so that changes made while the query runs are picked up by the next sync.
+		asOf := time.Now().UTC()
+
+		changes, whErr := s.GetChanges(c.Request.Context(), claims, since)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData := map[string]any{"asOf": asOf.Format(time.RFC3339Nano)}
+		for t, whs := range changes {
+			updated := make([]*warhammer.Wh, 0)
+			deleted := make([]map[string]any, 0)
+			for _, v := range whs.Changed {
+				if v.IsDeleted() {
+					deleted = append(deleted, whTombstone(v))
+				} else {
+					updated = append(updated, v)
+				}
+			}
+
+			updatedList, err := whListToListMap(updated)
+			if err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+
+			returnData[string(t)] = map[string]any{"updated": updatedList, "deleted": deleted, "removed": whs.Removed}
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whTombstone(w *warhammer.Wh) map[string]any {
+	return map[string]any{
+		"Id":             w.Id,
+		"OwnerId":        w.OwnerId,
+		"Version":        w.Version,
+		"UpdatedAt":      w.UpdatedAt,
+		"LastModifiedBy": w.LastModifiedBy,
+		"DeletedAt":      w.DeletedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package gin
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+func whEnumListHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		enums, whErr := s.GetEnums(c.Request.Context())
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData := make([]map[string]any, len(enums))
+		for i, v := range enums {
+			var err error
+			if returnData[i], err = v.ToMap(); err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whEnumUpdateHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		reqData, err := c.GetRawData()
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		var e warhammer.WhEnum
+		if err = json.Unmarshal(reqData, &e); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+		e.Name = c.Param("name")
+
+		claims := getUserClaims(c)
+
+		updatedEnum, whErr := s.UpdateEnum(c.Request.Context(), &e, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := updatedEnum.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
@@ -0,0 +1,174 @@
+package gin
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"time"
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPingInterval = 30 * time.Second
+	wsReadTimeout  = 2 * wsPingInterval
+)
+
+var wsUpgrader = websocket.Upgrader{
+	// Requests are authorized by the token, not by cookies, so cross-origin connections are safe to accept.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// WhEventsRequest changes the subscription of a connection, Action is either subscribe or unsubscribe.
+type WhEventsRequest struct {
+	Action string             `json:"action"`
+	Ids    []string           `json:"ids"`
+	Types  []warhammer.WhType `json:"types"`
+}
+
+// tokenFromQuery lets browsers, which cannot set headers on WebSocket requests, pass the token as a query parameter.
+func tokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+// whEventsHandler closes the connection when the token expires and, on every ping, when it was revoked or its owner
+// deleted, so that a subscription never outlives the credentials it was opened with.
+func whEventsHandler(s warhammer.WhService, js domain.JwtService, ps pat.PatService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// Upgrade has already responded with an error.
+			return
+		}
+		defer conn.Close()
+
+		claims := getUserClaims(c)
+		token, _ := parseAuthHeader(c.Request.Header.Get("Authorization"))
+
+		sub := s.Subscribe(claims)
+		defer s.Unsubscribe(sub)
+
+		var expired <-chan time.Time
+		if !claims.ExpiresAt.IsZero() {
+			expiry := time.NewTimer(time.Until(claims.ExpiresAt))
+			defer expiry.Stop()
+			expired = expiry.C
+		}
+
+		requests := make(chan *WhEventsRequest)
+		go readWhEventsRequests(conn, requests)
+
+		ping := time.NewTicker(wsPingInterval)
+		defer ping.Stop()
+
+		for {
+			var msg any
+			select {
+			case req, ok := <-requests:
+				if !ok {
+					return
+				}
+				msg = handleWhEventsRequest(sub, req)
+			case e, ok := <-sub.Events:
+				if !ok {
+					closeWhEvents(conn, "too many pending events")
+					return
+				}
+				if msg, err = whEventToMap(e); err != nil {
+					return
+				}
+			case <-expired:
+				closeWhEvents(conn, "token expired")
+				return
+			case <-ping.C:
+				if claims.Id != "anonymous" && authenticateJwtOrPat(c.Request.Context(), js, ps, token) == nil {
+					closeWhEvents(conn, "token revoked")
+					return
+				}
+				if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+					return
+				}
+				continue
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err = conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func closeWhEvents(conn *websocket.Conn, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+		time.Now().Add(wsWriteTimeout))
+}
+
+// readWhEventsRequests closes requests when the connection is closed by the client or stops answering pings.
+func readWhEventsRequests(conn *websocket.Conn, requests chan<- *WhEventsRequest) {
+	defer close(requests)
+
+	_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req WhEventsRequest
+		if err = json.Unmarshal(data, &req); err != nil {
+			// An empty action is answered with an error, the connection stays open.
+			req = WhEventsRequest{}
+		}
+		requests <- &req
+	}
+}
+
+func handleWhEventsRequest(sub *warhammer.WhSubscription, req *WhEventsRequest) map[string]any {
+	for _, t := range req.Types {
+		if !slices.Contains(warhammer.WhApiTypes, t) {
+			return map[string]any{"error": fmt.Sprintf("invalid type %s", t)}
+		}
+	}
+
+	switch req.Action {
+	case "subscribe":
+		sub.Add(req.Ids, req.Types)
+	case "unsubscribe":
+		sub.Remove(req.Ids, req.Types)
+	default:
+		return map[string]any{"error": "invalid request, expected action subscribe or unsubscribe"}
+	}
+
+	ids, types := sub.List()
+	slices.Sort(ids)
+	slices.Sort(types)
+	return map[string]any{"subscriptions": map[string]any{"ids": ids, "types": types}}
+}
+
+func whEventToMap(e *warhammer.WhEvent) (map[string]any, error) {
+	eventMap := map[string]any{"event": e.Type, "type": e.WhType, "id": e.Wh.Id}
+	if e.Type == warhammer.WhEventDeleted {
+		return eventMap, nil
+	}
+
+	whMap, err := e.Wh.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	eventMap["object"] = whMap
+	return eventMap, nil
+}
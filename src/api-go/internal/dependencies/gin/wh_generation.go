@@ -0,0 +1,140 @@
+package gin
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+func whGenerationPropsHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		generationPropsMap, whErr := s.GetGenerationProps(c.Request.Context(), c.Query("name"), claims)
+
+		if whErr != nil {
+			switch whErr.ErrType {
+			case warhammer.WhNotFoundError:
+				c.JSON(NotFoundErrResp(""))
+			case warhammer.WhUnauthorizedError:
+				c.JSON(UnauthorizedErrResp(""))
+			default:
+				c.JSON(ServerErrResp(""))
+			}
+			return
+		}
+
+		returnData, err := generationPropsMap.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whGenerationPropsListHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		list, whErr := s.ListGenerationProps(c.Request.Context(), claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := generationPropsListToListMap(list)
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whGenerationPropsCreateOrUpdateHandler(isCreate bool, s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		reqData, err := c.GetRawData()
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		var gp warhammer.WhGenerationProps
+		if err = json.Unmarshal(reqData, &gp); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		claims := getUserClaims(c)
+
+		var updatedGp *warhammer.WhGenerationProps
+		var whErr *warhammer.WhError
+		if isCreate {
+			updatedGp, whErr = s.CreateGenerationProps(c.Request.Context(), &gp, claims)
+		} else {
+			updatedGp, whErr = s.UpdateGenerationProps(c.Request.Context(), &gp, claims)
+		}
+
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := updatedGp.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whGenerationPropsHistoryHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		history, whErr := s.GetGenerationPropsHistory(c.Request.Context(), c.Query("name"), claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := generationPropsListToListMap(history)
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whGenerationPropsDeleteHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		if whErr := s.DeleteGenerationProps(c.Request.Context(), c.Query("name"), claims); whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		c.JSON(OkResp(""))
+	}
+}
+
+func generationPropsListToListMap(list []*warhammer.WhGenerationProps) ([]map[string]any, error) {
+	listMap := make([]map[string]any, len(list))
+
+	var err error
+	for i, v := range list {
+		if listMap[i], err = v.ToMap(); err != nil {
+			return nil, err
+		}
+	}
+
+	return listMap, nil
+}
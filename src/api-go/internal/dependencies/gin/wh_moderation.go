@@ -0,0 +1,43 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+type WhHide struct {
+	Reason string `json:"reason"`
+}
+
+func whHideHandler(s warhammer.WhService, t warhammer.WhType, hide bool) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		var updatedWh *warhammer.Wh
+		var whErr *warhammer.WhError
+		if hide {
+			var hideData WhHide
+			if err := c.BindJSON(&hideData); err != nil {
+				c.JSON(BadRequestErrResp(err.Error()))
+				return
+			}
+			updatedWh, whErr = s.Hide(c.Request.Context(), t, whId, hideData.Reason, claims)
+		} else {
+			updatedWh, whErr = s.Unhide(c.Request.Context(), t, whId, claims)
+		}
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := updatedWh.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.Header("ETag", updatedWh.ETag())
+		c.JSON(OkResp(returnData))
+	}
+}
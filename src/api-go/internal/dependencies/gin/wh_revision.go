@@ -0,0 +1,132 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"strconv"
+)
+
+func whRevisionListHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		revisions, whErr := s.GetRevisions(c.Request.Context(), t, whId, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		list := make([]map[string]any, len(revisions))
+		for i, v := range revisions {
+			var err error
+			if list[i], err = v.ToMap(false); err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+		}
+
+		c.JSON(OkResp(list))
+	}
+}
+
+func whRevisionGetHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		rev, err := strconv.Atoi(c.Param("rev"))
+		if err != nil {
+			c.JSON(BadRequestErrResp("invalid revision"))
+			return
+		}
+
+		revision, whErr := s.GetRevision(c.Request.Context(), t, whId, rev, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := revision.ToMap(true)
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whRevisionDiffHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		rev, err := strconv.Atoi(c.Param("rev"))
+		if err != nil {
+			c.JSON(BadRequestErrResp("invalid revision"))
+			return
+		}
+
+		againstRev := 0
+		if against := c.Query("against"); against != "" && against != "current" {
+			if againstRev, err = strconv.Atoi(against); err != nil || againstRev < 1 {
+				c.JSON(BadRequestErrResp("invalid against revision"))
+				return
+			}
+		}
+
+		changes, whErr := s.DiffRevision(c.Request.Context(), t, whId, rev, againstRev, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		c.JSON(OkResp(map[string]any{"rev": rev, "against": c.DefaultQuery("against", "current"), "changes": changes}))
+	}
+}
+
+func whRevisionRestoreHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		rev, err := strconv.Atoi(c.Param("rev"))
+		if err != nil {
+			c.JSON(BadRequestErrResp("invalid revision"))
+			return
+		}
+
+		whRead, whErr := s.RestoreRevision(c.Request.Context(), t, whId, rev, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := whRead.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.Header("ETag", whRead.ETag())
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whErrResp(c *gin.Context, whErr *warhammer.WhError) {
+	switch whErr.ErrType {
+	case warhammer.WhInvalidArgumentsError:
+		c.JSON(BadRequestErrResp(whErr.Error()))
+	case warhammer.WhUnauthorizedError:
+		c.JSON(UnauthorizedErrResp(""))
+	case warhammer.WhNotFoundError:
+		c.JSON(NotFoundErrResp(""))
+	case warhammer.WhConflictError:
+		c.JSON(PreconditionFailedErrResp("object has been modified"))
+	case warhammer.WhGoneError:
+		c.JSON(GoneErrResp(whErr.Error()))
+	default:
+		c.JSON(ServerErrResp(""))
+	}
+}
@@ -0,0 +1,33 @@
+package gin
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+func whTranslationsSubmitHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		reqData, err := c.GetRawData()
+		if err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		var translations []*warhammer.WhTranslationSubmission
+		if err = json.Unmarshal(reqData, &translations); err != nil {
+			c.JSON(BadRequestErrResp(err.Error()))
+			return
+		}
+
+		claims := getUserClaims(c)
+
+		notFound, whErr := s.SubmitTranslations(c.Request.Context(), translations, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		c.JSON(OkResp(map[string]any{"updated": len(translations) - len(notFound), "notFound": notFound}))
+	}
+}
@@ -0,0 +1,52 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+func whTrashHandler(s warhammer.WhService) func(*gin.Context) {
+	return func(c *gin.Context) {
+		claims := getUserClaims(c)
+
+		trash, whErr := s.GetTrash(c.Request.Context(), claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData := make(map[string]any, len(trash))
+		for t, whs := range trash {
+			list, err := whListToListMap(whs)
+			if err != nil {
+				c.JSON(ServerErrResp(""))
+				return
+			}
+			returnData[string(t)] = list
+		}
+
+		c.JSON(OkResp(returnData))
+	}
+}
+
+func whRestoreHandler(s warhammer.WhService, t warhammer.WhType) func(*gin.Context) {
+	return func(c *gin.Context) {
+		whId := c.Param("whId")
+		claims := getUserClaims(c)
+
+		restoredWh, whErr := s.Restore(c.Request.Context(), t, whId, claims)
+		if whErr != nil {
+			whErrResp(c, whErr)
+			return
+		}
+
+		returnData, err := restoredWh.ToMap()
+		if err != nil {
+			c.JSON(ServerErrResp(""))
+			return
+		}
+
+		c.Header("ETag", restoredWh.ETag())
+		c.JSON(OkResp(returnData))
+	}
+}
@@ -1,23 +1,48 @@
 package golangjwt
 
 import (
+	"context"
 	"fmt"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
 	"time"
 )
 
+// Service is implemented by all signers of this package.
+type Service interface {
+	domain.JwtService
+	domain.JwksProvider
+	StartRotation(ctx context.Context, interval time.Duration)
+}
+
+// NewJwtService returns the signer selected by cfg.SigningMethod. Key rings are loaded from db, which is not used
+// with HS256.
+func NewJwtService(ctx context.Context, cfg *config.Jwt, db domain.JwtKeyDbService) (Service, error) {
+	switch cfg.SigningMethod {
+	case jwt.SigningMethodHS256.Alg():
+		return NewHmacService(cfg.HmacSecret, cfg.AccessExpiry, cfg.ResetExpiry, cfg.VerifyEmailExpiry), nil
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		return NewKeyRingService(ctx, cfg.SigningMethod, cfg.AccessExpiry, cfg.ResetExpiry, cfg.VerifyEmailExpiry, cfg.KeyRotationInterval, cfg.KeyRefreshInterval, db)
+	default:
+		return nil, fmt.Errorf("unsupported jwt signing method %s", cfg.SigningMethod)
+	}
+}
+
 type HmacService struct {
-	HmacSecret            []byte
-	AccessTokenExpiryTime time.Duration
-	ResetTokenExpiryTime  time.Duration
+	HmacSecret                 []byte
+	AccessTokenExpiryTime      time.Duration
+	ResetTokenExpiryTime       time.Duration
+	VerifyEmailTokenExpiryTime time.Duration
 }
 
-func NewHmacService(hmacSecret string, accessTokenExpiryTime time.Duration, resetTokenExpiryTime time.Duration) *HmacService {
+func NewHmacService(hmacSecret string, accessTokenExpiryTime time.Duration, resetTokenExpiryTime time.Duration,
+	verifyEmailTokenExpiryTime time.Duration) *HmacService {
 	return &HmacService{
-		HmacSecret:            []byte(hmacSecret),
-		AccessTokenExpiryTime: accessTokenExpiryTime,
-		ResetTokenExpiryTime:  resetTokenExpiryTime,
+		HmacSecret:                 []byte(hmacSecret),
+		AccessTokenExpiryTime:      accessTokenExpiryTime,
+		ResetTokenExpiryTime:       resetTokenExpiryTime,
+		VerifyEmailTokenExpiryTime: verifyEmailTokenExpiryTime,
 	}
 }
 
@@ -26,30 +51,53 @@ func (jwtService *HmacService) GenerateAccessToken(claims *domain.Claims) (strin
 }
 
 func generateToken(claims *domain.Claims, expiryTime time.Duration, hmacSecret []byte) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newMapClaims(claims, expiryTime))
+	return token.SignedString(hmacSecret)
+}
+
+func newMapClaims(claims *domain.Claims, expiryTime time.Duration) jwt.MapClaims {
 	currentTime := time.Now()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	return jwt.MapClaims{
 		"sub":      claims.Id,
 		"exp":      currentTime.Add(expiryTime).Unix(),
 		"orig_iat": currentTime.Unix(),
-		"adm":      claims.Admin,
+		"rol":      claims.Roles,
 		"shrd_acc": claims.SharedAccounts,
 		"pwd":      claims.ResetPassword,
-	})
-	return token.SignedString(hmacSecret)
+		"vem":      claims.VerifyEmail,
+		"loc":      claims.Locale,
+		"sid":      claims.SessionId,
+	}
 }
 
 func (jwtService *HmacService) GenerateResetPasswordToken(claims *domain.Claims) (string, error) {
 	return generateToken(claims, jwtService.ResetTokenExpiryTime, jwtService.HmacSecret)
 }
 
+func (jwtService *HmacService) GenerateVerifyEmailToken(claims *domain.Claims) (string, error) {
+	return generateToken(claims, jwtService.VerifyEmailTokenExpiryTime, jwtService.HmacSecret)
+}
+
 func (jwtService *HmacService) ParseToken(tokenString string) (*domain.Claims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	return parseToken(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return jwtService.HmacSecret, nil
 	})
+}
+
+// PublicKeys returns no keys, the HMAC secret can not be published.
+func (jwtService *HmacService) PublicKeys() []domain.Jwk {
+	return []domain.Jwk{}
+}
+
+// StartRotation does nothing, the HMAC secret is static.
+func (jwtService *HmacService) StartRotation(ctx context.Context, interval time.Duration) {}
+
+func parseToken(tokenString string, keyFunc jwt.Keyfunc) (*domain.Claims, error) {
+	token, err := jwt.Parse(tokenString, keyFunc)
 
 	if err != nil {
 		return nil, &domain.InvalidTokenError{Err: err}
@@ -70,8 +118,13 @@ func (jwtService *HmacService) ParseToken(tokenString string) (*domain.Claims, e
 
 	var claims domain.Claims
 	claims.Id, _ = jwtClaims["sub"].(string)
-	claims.Admin, _ = jwtClaims["adm"].(bool)
 	claims.ResetPassword, _ = jwtClaims["pwd"].(bool)
+	claims.VerifyEmail, _ = jwtClaims["vem"].(string)
+	claims.Locale, _ = jwtClaims["loc"].(string)
+	claims.SessionId, _ = jwtClaims["sid"].(string)
+	if exp, ok := jwtClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0)
+	}
 
 	sharedAccounts, _ := jwtClaims["shrd_acc"].([]interface{})
 	claims.SharedAccounts = make([]string, len(sharedAccounts))
@@ -79,5 +132,15 @@ func (jwtService *HmacService) ParseToken(tokenString string) (*domain.Claims, e
 		claims.SharedAccounts[i], _ = acc.(string)
 	}
 
+	roles, _ := jwtClaims["rol"].([]interface{})
+	claims.Roles = make([]string, len(roles))
+	for i, role := range roles {
+		claims.Roles[i], _ = role.(string)
+	}
+	// Tokens issued before roles were introduced carry only the admin flag.
+	if admin, _ := jwtClaims["adm"].(bool); admin && len(claims.Roles) == 0 {
+		claims.Roles = []string{domain.RoleAdmin}
+	}
+
 	return &claims, nil
 }
@@ -0,0 +1,279 @@
+package golangjwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+const rsaKeyBits = 2048
+
+// KeyRingService signs tokens with asymmetric keys identified by the kid header. A new key is generated every
+// RotationInterval, older keys are kept for verification until all tokens signed with them have expired.
+//
+// Keys are shared through the database and reloaded every RefreshInterval. A new key is published for a full
+// RefreshInterval before it is used for signing, so that other instances and JWKS consumers know it beforehand.
+type KeyRingService struct {
+	SigningMethod         jwt.SigningMethod
+	AccessTokenExpiryTime time.Duration
+	ResetTokenExpiryTime  time.Duration
+	VerifyEmailExpiryTime time.Duration
+	RotationInterval      time.Duration
+	RefreshInterval       time.Duration
+	KeyDbService          domain.JwtKeyDbService
+
+	mu   sync.RWMutex
+	keys []*ringKey // newest first
+}
+
+type ringKey struct {
+	id        string
+	createdAt time.Time
+	private   crypto.Signer
+}
+
+func NewKeyRingService(ctx context.Context, algorithm string, accessTokenExpiryTime time.Duration, resetTokenExpiryTime time.Duration,
+	verifyEmailExpiryTime time.Duration, rotationInterval time.Duration, refreshInterval time.Duration, db domain.JwtKeyDbService) (*KeyRingService, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported jwt signing method %s", algorithm)
+	}
+
+	s := &KeyRingService{
+		SigningMethod:         method,
+		AccessTokenExpiryTime: accessTokenExpiryTime,
+		ResetTokenExpiryTime:  resetTokenExpiryTime,
+		VerifyEmailExpiryTime: verifyEmailExpiryTime,
+		RotationInterval:      rotationInterval,
+		RefreshInterval:       refreshInterval,
+		KeyDbService:          db,
+		keys:                  []*ringKey{},
+	}
+
+	if err := s.Rotate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Rotate reloads the key ring, adds a new key when the newest one is older than RotationInterval and removes keys
+// whose tokens can no longer be valid.
+func (s *KeyRingService) Rotate(ctx context.Context) error {
+	stored, dbErr := s.KeyDbService.RetrieveAll(ctx, s.SigningMethod.Alg())
+	if dbErr != nil {
+		return fmt.Errorf("error retrieving jwt keys, %w", dbErr)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].CreatedAt.After(stored[j].CreatedAt) })
+
+	now := time.Now()
+	if len(stored) == 0 || !stored[0].CreatedAt.After(now.Add(-s.RotationInterval)) {
+		key, err := s.generateKey(now)
+		if err != nil {
+			return err
+		}
+		if dbErr = s.KeyDbService.Create(ctx, key); dbErr != nil {
+			return fmt.Errorf("error storing jwt key, %w", dbErr)
+		}
+		stored = append([]*domain.JwtKey{key}, stored...)
+	}
+
+	// A key stops signing a RefreshInterval after its successor was created, tokens signed with it expire at most
+	// the longest token lifetime later.
+	retention := s.AccessTokenExpiryTime
+	for _, expiry := range []time.Duration{s.ResetTokenExpiryTime, s.VerifyEmailExpiryTime} {
+		if expiry > retention {
+			retention = expiry
+		}
+	}
+	retention += s.RefreshInterval
+
+	keys := make([]*ringKey, 0, len(stored))
+	expired := make([]string, 0)
+	for i, k := range stored {
+		if i > 0 && stored[i-1].CreatedAt.Before(now.Add(-retention)) {
+			expired = append(expired, k.Id)
+			continue
+		}
+
+		private, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("error parsing jwt key %s, %w", k.Id, err)
+		}
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("jwt key %s is not a signing key", k.Id)
+		}
+		keys = append(keys, &ringKey{id: k.Id, createdAt: k.CreatedAt, private: signer})
+	}
+
+	if len(expired) > 0 {
+		if dbErr = s.KeyDbService.Delete(ctx, expired); dbErr != nil {
+			return fmt.Errorf("error deleting expired jwt keys, %w", dbErr)
+		}
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *KeyRingService) generateKey(now time.Time) (*domain.JwtKey, error) {
+	var private any
+	var err error
+	switch s.SigningMethod {
+	case jwt.SigningMethodEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case jwt.SigningMethodRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		err = fmt.Errorf("unsupported jwt signing method %s", s.SigningMethod.Alg())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	return &domain.JwtKey{Id: hex.EncodeToString(id), Algorithm: s.SigningMethod.Alg(), PrivateKey: der, CreatedAt: now.UTC()}, nil
+}
+
+func (s *KeyRingService) StartRotation(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Rotate(ctx); err != nil {
+					log.Printf("error rotating jwt keys: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+// signingKey returns the newest key that has been published for at least RefreshInterval.
+func (s *KeyRingService) signingKey() (*ringKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.keys) == 0 {
+		return nil, errors.New("jwt key ring is empty")
+	}
+
+	publishedBefore := time.Now().Add(-s.RefreshInterval)
+	for _, k := range s.keys {
+		if !k.createdAt.After(publishedBefore) {
+			return k, nil
+		}
+	}
+
+	return s.keys[len(s.keys)-1], nil
+}
+
+func (s *KeyRingService) verificationKey(id string) (*ringKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, k := range s.keys {
+		if k.id == id {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+func (s *KeyRingService) generateToken(claims *domain.Claims, expiryTime time.Duration) (string, error) {
+	key, err := s.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(s.SigningMethod, newMapClaims(claims, expiryTime))
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+func (s *KeyRingService) GenerateAccessToken(claims *domain.Claims) (string, error) {
+	return s.generateToken(claims, s.AccessTokenExpiryTime)
+}
+
+func (s *KeyRingService) GenerateResetPasswordToken(claims *domain.Claims) (string, error) {
+	return s.generateToken(claims, s.ResetTokenExpiryTime)
+}
+
+func (s *KeyRingService) GenerateVerifyEmailToken(claims *domain.Claims) (string, error) {
+	return s.generateToken(claims, s.VerifyEmailExpiryTime)
+}
+
+func (s *KeyRingService) ParseToken(tokenString string) (*domain.Claims, error) {
+	return parseToken(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != s.SigningMethod.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.verificationKey(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %s", kid)
+		}
+		return key.private.Public(), nil
+	})
+}
+
+func (s *KeyRingService) PublicKeys() []domain.Jwk {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jwks := make([]domain.Jwk, 0, len(s.keys))
+	for _, k := range s.keys {
+		jwk := domain.Jwk{Use: "sig", Alg: s.SigningMethod.Alg(), Kid: k.id}
+		switch public := k.private.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		default:
+			continue
+		}
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
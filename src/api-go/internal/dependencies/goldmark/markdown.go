@@ -0,0 +1,38 @@
+package goldmark
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	gm "github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+type MarkdownRenderer struct {
+	Markdown gm.Markdown
+	Policy   *bluemonday.Policy
+}
+
+// NewMarkdownRenderer renders CommonMark with tables and strikethrough. Raw HTML is dropped by the parser and the
+// output is additionally restricted to formatting elements and plain links.
+func NewMarkdownRenderer() *MarkdownRenderer {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements("p", "br", "hr", "em", "strong", "del", "code", "pre", "blockquote", "ul", "ol", "li",
+		"h1", "h2", "h3", "h4", "h5", "h6", "table", "thead", "tbody", "tr", "th", "td")
+	policy.AllowAttrs("href").OnElements("a")
+	policy.AllowStandardURLs()
+	policy.AllowRelativeURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+
+	return &MarkdownRenderer{
+		Markdown: gm.New(gm.WithExtensions(extension.Table, extension.Strikethrough)),
+		Policy:   policy,
+	}
+}
+
+func (r *MarkdownRenderer) Render(markdown string) string {
+	var buf bytes.Buffer
+	if err := r.Markdown.Convert([]byte(markdown), &buf); err != nil {
+		return r.Policy.Sanitize(markdown)
+	}
+	return r.Policy.Sanitize(buf.String())
+}
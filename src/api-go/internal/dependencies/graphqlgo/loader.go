@@ -0,0 +1,147 @@
+package graphqlgo
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+var idPattern = regexp.MustCompile("^[0-9a-fA-F]{24}$")
+
+// loader batches lookups of a single query. Resolvers register ids and return thunks, graphql-go resolves all fields
+// of one level before calling the thunks, so the first thunk of every type fetches all ids requested on that level
+// with a single WhService.Get call. Results are cached for the rest of the query, objects that do not exist or are
+// not visible to the user are cached as nil.
+type loader struct {
+	ctx     context.Context
+	ws      warhammer.WhService
+	claims  *domain.Claims
+	mu      sync.Mutex
+	pending map[warhammer.WhType]map[string]bool
+	cache   map[warhammer.WhType]map[string]*warhammer.Wh
+}
+
+func newLoader(ctx context.Context, ws warhammer.WhService, claims *domain.Claims) *loader {
+	return &loader{
+		ctx:     ctx,
+		ws:      ws,
+		claims:  claims,
+		pending: make(map[warhammer.WhType]map[string]bool),
+		cache:   make(map[warhammer.WhType]map[string]*warhammer.Wh),
+	}
+}
+
+// load returns a thunk resolving to objects with ids, in the same order and without the ones that were not found.
+func (l *loader) load(t warhammer.WhType, ids []string) func() (any, error) {
+	l.mu.Lock()
+	for _, id := range ids {
+		if _, ok := l.cache[t][id]; ok {
+			continue
+		}
+		if l.pending[t] == nil {
+			l.pending[t] = make(map[string]bool)
+		}
+		l.pending[t][id] = true
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		if err := l.fetch(t); err != nil {
+			return nil, err
+		}
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		whs := make([]any, 0, len(ids))
+		for _, id := range ids {
+			if w := l.cache[t][id]; w != nil {
+				whs = append(whs, whToMap(w))
+			}
+		}
+		return whs, nil
+	}
+}
+
+// loadOne returns a thunk resolving to a single object or nil if it was not found.
+func (l *loader) loadOne(t warhammer.WhType, id string) func() (any, error) {
+	thunk := l.load(t, []string{id})
+
+	return func() (any, error) {
+		whs, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		if list := whs.([]any); len(list) > 0 {
+			return list[0], nil
+		}
+		return nil, nil
+	}
+}
+
+// loadAll fetches all objects of type t visible to the user and caches them for subsequent lookups.
+func (l *loader) loadAll(t warhammer.WhType) ([]any, error) {
+	whs, whErr := l.ws.Get(l.ctx, t, l.claims, false, []string{})
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	list := make([]any, len(whs))
+	for i, w := range whs {
+		l.cacheWh(t, w.Id, w)
+		list[i] = whToMap(w)
+	}
+	return list, nil
+}
+
+func (l *loader) fetch(t warhammer.WhType) error {
+	l.mu.Lock()
+	ids := make([]string, 0, len(l.pending[t]))
+	for id := range l.pending[t] {
+		if idPattern.MatchString(id) {
+			ids = append(ids, id)
+		} else {
+			l.cacheWh(t, id, nil)
+		}
+	}
+	delete(l.pending, t)
+	l.mu.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Strings(ids)
+
+	whs, whErr := l.ws.Get(l.ctx, t, l.claims, false, ids)
+	if whErr != nil && whErr.ErrType == warhammer.WhNotFoundError {
+		// Get fails the whole batch if any id is missing, fetching all visible objects keeps it at one more call.
+		whs, whErr = l.ws.Get(l.ctx, t, l.claims, false, []string{})
+	}
+	if whErr != nil {
+		return whErr
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range ids {
+		l.cacheWh(t, id, nil)
+	}
+	for _, w := range whs {
+		l.cacheWh(t, w.Id, w)
+	}
+	return nil
+}
+
+func (l *loader) cacheWh(t warhammer.WhType, id string, w *warhammer.Wh) {
+	if l.cache[t] == nil {
+		l.cache[t] = make(map[string]*warhammer.Wh)
+	}
+	l.cache[t][id] = w
+}
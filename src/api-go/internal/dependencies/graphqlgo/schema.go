@@ -0,0 +1,287 @@
+package graphqlgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// references lists fields holding ids of other objects, by struct type and json name. Plain id fields get a sibling
+// field with the Resolved suffix, lists of IdNumber are replaced with lists of Number types that resolve the object.
+var references = map[reflect.Type]map[string]warhammer.WhType{
+	reflect.TypeOf(warhammer.WhItem{}):         {"properties": warhammer.WhTypeProperty},
+	reflect.TypeOf(warhammer.WhItemGrimoire{}): {"spells": warhammer.WhTypeSpell},
+	reflect.TypeOf(warhammer.WhTalent{}):       {"group": warhammer.WhTypeTalent},
+	reflect.TypeOf(warhammer.WhSkill{}):        {"group": warhammer.WhTypeSkill},
+	reflect.TypeOf(warhammer.WhSpecies{}):      {"baseSpecies": warhammer.WhTypeSpecies},
+	reflect.TypeOf(warhammer.WhCareer{}):       {"species": warhammer.WhTypeSpecies},
+	reflect.TypeOf(warhammer.WhCareerLevel{}):  {"skills": warhammer.WhTypeSkill, "talents": warhammer.WhTypeTalent},
+	reflect.TypeOf(warhammer.WhCharacter{}): {
+		"equippedItems": warhammer.WhTypeItem,
+		"carriedItems":  warhammer.WhTypeItem,
+		"storedItems":   warhammer.WhTypeItem,
+		"skills":        warhammer.WhTypeSkill,
+		"talents":       warhammer.WhTypeTalent,
+		"species":       warhammer.WhTypeSpecies,
+		"careerPath":    warhammer.WhTypeCareer,
+		"career":        warhammer.WhTypeCareer,
+		"spells":        warhammer.WhTypeSpell,
+		"mutations":     warhammer.WhTypeMutation,
+	},
+}
+
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Arbitrary JSON value.",
+	Serialize:   func(value any) any { return value },
+})
+
+type loaderKey struct{}
+
+func loaderFromContext(p graphql.ResolveParams) *loader {
+	return p.Context.Value(loaderKey{}).(*loader)
+}
+
+type schemaBuilder struct {
+	objects       map[reflect.Type]*graphql.Object
+	whObjects     map[warhammer.WhType]*graphql.Object
+	numberObjects map[warhammer.WhType]*graphql.Object
+}
+
+// NewSchema exposes every WhType as a query by id and a list query. Object types are derived from the domain structs
+// through their json tags.
+func NewSchema() (graphql.Schema, error) {
+	b := schemaBuilder{
+		objects:       make(map[reflect.Type]*graphql.Object),
+		whObjects:     make(map[warhammer.WhType]*graphql.Object),
+		numberObjects: make(map[warhammer.WhType]*graphql.Object),
+	}
+
+	fields := graphql.Fields{}
+	for _, t := range warhammer.WhApiTypes {
+		t := t
+
+		fields[string(t)] = &graphql.Field{
+			Type:        b.whObject(t),
+			Description: fmt.Sprintf("Get %s by id.", t),
+			Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				id, _ := p.Args["id"].(string)
+				return loaderFromContext(p).loadOne(t, id), nil
+			},
+		}
+
+		fields[string(t)+"List"] = &graphql.Field{
+			Type:        graphql.NewList(b.whObject(t)),
+			Description: fmt.Sprintf("List %s objects with given ids or all if ids are not provided.", t),
+			Args:        graphql.FieldConfigArgument{"ids": {Type: graphql.NewList(graphql.NewNonNull(graphql.ID))}},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				if ids, ok := p.Args["ids"]; ok {
+					return loaderFromContext(p).load(t, toStringSlice(ids)), nil
+				}
+				return loaderFromContext(p).loadAll(t)
+			},
+		}
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+}
+
+func whTypeName(t warhammer.WhType) string {
+	return "Wh" + strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+func (b *schemaBuilder) whObject(t warhammer.WhType) *graphql.Object {
+	if o, ok := b.whObjects[t]; ok {
+		return o
+	}
+
+	wh, err := warhammer.NewApiWh(t)
+	if err != nil {
+		panic(err)
+	}
+	objectType := reflect.TypeOf(wh.Object).Elem()
+
+	o := graphql.NewObject(graphql.ObjectConfig{
+		Name: whTypeName(t),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             {Type: graphql.NewNonNull(graphql.ID)},
+				"ownerId":        {Type: graphql.String},
+				"canEdit":        {Type: graphql.Boolean},
+				"version":        {Type: graphql.Int},
+				"createdAt":      {Type: graphql.DateTime},
+				"updatedAt":      {Type: graphql.DateTime},
+				"lastModifiedBy": {Type: graphql.String},
+				"object":         {Type: b.object(objectType, whTypeName(t)+"Object")},
+			}
+		}),
+	})
+
+	b.whObjects[t] = o
+	return o
+}
+
+// numberObject wraps IdNumber with a field resolving the referenced object.
+func (b *schemaBuilder) numberObject(t warhammer.WhType) *graphql.Object {
+	if o, ok := b.numberObjects[t]; ok {
+		return o
+	}
+
+	o := graphql.NewObject(graphql.ObjectConfig{
+		Name: whTypeName(t) + "Number",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       {Type: graphql.String},
+				"number":   {Type: graphql.Int},
+				"resolved": b.referenceField("id", reflect.TypeOf(""), t),
+			}
+		}),
+	})
+
+	b.numberObjects[t] = o
+	return o
+}
+
+func (b *schemaBuilder) object(rt reflect.Type, name string) *graphql.Object {
+	if o, ok := b.objects[rt]; ok {
+		return o
+	}
+
+	refs := references[rt]
+	o := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			for i := 0; i < rt.NumField(); i++ {
+				f := rt.Field(i)
+				fieldName := jsonName(f)
+				if fieldName == "" {
+					continue
+				}
+
+				target, isRef := refs[fieldName]
+				if isRef && f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct {
+					fields[fieldName] = &graphql.Field{Type: graphql.NewList(b.numberObject(target))}
+					continue
+				}
+
+				fields[fieldName] = &graphql.Field{Type: b.fieldType(f.Type)}
+				if isRef {
+					fields[fieldName+"Resolved"] = b.referenceField(fieldName, f.Type, target)
+				}
+			}
+			return fields
+		}),
+	})
+
+	b.objects[rt] = o
+	return o
+}
+
+func (b *schemaBuilder) fieldType(rt reflect.Type) graphql.Output {
+	if rt == reflect.TypeOf(time.Time{}) {
+		return graphql.DateTime
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		return graphql.String
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	case reflect.Slice, reflect.Array:
+		return graphql.NewList(b.fieldType(rt.Elem()))
+	case reflect.Map:
+		return jsonScalar
+	case reflect.Struct:
+		return b.object(rt, rt.Name())
+	case reflect.Pointer:
+		return b.fieldType(rt.Elem())
+	default:
+		panic(fmt.Sprintf("unsupported field type %s", rt))
+	}
+}
+
+// referenceField resolves ids stored under key of the source object through the loader.
+func (b *schemaBuilder) referenceField(key string, rt reflect.Type, t warhammer.WhType) *graphql.Field {
+	if rt.Kind() == reflect.Slice {
+		return &graphql.Field{
+			Type: graphql.NewList(b.whObject(t)),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source, _ := p.Source.(map[string]any)
+				return loaderFromContext(p).load(t, toStringSlice(source[key])), nil
+			},
+		}
+	}
+
+	return &graphql.Field{
+		Type: b.whObject(t),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			source, _ := p.Source.(map[string]any)
+			id, _ := source[key].(string)
+			if id == "" {
+				return nil, nil
+			}
+			return loaderFromContext(p).loadOne(t, id), nil
+		},
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+
+	tag := strings.Split(f.Tag.Get("json"), ",")[0]
+	if tag == "-" {
+		return ""
+	}
+	if tag == "" {
+		return f.Name
+	}
+	return tag
+}
+
+func toStringSlice(value any) []string {
+	list, _ := value.([]any)
+	ids := make([]string, 0, len(list))
+	for _, v := range list {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// whToMap converts objects to the generic form the default resolvers of graphql-go read fields from.
+func whToMap(w *warhammer.Wh) map[string]any {
+	whMap := map[string]any{
+		"id":             w.Id,
+		"ownerId":        w.OwnerId,
+		"canEdit":        w.CanEdit,
+		"version":        w.Version,
+		"createdAt":      w.CreatedAt,
+		"updatedAt":      w.UpdatedAt,
+		"lastModifiedBy": w.LastModifiedBy,
+	}
+
+	if objectJson, err := json.Marshal(w.Object); err == nil {
+		var object map[string]any
+		if err = json.Unmarshal(objectJson, &object); err == nil {
+			whMap["object"] = object
+		}
+	}
+
+	return whMap
+}
@@ -0,0 +1,36 @@
+package graphqlgo
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+type GraphqlService struct {
+	Schema    graphql.Schema
+	WhService warhammer.WhService
+}
+
+// NewGraphqlService resolves all objects through WhService, so queries see exactly what the REST endpoints return for
+// the same claims.
+func NewGraphqlService(ws warhammer.WhService) *GraphqlService {
+	schema, err := NewSchema()
+	if err != nil {
+		panic(err)
+	}
+
+	return &GraphqlService{Schema: schema, WhService: ws}
+}
+
+func (s *GraphqlService) Execute(ctx context.Context, r *domain.GraphqlRequest, c *domain.Claims) any {
+	ctx = context.WithValue(ctx, loaderKey{}, newLoader(ctx, s.WhService, c))
+
+	return graphql.Do(graphql.Params{
+		Schema:         s.Schema,
+		RequestString:  r.Query,
+		VariableValues: r.Variables,
+		OperationName:  r.OperationName,
+		Context:        ctx,
+	})
+}
@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc/wfrpv1"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log"
+	"math"
+	"net"
+	"strings"
+)
+
+type claimsKey struct{}
+
+// UnaryAuthInterceptor and StreamAuthInterceptor treat the authorization metadata the same way RequireJwt treats the
+// Authorization header, calls without a valid token are served as anonymous.
+func UnaryAuthInterceptor(js domain.JwtService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(authenticate(ctx, js), req)
+	}
+}
+
+func StreamAuthInterceptor(js domain.JwtService) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: authenticate(ss.Context(), js)})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, js domain.JwtService) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	claims := &domain.Claims{Id: "anonymous", SharedAccounts: []string{}}
+	if token, err := parseAuthMetadata(md.Get("authorization")); err == nil {
+		if parsed, err := js.ParseToken(token); err == nil && !parsed.ResetPassword && parsed.VerifyEmail == "" && !isRevoked(ctx, js, parsed) {
+			claims = parsed
+		}
+	}
+	claims.Locale = requestedLocale(claims.Locale, md.Get("accept-language"))
+
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+func isRevoked(ctx context.Context, js domain.JwtService, claims *domain.Claims) bool {
+	rc, ok := js.(domain.TokenRevocationChecker)
+	return ok && rc.IsRevoked(ctx, claims)
+}
+
+func getUserClaims(ctx context.Context) *domain.Claims {
+	claims, ok := ctx.Value(claimsKey{}).(*domain.Claims)
+	if !ok {
+		return &domain.Claims{Id: "anonymous", SharedAccounts: []string{}}
+	}
+	return claims
+}
+
+func parseAuthMetadata(values []string) (string, error) {
+	if len(values) == 0 {
+		return "", fmt.Errorf("missing authorization metadata")
+	}
+
+	parts := strings.SplitN(values[0], " ", 2)
+	if !(len(parts) == 2 && parts[0] == "Bearer") {
+		return "", fmt.Errorf("invalid authorization metadata")
+	}
+
+	return parts[1], nil
+}
+
+// requestedLocale prefers the locale chosen in user settings over the one requested in accept-language metadata.
+func requestedLocale(preferred string, acceptLanguage []string) string {
+	if preferred != "" || len(acceptLanguage) == 0 {
+		return preferred
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage[0])
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	return tags[0].String()
+}
+
+type AuthServer struct {
+	wfrpv1.UnimplementedAuthServiceServer
+	UserService    user.UserService
+	SessionService session.SessionService
+	LockoutService lockout.LockoutService
+}
+
+// CreateToken applies the same lockout and uniform failure response as the token endpoint of the http api.
+func (s *AuthServer) CreateToken(ctx context.Context, req *wfrpv1.CreateTokenRequest) (*wfrpv1.CreateTokenResponse, error) {
+	username := req.GetUsername()
+	address := peerAddress(ctx)
+
+	wait, lErr := s.LockoutService.Begin(ctx, username, address)
+	if lErr != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	if wait > 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed attempts, try again in %d seconds", int(math.Ceil(wait.Seconds())))
+	}
+
+	u, uErr := s.UserService.Authenticate(ctx, username, req.GetPassword(), req.GetTotpCode())
+	if uErr != nil {
+		switch uErr.Type {
+		case user.UserNotFoundError, user.UserIncorrectPasswordError:
+			s.fail(ctx, username, address)
+			return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+		case user.UserTotpRequiredError:
+			s.release(ctx, username, address)
+			return nil, status.Error(codes.Unauthenticated, "totp code required")
+		case user.UserIncorrectTotpError:
+			s.fail(ctx, username, address)
+			return nil, status.Error(codes.Unauthenticated, "invalid totp code")
+		default:
+			s.release(ctx, username, address)
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+	}
+
+	if lErr = s.LockoutService.Succeed(ctx, username, address); lErr != nil {
+		log.Printf("error resetting sign-in attempts: %s", lErr)
+	}
+
+	claims := domain.Claims{Id: u.Id, Roles: u.EffectiveRoles(), SharedAccounts: u.SharedAccountIds, ResetPassword: false, Locale: u.Locale}
+	tokens, sErr := s.SessionService.Create(ctx, &claims)
+	if sErr != nil {
+		return nil, status.Error(codes.Internal, "error generating token")
+	}
+
+	return tokensToProto(tokens), nil
+}
+
+func (s *AuthServer) fail(ctx context.Context, username string, address string) {
+	if lErr := s.LockoutService.Fail(ctx, username, address); lErr != nil {
+		log.Printf("error recording failed sign-in attempt: %s", lErr)
+	}
+}
+
+func (s *AuthServer) release(ctx context.Context, username string, address string) {
+	if lErr := s.LockoutService.Release(ctx, username, address); lErr != nil {
+		log.Printf("error releasing sign-in attempt: %s", lErr)
+	}
+}
+
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (s *AuthServer) RefreshToken(ctx context.Context, req *wfrpv1.RefreshTokenRequest) (*wfrpv1.CreateTokenResponse, error) {
+	tokens, sErr := s.SessionService.Refresh(ctx, req.GetRefreshToken())
+	if sErr != nil {
+		switch sErr.Type {
+		case session.SessionInvalidTokenError, session.SessionReuseDetectedError:
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		default:
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+	}
+
+	return tokensToProto(tokens), nil
+}
+
+func tokensToProto(tokens *session.Tokens) *wfrpv1.CreateTokenResponse {
+	return &wfrpv1.CreateTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "bearer",
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+	}
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc/wfrpv1"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+	"time"
+)
+
+type Server struct {
+	Server          *grpc.Server
+	Addr            string
+	ShutdownTimeout time.Duration
+}
+
+func NewServer(cfg *config.Server, us user.UserService, ws warhammer.WhService, ss session.SessionService, js domain.JwtService,
+	ls lockout.LockoutService) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(js)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(js)),
+	)
+
+	wfrpv1.RegisterAuthServiceServer(server, &AuthServer{UserService: us, SessionService: ss, LockoutService: ls})
+	wfrpv1.RegisterUserServiceServer(server, &UserServer{UserService: us})
+	wfrpv1.RegisterWhServiceServer(server, &WhServer{WhService: ws})
+
+	return &Server{
+		Server:          server,
+		Addr:            fmt.Sprintf("%s:%d", cfg.Host, cfg.GrpcPort),
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+func (s *Server) Start() {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	go func() {
+		log.Printf("grpc server starting on %s", s.Addr)
+		if err := s.Server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+}
+
+// Stop waits for running calls up to ShutdownTimeout, open streams are then closed.
+func (s *Server) Stop() {
+	stopped := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.ShutdownTimeout):
+		s.Server.Stop()
+	}
+}
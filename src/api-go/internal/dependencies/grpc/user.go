@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc/wfrpv1"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type UserServer struct {
+	wfrpv1.UnimplementedUserServiceServer
+	UserService user.UserService
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *wfrpv1.GetUserRequest) (*wfrpv1.User, error) {
+	claims := getUserClaims(ctx)
+
+	userId := req.GetId()
+	if userId == "" {
+		userId = claims.Id
+	}
+
+	u, uErr := s.UserService.Get(ctx, claims, userId)
+	if uErr != nil {
+		return nil, userErrStatus(uErr)
+	}
+
+	return userToProto(u), nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, _ *wfrpv1.ListUsersRequest) (*wfrpv1.ListUsersResponse, error) {
+	users, uErr := s.UserService.List(ctx, getUserClaims(ctx))
+	if uErr != nil {
+		return nil, userErrStatus(uErr)
+	}
+
+	resp := &wfrpv1.ListUsersResponse{Users: make([]*wfrpv1.User, len(users))}
+	for i, u := range users {
+		resp.Users[i] = userToProto(u)
+	}
+
+	return resp, nil
+}
+
+func (s *UserServer) UserExists(ctx context.Context, req *wfrpv1.UserExistsRequest) (*wfrpv1.UserExistsResponse, error) {
+	exists, uErr := s.UserService.Exists(ctx, req.GetUsername())
+	if uErr != nil {
+		return nil, userErrStatus(uErr)
+	}
+
+	return &wfrpv1.UserExistsResponse{Exists: exists}, nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *wfrpv1.UpdateUserRequest) (*wfrpv1.User, error) {
+	u := user.EmptyUser()
+	u.Id = req.GetId()
+	u.SharedAccountNames = req.GetSharedAccounts()
+	u.Locale = req.GetLocale()
+
+	userRead, uErr := s.UserService.Update(ctx, getUserClaims(ctx), &u)
+	if uErr != nil {
+		return nil, userErrStatus(uErr)
+	}
+
+	return userToProto(userRead), nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *wfrpv1.DeleteUserRequest) (*wfrpv1.DeleteUserResponse, error) {
+	if uErr := s.UserService.Delete(ctx, getUserClaims(ctx), req.GetId()); uErr != nil {
+		return nil, userErrStatus(uErr)
+	}
+
+	return &wfrpv1.DeleteUserResponse{}, nil
+}
+
+func userToProto(u *user.User) *wfrpv1.User {
+	return &wfrpv1.User{
+		Id:              u.Id,
+		Username:        u.Username,
+		SharedAccounts:  u.SharedAccountNames,
+		Locale:          u.Locale,
+		Admin:           slices.Contains(u.Roles, domain.RoleAdmin),
+		CreatedOn:       timestamppb.New(u.CreatedOn),
+		LastAuthOn:      timestamppb.New(u.LastAuthOn),
+		EmailVerified:   u.EmailVerified,
+		PendingUsername: u.PendingUsername,
+		Roles:           u.Roles,
+	}
+}
+
+func userErrStatus(uErr *user.UserError) error {
+	switch uErr.Type {
+	case user.UserNotFoundError:
+		return status.Error(codes.NotFound, "not found")
+	case user.UserInvalidArgumentsError, user.UserEmailNotVerifiedError:
+		return status.Error(codes.InvalidArgument, uErr.Error())
+	case user.UserUnauthorizedError:
+		return status.Error(codes.PermissionDenied, "unauthorized")
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
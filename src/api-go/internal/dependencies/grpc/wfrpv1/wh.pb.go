@@ -0,0 +1,3512 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: wfrp/v1/wh.proto
+
+package wfrpv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WhType int32
+
+const (
+	WhType_WH_TYPE_UNSPECIFIED WhType = 0
+	WhType_WH_TYPE_MUTATION    WhType = 1
+	WhType_WH_TYPE_SPELL       WhType = 2
+	WhType_WH_TYPE_PROPERTY    WhType = 3
+	WhType_WH_TYPE_ITEM        WhType = 4
+	WhType_WH_TYPE_TALENT      WhType = 5
+	WhType_WH_TYPE_SKILL       WhType = 6
+	WhType_WH_TYPE_CAREER      WhType = 7
+	WhType_WH_TYPE_CHARACTER   WhType = 8
+	WhType_WH_TYPE_SPECIES     WhType = 9
+)
+
+// Enum value maps for WhType.
+var (
+	WhType_name = map[int32]string{
+		0: "WH_TYPE_UNSPECIFIED",
+		1: "WH_TYPE_MUTATION",
+		2: "WH_TYPE_SPELL",
+		3: "WH_TYPE_PROPERTY",
+		4: "WH_TYPE_ITEM",
+		5: "WH_TYPE_TALENT",
+		6: "WH_TYPE_SKILL",
+		7: "WH_TYPE_CAREER",
+		8: "WH_TYPE_CHARACTER",
+		9: "WH_TYPE_SPECIES",
+	}
+	WhType_value = map[string]int32{
+		"WH_TYPE_UNSPECIFIED": 0,
+		"WH_TYPE_MUTATION":    1,
+		"WH_TYPE_SPELL":       2,
+		"WH_TYPE_PROPERTY":    3,
+		"WH_TYPE_ITEM":        4,
+		"WH_TYPE_TALENT":      5,
+		"WH_TYPE_SKILL":       6,
+		"WH_TYPE_CAREER":      7,
+		"WH_TYPE_CHARACTER":   8,
+		"WH_TYPE_SPECIES":     9,
+	}
+)
+
+func (x WhType) Enum() *WhType {
+	p := new(WhType)
+	*p = x
+	return p
+}
+
+func (x WhType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WhType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wfrp_v1_wh_proto_enumTypes[0].Descriptor()
+}
+
+func (WhType) Type() protoreflect.EnumType {
+	return &file_wfrp_v1_wh_proto_enumTypes[0]
+}
+
+func (x WhType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WhType.Descriptor instead.
+func (WhType) EnumDescriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{0}
+}
+
+type Attributes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ws  int32 `protobuf:"varint,1,opt,name=ws,json=WS,proto3" json:"ws,omitempty"`
+	Bs  int32 `protobuf:"varint,2,opt,name=bs,json=BS,proto3" json:"bs,omitempty"`
+	S   int32 `protobuf:"varint,3,opt,name=s,json=S,proto3" json:"s,omitempty"`
+	T   int32 `protobuf:"varint,4,opt,name=t,json=T,proto3" json:"t,omitempty"`
+	I   int32 `protobuf:"varint,5,opt,name=i,json=I,proto3" json:"i,omitempty"`
+	Ag  int32 `protobuf:"varint,6,opt,name=ag,json=Ag,proto3" json:"ag,omitempty"`
+	Dex int32 `protobuf:"varint,7,opt,name=dex,json=Dex,proto3" json:"dex,omitempty"`
+	Int int32 `protobuf:"varint,8,opt,name=int,json=Int,proto3" json:"int,omitempty"`
+	Wp  int32 `protobuf:"varint,9,opt,name=wp,json=WP,proto3" json:"wp,omitempty"`
+	Fel int32 `protobuf:"varint,10,opt,name=fel,json=Fel,proto3" json:"fel,omitempty"`
+}
+
+func (x *Attributes) Reset() {
+	*x = Attributes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attributes) ProtoMessage() {}
+
+func (x *Attributes) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attributes.ProtoReflect.Descriptor instead.
+func (*Attributes) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Attributes) GetWs() int32 {
+	if x != nil {
+		return x.Ws
+	}
+	return 0
+}
+
+func (x *Attributes) GetBs() int32 {
+	if x != nil {
+		return x.Bs
+	}
+	return 0
+}
+
+func (x *Attributes) GetS() int32 {
+	if x != nil {
+		return x.S
+	}
+	return 0
+}
+
+func (x *Attributes) GetT() int32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *Attributes) GetI() int32 {
+	if x != nil {
+		return x.I
+	}
+	return 0
+}
+
+func (x *Attributes) GetAg() int32 {
+	if x != nil {
+		return x.Ag
+	}
+	return 0
+}
+
+func (x *Attributes) GetDex() int32 {
+	if x != nil {
+		return x.Dex
+	}
+	return 0
+}
+
+func (x *Attributes) GetInt() int32 {
+	if x != nil {
+		return x.Int
+	}
+	return 0
+}
+
+func (x *Attributes) GetWp() int32 {
+	if x != nil {
+		return x.Wp
+	}
+	return 0
+}
+
+func (x *Attributes) GetFel() int32 {
+	if x != nil {
+		return x.Fel
+	}
+	return 0
+}
+
+type Modifiers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size       int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Movement   int32       `protobuf:"varint,2,opt,name=movement,proto3" json:"movement,omitempty"`
+	Attributes *Attributes `protobuf:"bytes,3,opt,name=attributes,json=Attributes,proto3" json:"attributes,omitempty"`
+}
+
+func (x *Modifiers) Reset() {
+	*x = Modifiers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Modifiers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Modifiers) ProtoMessage() {}
+
+func (x *Modifiers) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Modifiers.ProtoReflect.Descriptor instead.
+func (*Modifiers) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Modifiers) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Modifiers) GetMovement() int32 {
+	if x != nil {
+		return x.Movement
+	}
+	return 0
+}
+
+func (x *Modifiers) GetAttributes() *Attributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type IdNumber struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number int32  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *IdNumber) Reset() {
+	*x = IdNumber{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IdNumber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdNumber) ProtoMessage() {}
+
+func (x *IdNumber) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdNumber.ProtoReflect.Descriptor instead.
+func (*IdNumber) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IdNumber) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IdNumber) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type Mutation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type        int32             `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	Modifiers   *Modifiers        `protobuf:"bytes,4,opt,name=modifiers,proto3" json:"modifiers,omitempty"`
+	Shared      bool              `protobuf:"varint,5,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,6,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Mutation) Reset() {
+	*x = Mutation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mutation) ProtoMessage() {}
+
+func (x *Mutation) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mutation.ProtoReflect.Descriptor instead.
+func (*Mutation) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Mutation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mutation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Mutation) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Mutation) GetModifiers() *Modifiers {
+	if x != nil {
+		return x.Modifiers
+	}
+	return nil
+}
+
+func (x *Mutation) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Mutation) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type Spell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Cn          int32             `protobuf:"varint,3,opt,name=cn,proto3" json:"cn,omitempty"`
+	Range       string            `protobuf:"bytes,4,opt,name=range,proto3" json:"range,omitempty"`
+	Target      string            `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	Duration    string            `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Shared      bool              `protobuf:"varint,7,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,8,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Spell) Reset() {
+	*x = Spell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spell) ProtoMessage() {}
+
+func (x *Spell) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spell.ProtoReflect.Descriptor instead.
+func (*Spell) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Spell) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Spell) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Spell) GetCn() int32 {
+	if x != nil {
+		return x.Cn
+	}
+	return 0
+}
+
+func (x *Spell) GetRange() string {
+	if x != nil {
+		return x.Range
+	}
+	return ""
+}
+
+func (x *Spell) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Spell) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *Spell) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Spell) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type Property struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type         int32             `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	ApplicableTo []int32           `protobuf:"varint,4,rep,packed,name=applicable_to,json=applicableTo,proto3" json:"applicable_to,omitempty"`
+	Shared       bool              `protobuf:"varint,5,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source       map[string]string `protobuf:"bytes,6,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Property) Reset() {
+	*x = Property{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Property) ProtoMessage() {}
+
+func (x *Property) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Property.ProtoReflect.Descriptor instead.
+func (*Property) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Property) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Property) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Property) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Property) GetApplicableTo() []int32 {
+	if x != nil {
+		return x.ApplicableTo
+	}
+	return nil
+}
+
+func (x *Property) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Property) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type ItemMelee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hands     int32   `protobuf:"varint,1,opt,name=hands,proto3" json:"hands,omitempty"`
+	Dmg       int32   `protobuf:"varint,2,opt,name=dmg,proto3" json:"dmg,omitempty"`
+	DmgSbMult float64 `protobuf:"fixed64,3,opt,name=dmg_sb_mult,json=dmgSbMult,proto3" json:"dmg_sb_mult,omitempty"`
+	Reach     int32   `protobuf:"varint,4,opt,name=reach,proto3" json:"reach,omitempty"`
+	Group     int32   `protobuf:"varint,5,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *ItemMelee) Reset() {
+	*x = ItemMelee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemMelee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemMelee) ProtoMessage() {}
+
+func (x *ItemMelee) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemMelee.ProtoReflect.Descriptor instead.
+func (*ItemMelee) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ItemMelee) GetHands() int32 {
+	if x != nil {
+		return x.Hands
+	}
+	return 0
+}
+
+func (x *ItemMelee) GetDmg() int32 {
+	if x != nil {
+		return x.Dmg
+	}
+	return 0
+}
+
+func (x *ItemMelee) GetDmgSbMult() float64 {
+	if x != nil {
+		return x.DmgSbMult
+	}
+	return 0
+}
+
+func (x *ItemMelee) GetReach() int32 {
+	if x != nil {
+		return x.Reach
+	}
+	return 0
+}
+
+func (x *ItemMelee) GetGroup() int32 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+type ItemRanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hands     int32   `protobuf:"varint,1,opt,name=hands,proto3" json:"hands,omitempty"`
+	Dmg       int32   `protobuf:"varint,2,opt,name=dmg,proto3" json:"dmg,omitempty"`
+	DmgSbMult float64 `protobuf:"fixed64,3,opt,name=dmg_sb_mult,json=dmgSbMult,proto3" json:"dmg_sb_mult,omitempty"`
+	Rng       int32   `protobuf:"varint,4,opt,name=rng,proto3" json:"rng,omitempty"`
+	RngSbMult float64 `protobuf:"fixed64,5,opt,name=rng_sb_mult,json=rngSbMult,proto3" json:"rng_sb_mult,omitempty"`
+	Group     int32   `protobuf:"varint,6,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *ItemRanged) Reset() {
+	*x = ItemRanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemRanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemRanged) ProtoMessage() {}
+
+func (x *ItemRanged) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemRanged.ProtoReflect.Descriptor instead.
+func (*ItemRanged) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ItemRanged) GetHands() int32 {
+	if x != nil {
+		return x.Hands
+	}
+	return 0
+}
+
+func (x *ItemRanged) GetDmg() int32 {
+	if x != nil {
+		return x.Dmg
+	}
+	return 0
+}
+
+func (x *ItemRanged) GetDmgSbMult() float64 {
+	if x != nil {
+		return x.DmgSbMult
+	}
+	return 0
+}
+
+func (x *ItemRanged) GetRng() int32 {
+	if x != nil {
+		return x.Rng
+	}
+	return 0
+}
+
+func (x *ItemRanged) GetRngSbMult() float64 {
+	if x != nil {
+		return x.RngSbMult
+	}
+	return 0
+}
+
+func (x *ItemRanged) GetGroup() int32 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+type ItemAmmunition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dmg     int32   `protobuf:"varint,1,opt,name=dmg,proto3" json:"dmg,omitempty"`
+	Rng     int32   `protobuf:"varint,2,opt,name=rng,proto3" json:"rng,omitempty"`
+	RngMult float64 `protobuf:"fixed64,3,opt,name=rng_mult,json=rngMult,proto3" json:"rng_mult,omitempty"`
+	Group   int32   `protobuf:"varint,4,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *ItemAmmunition) Reset() {
+	*x = ItemAmmunition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemAmmunition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAmmunition) ProtoMessage() {}
+
+func (x *ItemAmmunition) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAmmunition.ProtoReflect.Descriptor instead.
+func (*ItemAmmunition) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ItemAmmunition) GetDmg() int32 {
+	if x != nil {
+		return x.Dmg
+	}
+	return 0
+}
+
+func (x *ItemAmmunition) GetRng() int32 {
+	if x != nil {
+		return x.Rng
+	}
+	return 0
+}
+
+func (x *ItemAmmunition) GetRngMult() float64 {
+	if x != nil {
+		return x.RngMult
+	}
+	return 0
+}
+
+func (x *ItemAmmunition) GetGroup() int32 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+type ItemArmour struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Points   int32 `protobuf:"varint,1,opt,name=points,proto3" json:"points,omitempty"`
+	Location int32 `protobuf:"varint,2,opt,name=location,proto3" json:"location,omitempty"`
+	Group    int32 `protobuf:"varint,3,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *ItemArmour) Reset() {
+	*x = ItemArmour{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemArmour) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemArmour) ProtoMessage() {}
+
+func (x *ItemArmour) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemArmour.ProtoReflect.Descriptor instead.
+func (*ItemArmour) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ItemArmour) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *ItemArmour) GetLocation() int32 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ItemArmour) GetGroup() int32 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+type ItemContainer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capacity  int32 `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	CarryType int32 `protobuf:"varint,2,opt,name=carry_type,json=carryType,proto3" json:"carry_type,omitempty"`
+}
+
+func (x *ItemContainer) Reset() {
+	*x = ItemContainer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemContainer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemContainer) ProtoMessage() {}
+
+func (x *ItemContainer) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemContainer.ProtoReflect.Descriptor instead.
+func (*ItemContainer) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ItemContainer) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ItemContainer) GetCarryType() int32 {
+	if x != nil {
+		return x.CarryType
+	}
+	return 0
+}
+
+type ItemGrimoire struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spells []string `protobuf:"bytes,1,rep,name=spells,proto3" json:"spells,omitempty"`
+}
+
+func (x *ItemGrimoire) Reset() {
+	*x = ItemGrimoire{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemGrimoire) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemGrimoire) ProtoMessage() {}
+
+func (x *ItemGrimoire) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemGrimoire.ProtoReflect.Descriptor instead.
+func (*ItemGrimoire) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ItemGrimoire) GetSpells() []string {
+	if x != nil {
+		return x.Spells
+	}
+	return nil
+}
+
+type ItemOther struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CarryType int32 `protobuf:"varint,1,opt,name=carry_type,json=carryType,proto3" json:"carry_type,omitempty"`
+}
+
+func (x *ItemOther) Reset() {
+	*x = ItemOther{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemOther) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemOther) ProtoMessage() {}
+
+func (x *ItemOther) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemOther.ProtoReflect.Descriptor instead.
+func (*ItemOther) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ItemOther) GetCarryType() int32 {
+	if x != nil {
+		return x.CarryType
+	}
+	return 0
+}
+
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64           `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Enc         float64           `protobuf:"fixed64,4,opt,name=enc,proto3" json:"enc,omitempty"`
+	Properties  []string          `protobuf:"bytes,5,rep,name=properties,proto3" json:"properties,omitempty"`
+	Type        int32             `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	Shared      bool              `protobuf:"varint,7,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,8,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Melee       *ItemMelee        `protobuf:"bytes,9,opt,name=melee,proto3" json:"melee,omitempty"`
+	Ranged      *ItemRanged       `protobuf:"bytes,10,opt,name=ranged,proto3" json:"ranged,omitempty"`
+	Ammunition  *ItemAmmunition   `protobuf:"bytes,11,opt,name=ammunition,proto3" json:"ammunition,omitempty"`
+	Armour      *ItemArmour       `protobuf:"bytes,12,opt,name=armour,proto3" json:"armour,omitempty"`
+	Container   *ItemContainer    `protobuf:"bytes,13,opt,name=container,proto3" json:"container,omitempty"`
+	Grimoire    *ItemGrimoire     `protobuf:"bytes,14,opt,name=grimoire,proto3" json:"grimoire,omitempty"`
+	Other       *ItemOther        `protobuf:"bytes,15,opt,name=other,proto3" json:"other,omitempty"`
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Item) GetEnc() float64 {
+	if x != nil {
+		return x.Enc
+	}
+	return 0
+}
+
+func (x *Item) GetProperties() []string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Item) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Item) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Item) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *Item) GetMelee() *ItemMelee {
+	if x != nil {
+		return x.Melee
+	}
+	return nil
+}
+
+func (x *Item) GetRanged() *ItemRanged {
+	if x != nil {
+		return x.Ranged
+	}
+	return nil
+}
+
+func (x *Item) GetAmmunition() *ItemAmmunition {
+	if x != nil {
+		return x.Ammunition
+	}
+	return nil
+}
+
+func (x *Item) GetArmour() *ItemArmour {
+	if x != nil {
+		return x.Armour
+	}
+	return nil
+}
+
+func (x *Item) GetContainer() *ItemContainer {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+func (x *Item) GetGrimoire() *ItemGrimoire {
+	if x != nil {
+		return x.Grimoire
+	}
+	return nil
+}
+
+func (x *Item) GetOther() *ItemOther {
+	if x != nil {
+		return x.Other
+	}
+	return nil
+}
+
+type Talent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tests       string            `protobuf:"bytes,3,opt,name=tests,proto3" json:"tests,omitempty"`
+	MaxRank     int32             `protobuf:"varint,4,opt,name=max_rank,json=maxRank,proto3" json:"max_rank,omitempty"`
+	Attribute   int32             `protobuf:"varint,5,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	IsGroup     bool              `protobuf:"varint,6,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`
+	Modifiers   *Modifiers        `protobuf:"bytes,7,opt,name=modifiers,proto3" json:"modifiers,omitempty"`
+	Group       []string          `protobuf:"bytes,8,rep,name=group,proto3" json:"group,omitempty"`
+	Shared      bool              `protobuf:"varint,9,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,10,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Talent) Reset() {
+	*x = Talent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Talent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Talent) ProtoMessage() {}
+
+func (x *Talent) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Talent.ProtoReflect.Descriptor instead.
+func (*Talent) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Talent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Talent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Talent) GetTests() string {
+	if x != nil {
+		return x.Tests
+	}
+	return ""
+}
+
+func (x *Talent) GetMaxRank() int32 {
+	if x != nil {
+		return x.MaxRank
+	}
+	return 0
+}
+
+func (x *Talent) GetAttribute() int32 {
+	if x != nil {
+		return x.Attribute
+	}
+	return 0
+}
+
+func (x *Talent) GetIsGroup() bool {
+	if x != nil {
+		return x.IsGroup
+	}
+	return false
+}
+
+func (x *Talent) GetModifiers() *Modifiers {
+	if x != nil {
+		return x.Modifiers
+	}
+	return nil
+}
+
+func (x *Talent) GetGroup() []string {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *Talent) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Talent) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type Skill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Attribute   int32             `protobuf:"varint,3,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Type        int32             `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
+	IsGroup     bool              `protobuf:"varint,5,opt,name=is_group,json=isGroup,proto3" json:"is_group,omitempty"`
+	DisplayZero bool              `protobuf:"varint,6,opt,name=display_zero,json=displayZero,proto3" json:"display_zero,omitempty"`
+	Group       []string          `protobuf:"bytes,7,rep,name=group,proto3" json:"group,omitempty"`
+	Shared      bool              `protobuf:"varint,8,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,9,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Skill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Skill) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Skill) GetAttribute() int32 {
+	if x != nil {
+		return x.Attribute
+	}
+	return 0
+}
+
+func (x *Skill) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Skill) GetIsGroup() bool {
+	if x != nil {
+		return x.IsGroup
+	}
+	return false
+}
+
+func (x *Skill) GetDisplayZero() bool {
+	if x != nil {
+		return x.DisplayZero
+	}
+	return false
+}
+
+func (x *Skill) GetGroup() []string {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *Skill) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Skill) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type CareerLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status     int32    `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Standing   int32    `protobuf:"varint,3,opt,name=standing,proto3" json:"standing,omitempty"`
+	Attributes []int32  `protobuf:"varint,4,rep,packed,name=attributes,proto3" json:"attributes,omitempty"`
+	Skills     []string `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
+	Talents    []string `protobuf:"bytes,6,rep,name=talents,proto3" json:"talents,omitempty"`
+	Items      string   `protobuf:"bytes,7,opt,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *CareerLevel) Reset() {
+	*x = CareerLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CareerLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CareerLevel) ProtoMessage() {}
+
+func (x *CareerLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CareerLevel.ProtoReflect.Descriptor instead.
+func (*CareerLevel) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CareerLevel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CareerLevel) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *CareerLevel) GetStanding() int32 {
+	if x != nil {
+		return x.Standing
+	}
+	return 0
+}
+
+func (x *CareerLevel) GetAttributes() []int32 {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CareerLevel) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *CareerLevel) GetTalents() []string {
+	if x != nil {
+		return x.Talents
+	}
+	return nil
+}
+
+func (x *CareerLevel) GetItems() string {
+	if x != nil {
+		return x.Items
+	}
+	return ""
+}
+
+type Career struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Class       int32             `protobuf:"varint,3,opt,name=class,proto3" json:"class,omitempty"`
+	Species     string            `protobuf:"bytes,4,opt,name=species,proto3" json:"species,omitempty"`
+	Level1      *CareerLevel      `protobuf:"bytes,5,opt,name=level1,proto3" json:"level1,omitempty"`
+	Level2      *CareerLevel      `protobuf:"bytes,6,opt,name=level2,proto3" json:"level2,omitempty"`
+	Level3      *CareerLevel      `protobuf:"bytes,7,opt,name=level3,proto3" json:"level3,omitempty"`
+	Level4      *CareerLevel      `protobuf:"bytes,8,opt,name=level4,proto3" json:"level4,omitempty"`
+	Shared      bool              `protobuf:"varint,9,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source      map[string]string `protobuf:"bytes,10,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Career) Reset() {
+	*x = Career{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Career) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Career) ProtoMessage() {}
+
+func (x *Career) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Career.ProtoReflect.Descriptor instead.
+func (*Career) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Career) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Career) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Career) GetClass() int32 {
+	if x != nil {
+		return x.Class
+	}
+	return 0
+}
+
+func (x *Career) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *Career) GetLevel1() *CareerLevel {
+	if x != nil {
+		return x.Level1
+	}
+	return nil
+}
+
+func (x *Career) GetLevel2() *CareerLevel {
+	if x != nil {
+		return x.Level2
+	}
+	return nil
+}
+
+func (x *Career) GetLevel3() *CareerLevel {
+	if x != nil {
+		return x.Level3
+	}
+	return nil
+}
+
+func (x *Career) GetLevel4() *CareerLevel {
+	if x != nil {
+		return x.Level4
+	}
+	return nil
+}
+
+func (x *Career) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Career) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type Character struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string      `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Notes             string      `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	EquippedItems     []*IdNumber `protobuf:"bytes,4,rep,name=equipped_items,json=equippedItems,proto3" json:"equipped_items,omitempty"`
+	CarriedItems      []*IdNumber `protobuf:"bytes,5,rep,name=carried_items,json=carriedItems,proto3" json:"carried_items,omitempty"`
+	StoredItems       []*IdNumber `protobuf:"bytes,6,rep,name=stored_items,json=storedItems,proto3" json:"stored_items,omitempty"`
+	Skills            []*IdNumber `protobuf:"bytes,7,rep,name=skills,proto3" json:"skills,omitempty"`
+	Talents           []*IdNumber `protobuf:"bytes,8,rep,name=talents,proto3" json:"talents,omitempty"`
+	Species           string      `protobuf:"bytes,9,opt,name=species,proto3" json:"species,omitempty"`
+	BaseAttributes    *Attributes `protobuf:"bytes,10,opt,name=base_attributes,json=baseAttributes,proto3" json:"base_attributes,omitempty"`
+	AttributeAdvances *Attributes `protobuf:"bytes,11,opt,name=attribute_advances,json=attributeAdvances,proto3" json:"attribute_advances,omitempty"`
+	CareerPath        []string    `protobuf:"bytes,12,rep,name=career_path,json=careerPath,proto3" json:"career_path,omitempty"`
+	Career            string      `protobuf:"bytes,13,opt,name=career,proto3" json:"career,omitempty"`
+	Fate              int32       `protobuf:"varint,14,opt,name=fate,proto3" json:"fate,omitempty"`
+	Fortune           int32       `protobuf:"varint,15,opt,name=fortune,proto3" json:"fortune,omitempty"`
+	Resilience        int32       `protobuf:"varint,16,opt,name=resilience,proto3" json:"resilience,omitempty"`
+	Resolve           int32       `protobuf:"varint,17,opt,name=resolve,proto3" json:"resolve,omitempty"`
+	CurrentExp        int32       `protobuf:"varint,18,opt,name=current_exp,json=currentExp,proto3" json:"current_exp,omitempty"`
+	SpentExp          int32       `protobuf:"varint,19,opt,name=spent_exp,json=spentExp,proto3" json:"spent_exp,omitempty"`
+	Status            int32       `protobuf:"varint,20,opt,name=status,proto3" json:"status,omitempty"`
+	Standing          int32       `protobuf:"varint,21,opt,name=standing,proto3" json:"standing,omitempty"`
+	Brass             int32       `protobuf:"varint,22,opt,name=brass,proto3" json:"brass,omitempty"`
+	Silver            int32       `protobuf:"varint,23,opt,name=silver,proto3" json:"silver,omitempty"`
+	Gold              int32       `protobuf:"varint,24,opt,name=gold,proto3" json:"gold,omitempty"`
+	Spells            []string    `protobuf:"bytes,25,rep,name=spells,proto3" json:"spells,omitempty"`
+	Sin               int32       `protobuf:"varint,26,opt,name=sin,proto3" json:"sin,omitempty"`
+	Corruption        int32       `protobuf:"varint,27,opt,name=corruption,proto3" json:"corruption,omitempty"`
+	Mutations         []string    `protobuf:"bytes,28,rep,name=mutations,proto3" json:"mutations,omitempty"`
+	Shared            bool        `protobuf:"varint,29,opt,name=shared,proto3" json:"shared,omitempty"`
+}
+
+func (x *Character) Reset() {
+	*x = Character{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Character) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Character) ProtoMessage() {}
+
+func (x *Character) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Character.ProtoReflect.Descriptor instead.
+func (*Character) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Character) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Character) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Character) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Character) GetEquippedItems() []*IdNumber {
+	if x != nil {
+		return x.EquippedItems
+	}
+	return nil
+}
+
+func (x *Character) GetCarriedItems() []*IdNumber {
+	if x != nil {
+		return x.CarriedItems
+	}
+	return nil
+}
+
+func (x *Character) GetStoredItems() []*IdNumber {
+	if x != nil {
+		return x.StoredItems
+	}
+	return nil
+}
+
+func (x *Character) GetSkills() []*IdNumber {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Character) GetTalents() []*IdNumber {
+	if x != nil {
+		return x.Talents
+	}
+	return nil
+}
+
+func (x *Character) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *Character) GetBaseAttributes() *Attributes {
+	if x != nil {
+		return x.BaseAttributes
+	}
+	return nil
+}
+
+func (x *Character) GetAttributeAdvances() *Attributes {
+	if x != nil {
+		return x.AttributeAdvances
+	}
+	return nil
+}
+
+func (x *Character) GetCareerPath() []string {
+	if x != nil {
+		return x.CareerPath
+	}
+	return nil
+}
+
+func (x *Character) GetCareer() string {
+	if x != nil {
+		return x.Career
+	}
+	return ""
+}
+
+func (x *Character) GetFate() int32 {
+	if x != nil {
+		return x.Fate
+	}
+	return 0
+}
+
+func (x *Character) GetFortune() int32 {
+	if x != nil {
+		return x.Fortune
+	}
+	return 0
+}
+
+func (x *Character) GetResilience() int32 {
+	if x != nil {
+		return x.Resilience
+	}
+	return 0
+}
+
+func (x *Character) GetResolve() int32 {
+	if x != nil {
+		return x.Resolve
+	}
+	return 0
+}
+
+func (x *Character) GetCurrentExp() int32 {
+	if x != nil {
+		return x.CurrentExp
+	}
+	return 0
+}
+
+func (x *Character) GetSpentExp() int32 {
+	if x != nil {
+		return x.SpentExp
+	}
+	return 0
+}
+
+func (x *Character) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Character) GetStanding() int32 {
+	if x != nil {
+		return x.Standing
+	}
+	return 0
+}
+
+func (x *Character) GetBrass() int32 {
+	if x != nil {
+		return x.Brass
+	}
+	return 0
+}
+
+func (x *Character) GetSilver() int32 {
+	if x != nil {
+		return x.Silver
+	}
+	return 0
+}
+
+func (x *Character) GetGold() int32 {
+	if x != nil {
+		return x.Gold
+	}
+	return 0
+}
+
+func (x *Character) GetSpells() []string {
+	if x != nil {
+		return x.Spells
+	}
+	return nil
+}
+
+func (x *Character) GetSin() int32 {
+	if x != nil {
+		return x.Sin
+	}
+	return 0
+}
+
+func (x *Character) GetCorruption() int32 {
+	if x != nil {
+		return x.Corruption
+	}
+	return 0
+}
+
+func (x *Character) GetMutations() []string {
+	if x != nil {
+		return x.Mutations
+	}
+	return nil
+}
+
+func (x *Character) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+type Species struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	BaseSpecies    string            `protobuf:"bytes,3,opt,name=base_species,json=baseSpecies,proto3" json:"base_species,omitempty"`
+	BaseAttributes *Attributes       `protobuf:"bytes,4,opt,name=base_attributes,json=baseAttributes,proto3" json:"base_attributes,omitempty"`
+	AttributeDice  int32             `protobuf:"varint,5,opt,name=attribute_dice,json=attributeDice,proto3" json:"attribute_dice,omitempty"`
+	Fate           int32             `protobuf:"varint,6,opt,name=fate,proto3" json:"fate,omitempty"`
+	Resilience     int32             `protobuf:"varint,7,opt,name=resilience,proto3" json:"resilience,omitempty"`
+	ExtraPoints    int32             `protobuf:"varint,8,opt,name=extra_points,json=extraPoints,proto3" json:"extra_points,omitempty"`
+	Movement       int32             `protobuf:"varint,9,opt,name=movement,proto3" json:"movement,omitempty"`
+	Size           int32             `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`
+	Shared         bool              `protobuf:"varint,11,opt,name=shared,proto3" json:"shared,omitempty"`
+	Source         map[string]string `protobuf:"bytes,12,rep,name=source,proto3" json:"source,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Species) Reset() {
+	*x = Species{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Species) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Species) ProtoMessage() {}
+
+func (x *Species) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Species.ProtoReflect.Descriptor instead.
+func (*Species) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Species) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Species) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Species) GetBaseSpecies() string {
+	if x != nil {
+		return x.BaseSpecies
+	}
+	return ""
+}
+
+func (x *Species) GetBaseAttributes() *Attributes {
+	if x != nil {
+		return x.BaseAttributes
+	}
+	return nil
+}
+
+func (x *Species) GetAttributeDice() int32 {
+	if x != nil {
+		return x.AttributeDice
+	}
+	return 0
+}
+
+func (x *Species) GetFate() int32 {
+	if x != nil {
+		return x.Fate
+	}
+	return 0
+}
+
+func (x *Species) GetResilience() int32 {
+	if x != nil {
+		return x.Resilience
+	}
+	return 0
+}
+
+func (x *Species) GetExtraPoints() int32 {
+	if x != nil {
+		return x.ExtraPoints
+	}
+	return 0
+}
+
+func (x *Species) GetMovement() int32 {
+	if x != nil {
+		return x.Movement
+	}
+	return 0
+}
+
+func (x *Species) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Species) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+func (x *Species) GetSource() map[string]string {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type Wh struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId        string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	CanEdit        bool                   `protobuf:"varint,3,opt,name=can_edit,json=canEdit,proto3" json:"can_edit,omitempty"`
+	Version        int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastModifiedBy string                 `protobuf:"bytes,7,opt,name=last_modified_by,json=lastModifiedBy,proto3" json:"last_modified_by,omitempty"`
+	// Types that are assignable to Object:
+	//	*Wh_Mutation
+	//	*Wh_Spell
+	//	*Wh_Property
+	//	*Wh_Item
+	//	*Wh_Talent
+	//	*Wh_Skill
+	//	*Wh_Career
+	//	*Wh_Character
+	//	*Wh_Species
+	Object isWh_Object `protobuf_oneof:"object"`
+}
+
+func (x *Wh) Reset() {
+	*x = Wh{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Wh) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wh) ProtoMessage() {}
+
+func (x *Wh) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wh.ProtoReflect.Descriptor instead.
+func (*Wh) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Wh) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Wh) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Wh) GetCanEdit() bool {
+	if x != nil {
+		return x.CanEdit
+	}
+	return false
+}
+
+func (x *Wh) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Wh) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Wh) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Wh) GetLastModifiedBy() string {
+	if x != nil {
+		return x.LastModifiedBy
+	}
+	return ""
+}
+
+func (m *Wh) GetObject() isWh_Object {
+	if m != nil {
+		return m.Object
+	}
+	return nil
+}
+
+func (x *Wh) GetMutation() *Mutation {
+	if x, ok := x.GetObject().(*Wh_Mutation); ok {
+		return x.Mutation
+	}
+	return nil
+}
+
+func (x *Wh) GetSpell() *Spell {
+	if x, ok := x.GetObject().(*Wh_Spell); ok {
+		return x.Spell
+	}
+	return nil
+}
+
+func (x *Wh) GetProperty() *Property {
+	if x, ok := x.GetObject().(*Wh_Property); ok {
+		return x.Property
+	}
+	return nil
+}
+
+func (x *Wh) GetItem() *Item {
+	if x, ok := x.GetObject().(*Wh_Item); ok {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *Wh) GetTalent() *Talent {
+	if x, ok := x.GetObject().(*Wh_Talent); ok {
+		return x.Talent
+	}
+	return nil
+}
+
+func (x *Wh) GetSkill() *Skill {
+	if x, ok := x.GetObject().(*Wh_Skill); ok {
+		return x.Skill
+	}
+	return nil
+}
+
+func (x *Wh) GetCareer() *Career {
+	if x, ok := x.GetObject().(*Wh_Career); ok {
+		return x.Career
+	}
+	return nil
+}
+
+func (x *Wh) GetCharacter() *Character {
+	if x, ok := x.GetObject().(*Wh_Character); ok {
+		return x.Character
+	}
+	return nil
+}
+
+func (x *Wh) GetSpecies() *Species {
+	if x, ok := x.GetObject().(*Wh_Species); ok {
+		return x.Species
+	}
+	return nil
+}
+
+type isWh_Object interface {
+	isWh_Object()
+}
+
+type Wh_Mutation struct {
+	Mutation *Mutation `protobuf:"bytes,10,opt,name=mutation,proto3,oneof"`
+}
+
+type Wh_Spell struct {
+	Spell *Spell `protobuf:"bytes,11,opt,name=spell,proto3,oneof"`
+}
+
+type Wh_Property struct {
+	Property *Property `protobuf:"bytes,12,opt,name=property,proto3,oneof"`
+}
+
+type Wh_Item struct {
+	Item *Item `protobuf:"bytes,13,opt,name=item,proto3,oneof"`
+}
+
+type Wh_Talent struct {
+	Talent *Talent `protobuf:"bytes,14,opt,name=talent,proto3,oneof"`
+}
+
+type Wh_Skill struct {
+	Skill *Skill `protobuf:"bytes,15,opt,name=skill,proto3,oneof"`
+}
+
+type Wh_Career struct {
+	Career *Career `protobuf:"bytes,16,opt,name=career,proto3,oneof"`
+}
+
+type Wh_Character struct {
+	Character *Character `protobuf:"bytes,17,opt,name=character,proto3,oneof"`
+}
+
+type Wh_Species struct {
+	Species *Species `protobuf:"bytes,18,opt,name=species,proto3,oneof"`
+}
+
+func (*Wh_Mutation) isWh_Object() {}
+
+func (*Wh_Spell) isWh_Object() {}
+
+func (*Wh_Property) isWh_Object() {}
+
+func (*Wh_Item) isWh_Object() {}
+
+func (*Wh_Talent) isWh_Object() {}
+
+func (*Wh_Skill) isWh_Object() {}
+
+func (*Wh_Career) isWh_Object() {}
+
+func (*Wh_Character) isWh_Object() {}
+
+func (*Wh_Species) isWh_Object() {}
+
+type GetWhRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type WhType `protobuf:"varint,1,opt,name=type,proto3,enum=wfrp.v1.WhType" json:"type,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetWhRequest) Reset() {
+	*x = GetWhRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWhRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWhRequest) ProtoMessage() {}
+
+func (x *GetWhRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWhRequest.ProtoReflect.Descriptor instead.
+func (*GetWhRequest) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetWhRequest) GetType() WhType {
+	if x != nil {
+		return x.Type
+	}
+	return WhType_WH_TYPE_UNSPECIFIED
+}
+
+func (x *GetWhRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListWhRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type WhType `protobuf:"varint,1,opt,name=type,proto3,enum=wfrp.v1.WhType" json:"type,omitempty"`
+	// All objects visible to the caller are listed if ids are empty.
+	Ids []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ListWhRequest) Reset() {
+	*x = ListWhRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWhRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWhRequest) ProtoMessage() {}
+
+func (x *ListWhRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWhRequest.ProtoReflect.Descriptor instead.
+func (*ListWhRequest) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListWhRequest) GetType() WhType {
+	if x != nil {
+		return x.Type
+	}
+	return WhType_WH_TYPE_UNSPECIFIED
+}
+
+func (x *ListWhRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type CreateWhRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type is taken from the object set in wh, id and headers are ignored.
+	Wh *Wh `protobuf:"bytes,1,opt,name=wh,proto3" json:"wh,omitempty"`
+}
+
+func (x *CreateWhRequest) Reset() {
+	*x = CreateWhRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateWhRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWhRequest) ProtoMessage() {}
+
+func (x *CreateWhRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWhRequest.ProtoReflect.Descriptor instead.
+func (*CreateWhRequest) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateWhRequest) GetWh() *Wh {
+	if x != nil {
+		return x.Wh
+	}
+	return nil
+}
+
+type UpdateWhRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Id and version of wh are required, version has to match the stored one.
+	Wh *Wh `protobuf:"bytes,1,opt,name=wh,proto3" json:"wh,omitempty"`
+}
+
+func (x *UpdateWhRequest) Reset() {
+	*x = UpdateWhRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateWhRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWhRequest) ProtoMessage() {}
+
+func (x *UpdateWhRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWhRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWhRequest) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateWhRequest) GetWh() *Wh {
+	if x != nil {
+		return x.Wh
+	}
+	return nil
+}
+
+type DeleteWhRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type WhType `protobuf:"varint,1,opt,name=type,proto3,enum=wfrp.v1.WhType" json:"type,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteWhRequest) Reset() {
+	*x = DeleteWhRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWhRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWhRequest) ProtoMessage() {}
+
+func (x *DeleteWhRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWhRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWhRequest) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteWhRequest) GetType() WhType {
+	if x != nil {
+		return x.Type
+	}
+	return WhType_WH_TYPE_UNSPECIFIED
+}
+
+func (x *DeleteWhRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteWhResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteWhResponse) Reset() {
+	*x = DeleteWhResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wfrp_v1_wh_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWhResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWhResponse) ProtoMessage() {}
+
+func (x *DeleteWhResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wfrp_v1_wh_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWhResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWhResponse) Descriptor() ([]byte, []int) {
+	return file_wfrp_v1_wh_proto_rawDescGZIP(), []int{26}
+}
+
+var File_wfrp_v1_wh_proto protoreflect.FileDescriptor
+
+var file_wfrp_v1_wh_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x77, 0x66, 0x72, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x07, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xac, 0x01, 0x0a,
+	0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x77,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x57, 0x53, 0x12, 0x0e, 0x0a, 0x02, 0x62,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x42, 0x53, 0x12, 0x0c, 0x0a, 0x01, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x53, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x54, 0x12, 0x0c, 0x0a, 0x01, 0x69, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x49, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x41, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x44, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x49, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x77, 0x70, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x57, 0x50, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x6c,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x46, 0x65, 0x6c, 0x22, 0x70, 0x0a, 0x09, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77,
+	0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x52, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x22, 0x32, 0x0a,
+	0x08, 0x49, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x22, 0x90, 0x02, 0x0a, 0x08, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x66,
+	0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x52,
+	0x09, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x9e, 0x02, 0x0a, 0x05, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x63, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x63, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x83, 0x02, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x62, 0x6c, 0x65, 0x54,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x66, 0x72, 0x70,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7f, 0x0a, 0x09, 0x49,
+	0x74, 0x65, 0x6d, 0x4d, 0x65, 0x6c, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x61, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x6d, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x64, 0x6d, 0x67,
+	0x12, 0x1e, 0x0a, 0x0b, 0x64, 0x6d, 0x67, 0x5f, 0x73, 0x62, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x6d, 0x67, 0x53, 0x62, 0x4d, 0x75, 0x6c, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x72, 0x65, 0x61, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x9c, 0x01, 0x0a,
+	0x0a, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x68,
+	0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x68, 0x61, 0x6e, 0x64,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6d, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x64, 0x6d, 0x67, 0x12, 0x1e, 0x0a, 0x0b, 0x64, 0x6d, 0x67, 0x5f, 0x73, 0x62, 0x5f, 0x6d, 0x75,
+	0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x6d, 0x67, 0x53, 0x62, 0x4d,
+	0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x72, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0b, 0x72, 0x6e, 0x67, 0x5f, 0x73, 0x62, 0x5f,
+	0x6d, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x72, 0x6e, 0x67, 0x53,
+	0x62, 0x4d, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x65, 0x0a, 0x0e, 0x49,
+	0x74, 0x65, 0x6d, 0x41, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x64, 0x6d, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x64, 0x6d, 0x67, 0x12,
+	0x10, 0x0a, 0x03, 0x72, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6e,
+	0x67, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6e, 0x67, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x72, 0x6e, 0x67, 0x4d, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x22, 0x56, 0x0a, 0x0a, 0x49, 0x74, 0x65, 0x6d, 0x41, 0x72, 0x6d, 0x6f, 0x75, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x4a, 0x0a, 0x0d, 0x49, 0x74,
+	0x65, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x72, 0x72, 0x79,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x61, 0x72,
+	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0x26, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x6d, 0x47, 0x72,
+	0x69, 0x6d, 0x6f, 0x69, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x2a,
+	0x0a, 0x09, 0x49, 0x74, 0x65, 0x6d, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x61, 0x72, 0x72, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x63, 0x61, 0x72, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0xee, 0x04, 0x0a, 0x04, 0x49,
+	0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x6e, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x65, 0x6e,
+	0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x31, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x28, 0x0a, 0x05, 0x6d, 0x65, 0x6c, 0x65, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x4d, 0x65,
+	0x6c, 0x65, 0x65, 0x52, 0x05, 0x6d, 0x65, 0x6c, 0x65, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77, 0x66, 0x72,
+	0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52,
+	0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x6d, 0x6d, 0x75, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x66,
+	0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x41, 0x6d, 0x6d, 0x75, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x61, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2b, 0x0a, 0x06, 0x61, 0x72, 0x6d, 0x6f, 0x75, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x41,
+	0x72, 0x6d, 0x6f, 0x75, 0x72, 0x52, 0x06, 0x61, 0x72, 0x6d, 0x6f, 0x75, 0x72, 0x12, 0x34, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x08, 0x67, 0x72, 0x69, 0x6d, 0x6f, 0x69, 0x72, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x74, 0x65, 0x6d, 0x47, 0x72, 0x69, 0x6d, 0x6f, 0x69, 0x72, 0x65, 0x52, 0x08, 0x67, 0x72,
+	0x69, 0x6d, 0x6f, 0x69, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x74, 0x65, 0x6d, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf8, 0x02, 0x0a, 0x06,
+	0x54, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69,
+	0x73, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
+	0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x30, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x66, 0x72, 0x70,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x52, 0x09, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xca, 0x02, 0x0a, 0x05, 0x53, 0x6b, 0x69, 0x6c, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x7a,
+	0x65, 0x72, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x5a, 0x65, 0x72, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6b, 0x69, 0x6c, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xbd, 0x01, 0x0a, 0x0b, 0x43, 0x61, 0x72, 0x65, 0x65, 0x72, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6b, 0x69,
+	0x6c, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x22, 0xae, 0x03, 0x0a, 0x06, 0x43, 0x61, 0x72, 0x65, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70,
+	0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x31, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x72, 0x65, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x31, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72,
+	0x65, 0x65, 0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32,
+	0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x65, 0x65,
+	0x72, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x12, 0x2c,
+	0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x34, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72, 0x65, 0x65, 0x72, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x34, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x72, 0x65, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x07, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x38,
+	0x0a, 0x0e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x0d, 0x65, 0x71, 0x75, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x36, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x52, 0x0c, 0x63, 0x61, 0x72, 0x72, 0x69, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x34, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c,
+	0x73, 0x12, 0x2b, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0f, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x12, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x11, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x41, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61,
+	0x72, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x61, 0x72, 0x65, 0x65, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x61, 0x72, 0x65, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x72,
+	0x65, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x74, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x66, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6f, 0x72, 0x74, 0x75,
+	0x6e, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x66, 0x6f, 0x72, 0x74, 0x75, 0x6e,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x62, 0x72, 0x61, 0x73, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x62, 0x72,
+	0x61, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x6c, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x67,
+	0x6f, 0x6c, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x67, 0x6f, 0x6c, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x19, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x6e, 0x18, 0x1a,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63,
+	0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x22,
+	0xd7, 0x03, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x53, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0f, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x52, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x5f,
+	0x64, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x44, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x66, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x39,
+	0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9d, 0x05, 0x0a, 0x02, 0x57, 0x68,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x63,
+	0x61, 0x6e, 0x5f, 0x65, 0x64, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63,
+	0x61, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x42, 0x79,
+	0x12, 0x2f, 0x0a, 0x08, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65, 0x6c, 0x6c,
+	0x48, 0x00, 0x52, 0x05, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x66,
+	0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x48, 0x00,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x04, 0x69, 0x74,
+	0x65, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x48, 0x00, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12,
+	0x29, 0x0a, 0x06, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x6c, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x06, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x6b,
+	0x69, 0x6c, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x77, 0x66, 0x72, 0x70,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x73, 0x6b, 0x69,
+	0x6c, 0x6c, 0x12, 0x29, 0x0a, 0x06, 0x63, 0x61, 0x72, 0x65, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72,
+	0x65, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x63, 0x61, 0x72, 0x65, 0x65, 0x72, 0x12, 0x32, 0x0a,
+	0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65,
+	0x72, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x42,
+	0x08, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x46,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
+	0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x2e, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x02, 0x77, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x68, 0x52, 0x02, 0x77, 0x68, 0x22, 0x2e, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x02, 0x77, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x68, 0x52, 0x02, 0x77, 0x68, 0x22, 0x46, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x12,
+	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2a, 0xd9, 0x01, 0x0a, 0x06, 0x57, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a,
+	0x13, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4d, 0x55, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x4c, 0x4c, 0x10, 0x02, 0x12,
+	0x14, 0x0a, 0x10, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x45,
+	0x52, 0x54, 0x59, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x49, 0x54, 0x45, 0x4d, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x57, 0x48, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x54, 0x41, 0x4c, 0x45, 0x4e, 0x54, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x57,
+	0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x06, 0x12, 0x12,
+	0x0a, 0x0e, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x52, 0x45, 0x45, 0x52,
+	0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x57, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48,
+	0x41, 0x52, 0x41, 0x43, 0x54, 0x45, 0x52, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f, 0x57, 0x48, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x10, 0x09, 0x32, 0x90,
+	0x02, 0x0a, 0x09, 0x57, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x05,
+	0x47, 0x65, 0x74, 0x57, 0x68, 0x12, 0x15, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x77,
+	0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x68, 0x12, 0x2f, 0x0a, 0x06, 0x4c, 0x69, 0x73,
+	0x74, 0x57, 0x68, 0x12, 0x16, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x77, 0x66,
+	0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x68, 0x30, 0x01, 0x12, 0x31, 0x0a, 0x08, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x57, 0x68, 0x12, 0x18, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x68, 0x12, 0x31, 0x0a,
+	0x08, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x68, 0x12, 0x18, 0x2e, 0x77, 0x66, 0x72, 0x70,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x68,
+	0x12, 0x3f, 0x0a, 0x08, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x68, 0x12, 0x18, 0x2e, 0x77,
+	0x66, 0x72, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x66, 0x72, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x50, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6a, 0x6d, 0x69, 0x6c, 0x6f, 0x73, 0x7a, 0x65, 0x2f, 0x77, 0x66, 0x72, 0x70, 0x2d, 0x68, 0x61,
+	0x6d, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x77, 0x66, 0x72, 0x70, 0x76, 0x31, 0x3b, 0x77, 0x66, 0x72,
+	0x70, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_wfrp_v1_wh_proto_rawDescOnce sync.Once
+	file_wfrp_v1_wh_proto_rawDescData = file_wfrp_v1_wh_proto_rawDesc
+)
+
+func file_wfrp_v1_wh_proto_rawDescGZIP() []byte {
+	file_wfrp_v1_wh_proto_rawDescOnce.Do(func() {
+		file_wfrp_v1_wh_proto_rawDescData = protoimpl.X.CompressGZIP(file_wfrp_v1_wh_proto_rawDescData)
+	})
+	return file_wfrp_v1_wh_proto_rawDescData
+}
+
+var file_wfrp_v1_wh_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_wfrp_v1_wh_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_wfrp_v1_wh_proto_goTypes = []interface{}{
+	(WhType)(0),                   // 0: wfrp.v1.WhType
+	(*Attributes)(nil),            // 1: wfrp.v1.Attributes
+	(*Modifiers)(nil),             // 2: wfrp.v1.Modifiers
+	(*IdNumber)(nil),              // 3: wfrp.v1.IdNumber
+	(*Mutation)(nil),              // 4: wfrp.v1.Mutation
+	(*Spell)(nil),                 // 5: wfrp.v1.Spell
+	(*Property)(nil),              // 6: wfrp.v1.Property
+	(*ItemMelee)(nil),             // 7: wfrp.v1.ItemMelee
+	(*ItemRanged)(nil),            // 8: wfrp.v1.ItemRanged
+	(*ItemAmmunition)(nil),        // 9: wfrp.v1.ItemAmmunition
+	(*ItemArmour)(nil),            // 10: wfrp.v1.ItemArmour
+	(*ItemContainer)(nil),         // 11: wfrp.v1.ItemContainer
+	(*ItemGrimoire)(nil),          // 12: wfrp.v1.ItemGrimoire
+	(*ItemOther)(nil),             // 13: wfrp.v1.ItemOther
+	(*Item)(nil),                  // 14: wfrp.v1.Item
+	(*Talent)(nil),                // 15: wfrp.v1.Talent
+	(*Skill)(nil),                 // 16: wfrp.v1.Skill
+	(*CareerLevel)(nil),           // 17: wfrp.v1.CareerLevel
+	(*Career)(nil),                // 18: wfrp.v1.Career
+	(*Character)(nil),             // 19: wfrp.v1.Character
+	(*Species)(nil),               // 20: wfrp.v1.Species
+	(*Wh)(nil),                    // 21: wfrp.v1.Wh
+	(*GetWhRequest)(nil),          // 22: wfrp.v1.GetWhRequest
+	(*ListWhRequest)(nil),         // 23: wfrp.v1.ListWhRequest
+	(*CreateWhRequest)(nil),       // 24: wfrp.v1.CreateWhRequest
+	(*UpdateWhRequest)(nil),       // 25: wfrp.v1.UpdateWhRequest
+	(*DeleteWhRequest)(nil),       // 26: wfrp.v1.DeleteWhRequest
+	(*DeleteWhResponse)(nil),      // 27: wfrp.v1.DeleteWhResponse
+	nil,                           // 28: wfrp.v1.Mutation.SourceEntry
+	nil,                           // 29: wfrp.v1.Spell.SourceEntry
+	nil,                           // 30: wfrp.v1.Property.SourceEntry
+	nil,                           // 31: wfrp.v1.Item.SourceEntry
+	nil,                           // 32: wfrp.v1.Talent.SourceEntry
+	nil,                           // 33: wfrp.v1.Skill.SourceEntry
+	nil,                           // 34: wfrp.v1.Career.SourceEntry
+	nil,                           // 35: wfrp.v1.Species.SourceEntry
+	(*timestamppb.Timestamp)(nil), // 36: google.protobuf.Timestamp
+}
+var file_wfrp_v1_wh_proto_depIdxs = []int32{
+	1,  // 0: wfrp.v1.Modifiers.attributes:type_name -> wfrp.v1.Attributes
+	2,  // 1: wfrp.v1.Mutation.modifiers:type_name -> wfrp.v1.Modifiers
+	28, // 2: wfrp.v1.Mutation.source:type_name -> wfrp.v1.Mutation.SourceEntry
+	29, // 3: wfrp.v1.Spell.source:type_name -> wfrp.v1.Spell.SourceEntry
+	30, // 4: wfrp.v1.Property.source:type_name -> wfrp.v1.Property.SourceEntry
+	31, // 5: wfrp.v1.Item.source:type_name -> wfrp.v1.Item.SourceEntry
+	7,  // 6: wfrp.v1.Item.melee:type_name -> wfrp.v1.ItemMelee
+	8,  // 7: wfrp.v1.Item.ranged:type_name -> wfrp.v1.ItemRanged
+	9,  // 8: wfrp.v1.Item.ammunition:type_name -> wfrp.v1.ItemAmmunition
+	10, // 9: wfrp.v1.Item.armour:type_name -> wfrp.v1.ItemArmour
+	11, // 10: wfrp.v1.Item.container:type_name -> wfrp.v1.ItemContainer
+	12, // 11: wfrp.v1.Item.grimoire:type_name -> wfrp.v1.ItemGrimoire
+	13, // 12: wfrp.v1.Item.other:type_name -> wfrp.v1.ItemOther
+	2,  // 13: wfrp.v1.Talent.modifiers:type_name -> wfrp.v1.Modifiers
+	32, // 14: wfrp.v1.Talent.source:type_name -> wfrp.v1.Talent.SourceEntry
+	33, // 15: wfrp.v1.Skill.source:type_name -> wfrp.v1.Skill.SourceEntry
+	17, // 16: wfrp.v1.Career.level1:type_name -> wfrp.v1.CareerLevel
+	17, // 17: wfrp.v1.Career.level2:type_name -> wfrp.v1.CareerLevel
+	17, // 18: wfrp.v1.Career.level3:type_name -> wfrp.v1.CareerLevel
+	17, // 19: wfrp.v1.Career.level4:type_name -> wfrp.v1.CareerLevel
+	34, // 20: wfrp.v1.Career.source:type_name -> wfrp.v1.Career.SourceEntry
+	3,  // 21: wfrp.v1.Character.equipped_items:type_name -> wfrp.v1.IdNumber
+	3,  // 22: wfrp.v1.Character.carried_items:type_name -> wfrp.v1.IdNumber
+	3,  // 23: wfrp.v1.Character.stored_items:type_name -> wfrp.v1.IdNumber
+	3,  // 24: wfrp.v1.Character.skills:type_name -> wfrp.v1.IdNumber
+	3,  // 25: wfrp.v1.Character.talents:type_name -> wfrp.v1.IdNumber
+	1,  // 26: wfrp.v1.Character.base_attributes:type_name -> wfrp.v1.Attributes
+	1,  // 27: wfrp.v1.Character.attribute_advances:type_name -> wfrp.v1.Attributes
+	1,  // 28: wfrp.v1.Species.base_attributes:type_name -> wfrp.v1.Attributes
+	35, // 29: wfrp.v1.Species.source:type_name -> wfrp.v1.Species.SourceEntry
+	36, // 30: wfrp.v1.Wh.created_at:type_name -> google.protobuf.Timestamp
+	36, // 31: wfrp.v1.Wh.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 32: wfrp.v1.Wh.mutation:type_name -> wfrp.v1.Mutation
+	5,  // 33: wfrp.v1.Wh.spell:type_name -> wfrp.v1.Spell
+	6,  // 34: wfrp.v1.Wh.property:type_name -> wfrp.v1.Property
+	14, // 35: wfrp.v1.Wh.item:type_name -> wfrp.v1.Item
+	15, // 36: wfrp.v1.Wh.talent:type_name -> wfrp.v1.Talent
+	16, // 37: wfrp.v1.Wh.skill:type_name -> wfrp.v1.Skill
+	18, // 38: wfrp.v1.Wh.career:type_name -> wfrp.v1.Career
+	19, // 39: wfrp.v1.Wh.character:type_name -> wfrp.v1.Character
+	20, // 40: wfrp.v1.Wh.species:type_name -> wfrp.v1.Species
+	0,  // 41: wfrp.v1.GetWhRequest.type:type_name -> wfrp.v1.WhType
+	0,  // 42: wfrp.v1.ListWhRequest.type:type_name -> wfrp.v1.WhType
+	21, // 43: wfrp.v1.CreateWhRequest.wh:type_name -> wfrp.v1.Wh
+	21, // 44: wfrp.v1.UpdateWhRequest.wh:type_name -> wfrp.v1.Wh
+	0,  // 45: wfrp.v1.DeleteWhRequest.type:type_name -> wfrp.v1.WhType
+	22, // 46: wfrp.v1.WhService.GetWh:input_type -> wfrp.v1.GetWhRequest
+	23, // 47: wfrp.v1.WhService.ListWh:input_type -> wfrp.v1.ListWhRequest
+	24, // 48: wfrp.v1.WhService.CreateWh:input_type -> wfrp.v1.CreateWhRequest
+	25, // 49: wfrp.v1.WhService.UpdateWh:input_type -> wfrp.v1.UpdateWhRequest
+	26, // 50: wfrp.v1.WhService.DeleteWh:input_type -> wfrp.v1.DeleteWhRequest
+	21, // 51: wfrp.v1.WhService.GetWh:output_type -> wfrp.v1.Wh
+	21, // 52: wfrp.v1.WhService.ListWh:output_type -> wfrp.v1.Wh
+	21, // 53: wfrp.v1.WhService.CreateWh:output_type -> wfrp.v1.Wh
+	21, // 54: wfrp.v1.WhService.UpdateWh:output_type -> wfrp.v1.Wh
+	27, // 55: wfrp.v1.WhService.DeleteWh:output_type -> wfrp.v1.DeleteWhResponse
+	51, // [51:56] is the sub-list for method output_type
+	46, // [46:51] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
+}
+
+func init() { file_wfrp_v1_wh_proto_init() }
+func file_wfrp_v1_wh_proto_init() {
+	if File_wfrp_v1_wh_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_wfrp_v1_wh_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attributes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Modifiers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IdNumber); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mutation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Property); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemMelee); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemRanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemAmmunition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemArmour); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemContainer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemGrimoire); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ItemOther); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Talent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Skill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CareerLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Career); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Character); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Species); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Wh); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWhRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWhRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateWhRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateWhRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWhRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wfrp_v1_wh_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWhResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_wfrp_v1_wh_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*Wh_Mutation)(nil),
+		(*Wh_Spell)(nil),
+		(*Wh_Property)(nil),
+		(*Wh_Item)(nil),
+		(*Wh_Talent)(nil),
+		(*Wh_Skill)(nil),
+		(*Wh_Career)(nil),
+		(*Wh_Character)(nil),
+		(*Wh_Species)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_wfrp_v1_wh_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wfrp_v1_wh_proto_goTypes,
+		DependencyIndexes: file_wfrp_v1_wh_proto_depIdxs,
+		EnumInfos:         file_wfrp_v1_wh_proto_enumTypes,
+		MessageInfos:      file_wfrp_v1_wh_proto_msgTypes,
+	}.Build()
+	File_wfrp_v1_wh_proto = out.File
+	file_wfrp_v1_wh_proto_rawDesc = nil
+	file_wfrp_v1_wh_proto_goTypes = nil
+	file_wfrp_v1_wh_proto_depIdxs = nil
+}
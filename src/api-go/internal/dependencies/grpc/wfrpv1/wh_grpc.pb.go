@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: wfrp/v1/wh.proto
+
+package wfrpv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	WhService_GetWh_FullMethodName    = "/wfrp.v1.WhService/GetWh"
+	WhService_ListWh_FullMethodName   = "/wfrp.v1.WhService/ListWh"
+	WhService_CreateWh_FullMethodName = "/wfrp.v1.WhService/CreateWh"
+	WhService_UpdateWh_FullMethodName = "/wfrp.v1.WhService/UpdateWh"
+	WhService_DeleteWh_FullMethodName = "/wfrp.v1.WhService/DeleteWh"
+)
+
+// WhServiceClient is the client API for WhService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WhServiceClient interface {
+	GetWh(ctx context.Context, in *GetWhRequest, opts ...grpc.CallOption) (*Wh, error)
+	ListWh(ctx context.Context, in *ListWhRequest, opts ...grpc.CallOption) (WhService_ListWhClient, error)
+	CreateWh(ctx context.Context, in *CreateWhRequest, opts ...grpc.CallOption) (*Wh, error)
+	UpdateWh(ctx context.Context, in *UpdateWhRequest, opts ...grpc.CallOption) (*Wh, error)
+	DeleteWh(ctx context.Context, in *DeleteWhRequest, opts ...grpc.CallOption) (*DeleteWhResponse, error)
+}
+
+type whServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWhServiceClient(cc grpc.ClientConnInterface) WhServiceClient {
+	return &whServiceClient{cc}
+}
+
+func (c *whServiceClient) GetWh(ctx context.Context, in *GetWhRequest, opts ...grpc.CallOption) (*Wh, error) {
+	out := new(Wh)
+	err := c.cc.Invoke(ctx, WhService_GetWh_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whServiceClient) ListWh(ctx context.Context, in *ListWhRequest, opts ...grpc.CallOption) (WhService_ListWhClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WhService_ServiceDesc.Streams[0], WhService_ListWh_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &whServiceListWhClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WhService_ListWhClient interface {
+	Recv() (*Wh, error)
+	grpc.ClientStream
+}
+
+type whServiceListWhClient struct {
+	grpc.ClientStream
+}
+
+func (x *whServiceListWhClient) Recv() (*Wh, error) {
+	m := new(Wh)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *whServiceClient) CreateWh(ctx context.Context, in *CreateWhRequest, opts ...grpc.CallOption) (*Wh, error) {
+	out := new(Wh)
+	err := c.cc.Invoke(ctx, WhService_CreateWh_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whServiceClient) UpdateWh(ctx context.Context, in *UpdateWhRequest, opts ...grpc.CallOption) (*Wh, error) {
+	out := new(Wh)
+	err := c.cc.Invoke(ctx, WhService_UpdateWh_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whServiceClient) DeleteWh(ctx context.Context, in *DeleteWhRequest, opts ...grpc.CallOption) (*DeleteWhResponse, error) {
+	out := new(DeleteWhResponse)
+	err := c.cc.Invoke(ctx, WhService_DeleteWh_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WhServiceServer is the server API for WhService service.
+// All implementations must embed UnimplementedWhServiceServer
+// for forward compatibility
+type WhServiceServer interface {
+	GetWh(context.Context, *GetWhRequest) (*Wh, error)
+	ListWh(*ListWhRequest, WhService_ListWhServer) error
+	CreateWh(context.Context, *CreateWhRequest) (*Wh, error)
+	UpdateWh(context.Context, *UpdateWhRequest) (*Wh, error)
+	DeleteWh(context.Context, *DeleteWhRequest) (*DeleteWhResponse, error)
+	mustEmbedUnimplementedWhServiceServer()
+}
+
+// UnimplementedWhServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWhServiceServer struct {
+}
+
+func (UnimplementedWhServiceServer) GetWh(context.Context, *GetWhRequest) (*Wh, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWh not implemented")
+}
+func (UnimplementedWhServiceServer) ListWh(*ListWhRequest, WhService_ListWhServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListWh not implemented")
+}
+func (UnimplementedWhServiceServer) CreateWh(context.Context, *CreateWhRequest) (*Wh, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWh not implemented")
+}
+func (UnimplementedWhServiceServer) UpdateWh(context.Context, *UpdateWhRequest) (*Wh, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWh not implemented")
+}
+func (UnimplementedWhServiceServer) DeleteWh(context.Context, *DeleteWhRequest) (*DeleteWhResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWh not implemented")
+}
+func (UnimplementedWhServiceServer) mustEmbedUnimplementedWhServiceServer() {}
+
+// UnsafeWhServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WhServiceServer will
+// result in compilation errors.
+type UnsafeWhServiceServer interface {
+	mustEmbedUnimplementedWhServiceServer()
+}
+
+func RegisterWhServiceServer(s grpc.ServiceRegistrar, srv WhServiceServer) {
+	s.RegisterService(&WhService_ServiceDesc, srv)
+}
+
+func _WhService_GetWh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWhRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhServiceServer).GetWh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WhService_GetWh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhServiceServer).GetWh(ctx, req.(*GetWhRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhService_ListWh_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWhRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WhServiceServer).ListWh(m, &whServiceListWhServer{stream})
+}
+
+type WhService_ListWhServer interface {
+	Send(*Wh) error
+	grpc.ServerStream
+}
+
+type whServiceListWhServer struct {
+	grpc.ServerStream
+}
+
+func (x *whServiceListWhServer) Send(m *Wh) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WhService_CreateWh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWhRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhServiceServer).CreateWh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WhService_CreateWh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhServiceServer).CreateWh(ctx, req.(*CreateWhRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhService_UpdateWh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWhRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhServiceServer).UpdateWh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WhService_UpdateWh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhServiceServer).UpdateWh(ctx, req.(*UpdateWhRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhService_DeleteWh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWhRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhServiceServer).DeleteWh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WhService_DeleteWh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhServiceServer).DeleteWh(ctx, req.(*DeleteWhRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WhService_ServiceDesc is the grpc.ServiceDesc for WhService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WhService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wfrp.v1.WhService",
+	HandlerType: (*WhServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWh",
+			Handler:    _WhService_GetWh_Handler,
+		},
+		{
+			MethodName: "CreateWh",
+			Handler:    _WhService_CreateWh_Handler,
+		},
+		{
+			MethodName: "UpdateWh",
+			Handler:    _WhService_UpdateWh_Handler,
+		},
+		{
+			MethodName: "DeleteWh",
+			Handler:    _WhService_DeleteWh_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListWh",
+			Handler:       _WhService_ListWh_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wfrp/v1/wh.proto",
+}
@@ -0,0 +1,241 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/grpc/wfrpv1"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var whTypes = map[wfrpv1.WhType]warhammer.WhType{
+	wfrpv1.WhType_WH_TYPE_MUTATION:  warhammer.WhTypeMutation,
+	wfrpv1.WhType_WH_TYPE_SPELL:     warhammer.WhTypeSpell,
+	wfrpv1.WhType_WH_TYPE_PROPERTY:  warhammer.WhTypeProperty,
+	wfrpv1.WhType_WH_TYPE_ITEM:      warhammer.WhTypeItem,
+	wfrpv1.WhType_WH_TYPE_TALENT:    warhammer.WhTypeTalent,
+	wfrpv1.WhType_WH_TYPE_SKILL:     warhammer.WhTypeSkill,
+	wfrpv1.WhType_WH_TYPE_CAREER:    warhammer.WhTypeCareer,
+	wfrpv1.WhType_WH_TYPE_CHARACTER: warhammer.WhTypeCharacter,
+	wfrpv1.WhType_WH_TYPE_SPECIES:   warhammer.WhTypeSpecies,
+}
+
+type WhServer struct {
+	wfrpv1.UnimplementedWhServiceServer
+	WhService warhammer.WhService
+}
+
+func (s *WhServer) GetWh(ctx context.Context, req *wfrpv1.GetWhRequest) (*wfrpv1.Wh, error) {
+	t, err := whType(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	whs, whErr := s.WhService.Get(ctx, t, getUserClaims(ctx), false, []string{req.GetId()})
+	if whErr != nil {
+		return nil, whErrStatus(whErr)
+	}
+	if len(whs) == 0 {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return whToProto(t, whs[0])
+}
+
+func (s *WhServer) ListWh(req *wfrpv1.ListWhRequest, stream wfrpv1.WhService_ListWhServer) error {
+	t, err := whType(req.GetType())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	whs, whErr := s.WhService.Get(ctx, t, getUserClaims(ctx), false, req.GetIds())
+	if whErr != nil {
+		return whErrStatus(whErr)
+	}
+
+	for _, v := range whs {
+		pb, err := whToProto(t, v)
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(pb); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WhServer) CreateWh(ctx context.Context, req *wfrpv1.CreateWhRequest) (*wfrpv1.Wh, error) {
+	t, whWrite, err := whFromProto(req.GetWh())
+	if err != nil {
+		return nil, err
+	}
+
+	whRead, whErr := s.WhService.Create(ctx, t, whWrite, getUserClaims(ctx))
+	if whErr != nil {
+		return nil, whErrStatus(whErr)
+	}
+
+	return whToProto(t, whRead)
+}
+
+func (s *WhServer) UpdateWh(ctx context.Context, req *wfrpv1.UpdateWhRequest) (*wfrpv1.Wh, error) {
+	t, whWrite, err := whFromProto(req.GetWh())
+	if err != nil {
+		return nil, err
+	}
+	whWrite.Id = req.GetWh().GetId()
+	whWrite.Version = int(req.GetWh().GetVersion())
+
+	whRead, whErr := s.WhService.Update(ctx, t, whWrite, getUserClaims(ctx))
+	if whErr != nil {
+		return nil, whErrStatus(whErr)
+	}
+
+	return whToProto(t, whRead)
+}
+
+func (s *WhServer) DeleteWh(ctx context.Context, req *wfrpv1.DeleteWhRequest) (*wfrpv1.DeleteWhResponse, error) {
+	t, err := whType(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	if whErr := s.WhService.Delete(ctx, t, req.GetId(), getUserClaims(ctx)); whErr != nil {
+		return nil, whErrStatus(whErr)
+	}
+
+	return &wfrpv1.DeleteWhResponse{}, nil
+}
+
+func whType(pbType wfrpv1.WhType) (warhammer.WhType, error) {
+	t, ok := whTypes[pbType]
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("invalid type %s", pbType))
+	}
+	return t, nil
+}
+
+func whErrStatus(whErr *warhammer.WhError) error {
+	switch whErr.ErrType {
+	case warhammer.WhInvalidArgumentsError:
+		return status.Error(codes.InvalidArgument, whErr.Error())
+	case warhammer.WhUnauthorizedError:
+		return status.Error(codes.PermissionDenied, "unauthorized")
+	case warhammer.WhNotFoundError:
+		return status.Error(codes.NotFound, "not found")
+	case warhammer.WhConflictError:
+		return status.Error(codes.FailedPrecondition, "object has been modified")
+	case warhammer.WhGoneError:
+		return status.Error(codes.OutOfRange, whErr.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// Objects are converted through their JSON representation, field json names of the messages match the ones of the
+// domain types.
+
+func whToProto(t warhammer.WhType, w *warhammer.Wh) (*wfrpv1.Wh, error) {
+	pb := &wfrpv1.Wh{
+		Id:             w.Id,
+		OwnerId:        w.OwnerId,
+		CanEdit:        w.CanEdit,
+		Version:        int32(w.Version),
+		CreatedAt:      timestamppb.New(w.CreatedAt),
+		UpdatedAt:      timestamppb.New(w.UpdatedAt),
+		LastModifiedBy: w.LastModifiedBy,
+	}
+
+	var object proto.Message
+	switch t {
+	case warhammer.WhTypeMutation:
+		o := &wfrpv1.Mutation{}
+		pb.Object, object = &wfrpv1.Wh_Mutation{Mutation: o}, o
+	case warhammer.WhTypeSpell:
+		o := &wfrpv1.Spell{}
+		pb.Object, object = &wfrpv1.Wh_Spell{Spell: o}, o
+	case warhammer.WhTypeProperty:
+		o := &wfrpv1.Property{}
+		pb.Object, object = &wfrpv1.Wh_Property{Property: o}, o
+	case warhammer.WhTypeItem:
+		o := &wfrpv1.Item{}
+		pb.Object, object = &wfrpv1.Wh_Item{Item: o}, o
+	case warhammer.WhTypeTalent:
+		o := &wfrpv1.Talent{}
+		pb.Object, object = &wfrpv1.Wh_Talent{Talent: o}, o
+	case warhammer.WhTypeSkill:
+		o := &wfrpv1.Skill{}
+		pb.Object, object = &wfrpv1.Wh_Skill{Skill: o}, o
+	case warhammer.WhTypeCareer:
+		o := &wfrpv1.Career{}
+		pb.Object, object = &wfrpv1.Wh_Career{Career: o}, o
+	case warhammer.WhTypeCharacter:
+		o := &wfrpv1.Character{}
+		pb.Object, object = &wfrpv1.Wh_Character{Character: o}, o
+	case warhammer.WhTypeSpecies:
+		o := &wfrpv1.Species{}
+		pb.Object, object = &wfrpv1.Wh_Species{Species: o}, o
+	default:
+		return nil, status.Error(codes.Internal, fmt.Sprintf("invalid type %s", t))
+	}
+
+	objectJson, err := json.Marshal(w.Object)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(objectJson, object); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return pb, nil
+}
+
+func whFromProto(pb *wfrpv1.Wh) (warhammer.WhType, *warhammer.Wh, error) {
+	var t warhammer.WhType
+	var object proto.Message
+	switch o := pb.GetObject().(type) {
+	case *wfrpv1.Wh_Mutation:
+		t, object = warhammer.WhTypeMutation, o.Mutation
+	case *wfrpv1.Wh_Spell:
+		t, object = warhammer.WhTypeSpell, o.Spell
+	case *wfrpv1.Wh_Property:
+		t, object = warhammer.WhTypeProperty, o.Property
+	case *wfrpv1.Wh_Item:
+		t, object = warhammer.WhTypeItem, o.Item
+	case *wfrpv1.Wh_Talent:
+		t, object = warhammer.WhTypeTalent, o.Talent
+	case *wfrpv1.Wh_Skill:
+		t, object = warhammer.WhTypeSkill, o.Skill
+	case *wfrpv1.Wh_Career:
+		t, object = warhammer.WhTypeCareer, o.Career
+	case *wfrpv1.Wh_Character:
+		t, object = warhammer.WhTypeCharacter, o.Character
+	case *wfrpv1.Wh_Species:
+		t, object = warhammer.WhTypeSpecies, o.Species
+	default:
+		return "", nil, status.Error(codes.InvalidArgument, "object is required")
+	}
+
+	wh, err := warhammer.NewApiWh(t)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	objectJson, err := (protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(object)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = json.Unmarshal(objectJson, wh.Object); err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return t, &wh, nil
+}
@@ -0,0 +1,81 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"sort"
+)
+
+type AuditDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewAuditDbService() *AuditDbService {
+	db, err := createNewAuditMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &AuditDbService{Db: db}
+}
+
+func createNewAuditMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"audit": {
+				Name: "audit",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *AuditDbService) Create(ctx context.Context, e *audit.Event) (*audit.Event, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("audit", e.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return e.PointToCopy(), nil
+}
+
+func (s *AuditDbService) Retrieve(ctx context.Context, q *audit.Query) ([]*audit.Event, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("audit", "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	events := make([]*audit.Event, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		e, ok := obj.(*audit.Event)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate audit event from raw %v", obj)}
+		}
+		if q.Matches(e) {
+			events = append(events, e.PointToCopy())
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+
+	if q.Limit > 0 && len(events) > q.Limit {
+		events = events[:q.Limit]
+	}
+
+	return events, nil
+}
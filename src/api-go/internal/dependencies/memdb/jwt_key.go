@@ -0,0 +1,91 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+)
+
+type JwtKeyDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewJwtKeyDbService() *JwtKeyDbService {
+	db, err := createNewJwtKeyMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &JwtKeyDbService{Db: db}
+}
+
+func createNewJwtKeyMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"jwt_key": {
+				Name: "jwt_key",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+					"algorithm": {
+						Name:    "algorithm",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "Algorithm"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *JwtKeyDbService) Create(ctx context.Context, key *domain.JwtKey) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	cpy := *key
+	if err := txn.Insert("jwt_key", &cpy); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *JwtKeyDbService) RetrieveAll(ctx context.Context, algorithm string) ([]*domain.JwtKey, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("jwt_key", "algorithm", algorithm)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	keys := make([]*domain.JwtKey, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		key, ok := obj.(*domain.JwtKey)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate jwt key from raw %v", obj)}
+		}
+		cpy := *key
+		keys = append(keys, &cpy)
+	}
+
+	return keys, nil
+}
+
+func (s *JwtKeyDbService) Delete(ctx context.Context, ids []string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		if _, err := txn.DeleteAll("jwt_key", "id", id); err != nil {
+			return &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+	}
+	txn.Commit()
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"time"
+)
+
+type AttemptDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewAttemptDbService() *AttemptDbService {
+	db, err := createNewAttemptMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &AttemptDbService{Db: db}
+}
+
+func createNewAttemptMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"attempt": {
+				Name: "attempt",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Key"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *AttemptDbService) Retrieve(ctx context.Context, key string) (*lockout.Attempts, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	return getAttempts(txn, key)
+}
+
+func getAttempts(txn *memdb.Txn, key string) (*lockout.Attempts, *domain.DbError) {
+	raw, err := txn.First("attempt", "id", key)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("attempts not found")}
+	}
+
+	attempts, ok := raw.(*lockout.Attempts)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate attempts from raw %v", raw)}
+	}
+
+	return attempts.PointToCopy(), nil
+}
+
+func (s *AttemptDbService) Increment(ctx context.Context, key string, now time.Time, window time.Duration) (*lockout.Attempts, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	attempts, dbErr := getAttempts(txn, key)
+	if dbErr != nil {
+		if dbErr.Type != domain.DbNotFoundError {
+			return nil, dbErr
+		}
+		attempts = &lockout.Attempts{Key: key}
+	}
+
+	if attempts.LastFailure.Before(now.Add(-window)) {
+		attempts.Failures = 0
+	}
+	previous := attempts.PointToCopy()
+
+	attempts.Failures++
+	attempts.LastFailure = now
+
+	if err := txn.Insert("attempt", attempts); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return previous, nil
+}
+
+func (s *AttemptDbService) Decrement(ctx context.Context, key string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	attempts, dbErr := getAttempts(txn, key)
+	if dbErr != nil {
+		if dbErr.Type == domain.DbNotFoundError {
+			return nil
+		}
+		return dbErr
+	}
+
+	if attempts.Failures == 0 {
+		return nil
+	}
+	attempts.Failures--
+
+	if err := txn.Insert("attempt", attempts); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *AttemptDbService) Delete(ctx context.Context, key string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("attempt", "id", key); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *AttemptDbService) DeleteBefore(ctx context.Context, t time.Time) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	it, err := txn.Get("attempt", "id")
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	expired := make([]*lockout.Attempts, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if attempts := obj.(*lockout.Attempts); attempts.LastFailure.Before(t) {
+			expired = append(expired, attempts)
+		}
+	}
+
+	for _, attempts := range expired {
+		if err = txn.Delete("attempt", attempts); err != nil {
+			return &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+	}
+	txn.Commit()
+
+	return nil
+}
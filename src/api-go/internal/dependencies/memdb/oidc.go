@@ -0,0 +1,210 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+)
+
+type OidcLinkDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewOidcLinkDbService() *OidcLinkDbService {
+	db, err := createNewOidcLinkMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &OidcLinkDbService{Db: db}
+}
+
+func createNewOidcLinkMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"oidc_link": {
+				Name: "oidc_link",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:   "id",
+						Unique: true,
+						Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "Provider"},
+							&memdb.StringFieldIndex{Field: "Subject"},
+						}},
+					},
+					"userId": {
+						Name:   "userId",
+						Unique: true,
+						Indexer: &memdb.CompoundIndex{Indexes: []memdb.Indexer{
+							&memdb.StringFieldIndex{Field: "UserId"},
+							&memdb.StringFieldIndex{Field: "Provider"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *OidcLinkDbService) Create(ctx context.Context, l *oidc.Link) (*oidc.Link, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	for _, idx := range []struct {
+		name string
+		args []any
+	}{{"id", []any{l.Provider, l.Subject}}, {"userId", []any{l.UserId, l.Provider}}} {
+		existing, err := txn.First("oidc_link", idx.name, idx.args...)
+		if err != nil {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+		if existing != nil {
+			return nil, &domain.DbError{Type: domain.DbAlreadyExistsError, Err: errors.New("oidc link already exists")}
+		}
+	}
+
+	if err := txn.Insert("oidc_link", l.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return l.PointToCopy(), nil
+}
+
+func (s *OidcLinkDbService) Retrieve(ctx context.Context, provider string, subject string) (*oidc.Link, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	raw, err := txn.First("oidc_link", "id", provider, subject)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("oidc link not found")}
+	}
+
+	link, ok := raw.(*oidc.Link)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate oidc link from raw %v", raw)}
+	}
+
+	return link.PointToCopy(), nil
+}
+
+func (s *OidcLinkDbService) RetrieveByUser(ctx context.Context, userId string) ([]*oidc.Link, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("oidc_link", "userId_prefix", userId)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	links := make([]*oidc.Link, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		link, ok := obj.(*oidc.Link)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate oidc link from raw %v", obj)}
+		}
+		links = append(links, link.PointToCopy())
+	}
+
+	return links, nil
+}
+
+func (s *OidcLinkDbService) Delete(ctx context.Context, userId string, provider string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	n, err := txn.DeleteAll("oidc_link", "userId", userId, provider)
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	if n == 0 {
+		return &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("oidc link not found")}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *OidcLinkDbService) DeleteByUser(ctx context.Context, userId string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("oidc_link", "userId_prefix", userId); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+type OidcLoginStateDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewOidcLoginStateDbService() *OidcLoginStateDbService {
+	db, err := createNewOidcLoginStateMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &OidcLoginStateDbService{Db: db}
+}
+
+func createNewOidcLoginStateMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"oidc_login_state": {
+				Name: "oidc_login_state",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "State"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *OidcLoginStateDbService) Create(ctx context.Context, st *oidc.LoginState) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("oidc_login_state", st.PointToCopy()); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *OidcLoginStateDbService) Take(ctx context.Context, state string) (*oidc.LoginState, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First("oidc_login_state", "id", state)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("oidc login state not found")}
+	}
+
+	st, ok := raw.(*oidc.LoginState)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate oidc login state from raw %v", raw)}
+	}
+
+	if err = txn.Delete("oidc_login_state", st); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return st.PointToCopy(), nil
+}
@@ -0,0 +1,159 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"sort"
+	"time"
+)
+
+type PatDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewPatDbService() *PatDbService {
+	db, err := createNewPatMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &PatDbService{Db: db}
+}
+
+func createNewPatMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"pat": {
+				Name: "pat",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+					"tokenHash": {
+						Name:    "tokenHash",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "TokenHash"},
+					},
+					"userId": {
+						Name:    "userId",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "UserId"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *PatDbService) Create(ctx context.Context, t *pat.Token) (*pat.Token, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("pat", t.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return t.PointToCopy(), nil
+}
+
+func (s *PatDbService) RetrieveByHash(ctx context.Context, tokenHash string) (*pat.Token, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	return getPat(txn, "tokenHash", tokenHash)
+}
+
+func getPat(txn *memdb.Txn, index string, value string) (*pat.Token, *domain.DbError) {
+	raw, err := txn.First("pat", index, value)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("personal access token not found")}
+	}
+
+	t, ok := raw.(*pat.Token)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate personal access token from raw %v", raw)}
+	}
+
+	return t.PointToCopy(), nil
+}
+
+func (s *PatDbService) RetrieveByUser(ctx context.Context, userId string) ([]*pat.Token, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("pat", "userId", userId)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	tokens := make([]*pat.Token, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		t, ok := obj.(*pat.Token)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate personal access token from raw %v", obj)}
+		}
+		tokens = append(tokens, t.PointToCopy())
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+
+	return tokens, nil
+}
+
+func (s *PatDbService) Touch(ctx context.Context, id string, lastUsedAt time.Time) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	t, dbErr := getPat(txn, "id", id)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	t.LastUsedAt = lastUsedAt
+	if err := txn.Insert("pat", t); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *PatDbService) Delete(ctx context.Context, userId string, id string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	t, dbErr := getPat(txn, "id", id)
+	if dbErr != nil {
+		return dbErr
+	}
+	if t.UserId != userId {
+		return &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("personal access token not found")}
+	}
+
+	if _, err := txn.DeleteAll("pat", "id", id); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *PatDbService) DeleteByUser(ctx context.Context, userId string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("pat", "userId", userId); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"time"
+)
+
+type SessionDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewSessionDbService() *SessionDbService {
+	db, err := createNewSessionMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &SessionDbService{Db: db}
+}
+
+func createNewSessionMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"session": {
+				Name: "session",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+					"userId": {
+						Name:    "userId",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "UserId"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *SessionDbService) Create(ctx context.Context, sess *session.Session) (*session.Session, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("session", sess.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return sess.PointToCopy(), nil
+}
+
+func (s *SessionDbService) Retrieve(ctx context.Context, id string) (*session.Session, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	return getSession(txn, id)
+}
+
+func getSession(txn *memdb.Txn, id string) (*session.Session, *domain.DbError) {
+	raw, err := txn.First("session", "id", id)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("session not found")}
+	}
+
+	sess, ok := raw.(*session.Session)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate session from raw %v", raw)}
+	}
+
+	return sess.PointToCopy(), nil
+}
+
+func (s *SessionDbService) Rotate(ctx context.Context, id string, oldHash string, newHash string, refreshedAt time.Time, expiresAt time.Time) (*session.Session, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	sess, dbErr := getSession(txn, id)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if sess.TokenHash != oldHash {
+		return nil, &domain.DbError{Type: domain.DbConflictError, Err: errors.New("refresh token already rotated")}
+	}
+
+	sess.UsedTokenHashes = append(sess.UsedTokenHashes, oldHash)
+	if len(sess.UsedTokenHashes) > session.MaxUsedTokenHashes {
+		sess.UsedTokenHashes = sess.UsedTokenHashes[len(sess.UsedTokenHashes)-session.MaxUsedTokenHashes:]
+	}
+	sess.TokenHash = newHash
+	sess.RefreshedAt = refreshedAt
+	sess.ExpiresAt = expiresAt
+
+	if err := txn.Insert("session", sess.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return sess, nil
+}
+
+func (s *SessionDbService) Revoke(ctx context.Context, id string, revokedAt time.Time, reason string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	sess, dbErr := getSession(txn, id)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	if !sess.RevokedAt.IsZero() {
+		return nil
+	}
+	sess.RevokedAt = revokedAt
+	sess.RevokedReason = reason
+
+	if err := txn.Insert("session", sess); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *SessionDbService) DeleteByUser(ctx context.Context, userId string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("session", "userId", userId); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package memdb
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+)
+
+// TxService runs fn as is, every memdb service keeps its own database so writes can not span them atomically.
+type TxService struct{}
+
+func NewTxService() *TxService {
+	return &TxService{}
+}
+
+func (s *TxService) WithTransaction(ctx context.Context, fn func(ctx context.Context) *domain.DbError) *domain.DbError {
+	return fn(ctx)
+}
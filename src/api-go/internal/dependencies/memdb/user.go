@@ -200,6 +200,31 @@ func (s *UserDbService) Delete(ctx context.Context, id string) *domain.DbError {
 	if _, err := txn.DeleteAll("user", "id", id); err != nil {
 		return &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
+
+	it, err := txn.Get("user", "id")
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var linked []*user.User
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if u := obj.(*user.User); slices.Contains(u.SharedAccountIds, id) {
+			linked = append(linked, u)
+		}
+	}
+
+	for _, u := range linked {
+		unlinked := u.PointToCopy()
+		unlinked.SharedAccountIds = make([]string, 0, len(u.SharedAccountIds))
+		for _, v := range u.SharedAccountIds {
+			if v != id {
+				unlinked.SharedAccountIds = append(unlinked.SharedAccountIds, v)
+			}
+		}
+		if err := txn.Insert("user", unlinked); err != nil {
+			return &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+	}
 	txn.Commit()
 
 	return nil
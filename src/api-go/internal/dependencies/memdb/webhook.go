@@ -0,0 +1,310 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"sort"
+	"time"
+)
+
+type WebhookDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewWebhookDbService() *WebhookDbService {
+	db, err := createNewWebhookMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &WebhookDbService{Db: db}
+}
+
+func createNewWebhookMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"webhook": {
+				Name: "webhook",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+					"ownerId": {
+						Name:    "ownerId",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "OwnerId"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *WebhookDbService) Create(ctx context.Context, w *webhook.Webhook) (*webhook.Webhook, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("webhook", w.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return w.PointToCopy(), nil
+}
+
+func (s *WebhookDbService) Update(ctx context.Context, w *webhook.Webhook) (*webhook.Webhook, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First("webhook", "id", w.Id)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("webhook not found")}
+	}
+
+	if err = txn.Insert("webhook", w.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return w.PointToCopy(), nil
+}
+
+func (s *WebhookDbService) Retrieve(ctx context.Context, id string) (*webhook.Webhook, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	raw, err := txn.First("webhook", "id", id)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("webhook not found")}
+	}
+
+	w, ok := raw.(*webhook.Webhook)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate webhook from raw %v", raw)}
+	}
+
+	return w.PointToCopy(), nil
+}
+
+func (s *WebhookDbService) RetrieveByOwner(ctx context.Context, ownerId string) ([]*webhook.Webhook, *domain.DbError) {
+	return s.retrieveMatching("ownerId", []any{ownerId}, func(w *webhook.Webhook) bool { return true })
+}
+
+func (s *WebhookDbService) RetrieveByEventType(ctx context.Context, eventType string) ([]*webhook.Webhook, *domain.DbError) {
+	return s.retrieveMatching("id", nil, func(w *webhook.Webhook) bool { return w.Subscribes(eventType) })
+}
+
+func (s *WebhookDbService) retrieveMatching(index string, args []any, match func(w *webhook.Webhook) bool) ([]*webhook.Webhook, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("webhook", index, args...)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	webhooks := make([]*webhook.Webhook, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		w, ok := obj.(*webhook.Webhook)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate webhook from raw %v", obj)}
+		}
+		if match(w) {
+			webhooks = append(webhooks, w.PointToCopy())
+		}
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+func (s *WebhookDbService) Delete(ctx context.Context, id string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	deleted, err := txn.DeleteAll("webhook", "id", id)
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	if deleted == 0 {
+		return &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("webhook not found")}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+type WebhookDeliveryDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewWebhookDeliveryDbService() *WebhookDeliveryDbService {
+	db, err := createNewWebhookDeliveryMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &WebhookDeliveryDbService{Db: db}
+}
+
+func createNewWebhookDeliveryMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"delivery": {
+				Name: "delivery",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "Id"},
+					},
+					"webhookId": {
+						Name:    "webhookId",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "WebhookId"},
+					},
+					"status": {
+						Name:    "status",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "Status"},
+					},
+					"ownerId": {
+						Name:    "ownerId",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "OwnerId"},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *WebhookDeliveryDbService) Create(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("delivery", d.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return d.PointToCopy(), nil
+}
+
+func (s *WebhookDeliveryDbService) Update(ctx context.Context, d *webhook.Delivery) (*webhook.Delivery, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First("delivery", "id", d.Id)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("delivery not found")}
+	}
+
+	if err = txn.Insert("delivery", d.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return d.PointToCopy(), nil
+}
+
+func (s *WebhookDeliveryDbService) Retrieve(ctx context.Context, webhookId string, limit int) ([]*webhook.Delivery, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("delivery", "webhookId", webhookId)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	deliveries := make([]*webhook.Delivery, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		d, ok := obj.(*webhook.Delivery)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate delivery from raw %v", obj)}
+		}
+		deliveries = append(deliveries, d.PointToCopy())
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookDeliveryDbService) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*webhook.Delivery, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	it, err := txn.Get("delivery", "status", webhook.DeliveryStatusPending)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var due *webhook.Delivery
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		d, ok := obj.(*webhook.Delivery)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate delivery from raw %v", obj)}
+		}
+		if d.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || d.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = d
+		}
+	}
+
+	if due == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("no delivery due")}
+	}
+
+	claimed := due.PointToCopy()
+	claimed.NextAttemptAt = leaseUntil
+	if err = txn.Insert("delivery", claimed); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return claimed.PointToCopy(), nil
+}
+
+func (s *WebhookDeliveryDbService) DeleteAll(ctx context.Context, webhookId string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("delivery", "webhookId", webhookId); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *WebhookDeliveryDbService) DeleteByOwner(ctx context.Context, ownerId string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if _, err := txn.DeleteAll("delivery", "ownerId", ownerId); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
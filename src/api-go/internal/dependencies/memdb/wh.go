@@ -8,12 +8,22 @@ import (
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
 	"golang.org/x/exp/slices"
+	"time"
 )
 
+const removedTable = "removed"
+
 type WhDbService struct {
 	Db *memdb.MemDB
 }
 
+// removedWh remembers an object deleted together with its owner.
+type removedWh struct {
+	WhType    string
+	WhId      string
+	RemovedAt time.Time
+}
+
 func NewWhDbService() *WhDbService {
 	db, err := createNewWhMemDb()
 	if err != nil {
@@ -44,6 +54,68 @@ func createNewWhMemDb() (*memdb.MemDB, error) {
 
 	schema.Tables[warhammer.WhTypeOther] = &memdb.TableSchema{
 		Name: warhammer.WhTypeOther,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:   "id",
+				Unique: true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "OwnerId"},
+						&memdb.StringFieldIndex{Field: "Name"},
+					},
+				},
+			},
+		},
+	}
+
+	schema.Tables[generationHistoryTable] = &memdb.TableSchema{
+		Name: generationHistoryTable,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:   "id",
+				Unique: true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "OwnerId"},
+						&memdb.StringFieldIndex{Field: "Name"},
+						&memdb.IntFieldIndex{Field: "Version"},
+					},
+				},
+			},
+			"set": {
+				Name: "set",
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "OwnerId"},
+						&memdb.StringFieldIndex{Field: "Name"},
+					},
+				},
+			},
+		},
+	}
+
+	schema.Tables[removedTable] = &memdb.TableSchema{
+		Name: removedTable,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:   "id",
+				Unique: true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{Field: "WhType"},
+						&memdb.StringFieldIndex{Field: "WhId"},
+					},
+				},
+			},
+			"type": {
+				Name:    "type",
+				Indexer: &memdb.StringFieldIndex{Field: "WhType"},
+			},
+		},
+	}
+
+	schema.Tables[enumTable] = &memdb.TableSchema{
+		Name: enumTable,
 		Indexes: map[string]*memdb.IndexSchema{
 			"id": {
 				Name:    "id",
@@ -79,53 +151,208 @@ func (s *WhDbService) Create(ctx context.Context, t warhammer.WhType, w *warhamm
 	return upsertWh(s.Db, t, w)
 }
 
-func (s *WhDbService) Update(ctx context.Context, t warhammer.WhType, w *warhammer.Wh, userId string) (*warhammer.Wh, *domain.DbError) {
+func (s *WhDbService) Update(ctx context.Context, t warhammer.WhType, w *warhammer.Wh, ownerId string) (*warhammer.Wh, *domain.DbError) {
 	wh, dbErr := getOne(s.Db, t, w.Id)
 	if dbErr != nil {
 		return nil, dbErr
 	}
 
-	if wh.OwnerId != userId {
-		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("invalid owner id")}
+	if wh.OwnerId != ownerId || wh.IsDeleted() {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("wh not found")}
 	}
 
 	return upsertWh(s.Db, t, w)
 }
 
+// upsertWh inserts w as is if it does not exist yet. Otherwise w.Version has to match the stored version and is
+// incremented on write.
 func upsertWh(db *memdb.MemDB, t warhammer.WhType, w *warhammer.Wh) (*warhammer.Wh, *domain.DbError) {
 	txn := db.Txn(true)
 	defer txn.Abort()
-	if err := txn.Insert(string(t), w); err != nil {
+
+	newWh := w.PointToCopy()
+
+	existing, err := txn.First(string(t), "id", w.Id)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if existing != nil {
+		existingWh, ok := existing.(*warhammer.Wh)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate wh from raw %v", existing)}
+		}
+		if existingWh.Version != w.Version {
+			return nil, &domain.DbError{Type: domain.DbConflictError, Err: errors.New("wh version mismatch")}
+		}
+		newWh.Version++
+	}
+
+	if err := txn.Insert(string(t), newWh); err != nil {
 		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
 	txn.Commit()
 
-	return w.PointToCopy(), nil
+	return newWh.PointToCopy(), nil
+}
+
+func (s *WhDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, ownerId string, userId string) *domain.DbError {
+	wh, dbErr := getOne(s.Db, t, whId)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	if wh.OwnerId != ownerId || wh.IsDeleted() {
+		return &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("wh not found")}
+	}
+
+	deletedAt := time.Now().UTC()
+	wh.DeletedAt = &deletedAt
+	wh.UpdatedAt = deletedAt
+	wh.LastModifiedBy = userId
+
+	_, dbErr = upsertWh(s.Db, t, wh)
+	return dbErr
+}
+
+func (s *WhDbService) Restore(ctx context.Context, t warhammer.WhType, whId string, ownerId string, userId string) (*warhammer.Wh, *domain.DbError) {
+	wh, dbErr := getOne(s.Db, t, whId)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if wh.OwnerId != ownerId || !wh.IsDeleted() {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("wh not found in trash")}
+	}
+
+	wh.DeletedAt = nil
+	wh.UpdatedAt = time.Now().UTC()
+	wh.LastModifiedBy = userId
+
+	return upsertWh(s.Db, t, wh)
+}
+
+func (s *WhDbService) RetrieveDeleted(ctx context.Context, t warhammer.WhType, ownerId string) ([]*warhammer.Wh, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get(string(t), "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	whs := make([]*warhammer.Wh, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		wh, ok := obj.(*warhammer.Wh)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate wh from raw %v", obj)}
+		}
+		if wh.IsDeleted() && wh.OwnerId == ownerId {
+			whs = append(whs, wh.PointToCopy())
+		}
+	}
+
+	return whs, nil
 }
 
-func (s *WhDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, userId string) *domain.DbError {
+// UpdateHidden hides or unhides an object. Objects owned by admin can not be hidden.
+func (s *WhDbService) UpdateHidden(ctx context.Context, t warhammer.WhType, whId string, hidden bool, reason string, userId string) (*warhammer.Wh, *domain.DbError) {
 	wh, dbErr := getOne(s.Db, t, whId)
 	if dbErr != nil {
-		switch dbErr.Type {
-		case domain.DbNotFoundError:
-			return nil
-		default:
-			return dbErr
+		return nil, dbErr
+	}
+
+	if wh.OwnerId == "admin" || wh.IsDeleted() {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("wh not found")}
+	}
+
+	wh.Hidden = hidden
+	if hidden {
+		wh.HiddenBy = userId
+		wh.HiddenReason = reason
+	} else {
+		wh.HiddenBy = ""
+		wh.HiddenReason = ""
+	}
+	wh.UpdatedAt = time.Now().UTC()
+
+	return upsertWh(s.Db, t, wh)
+}
+
+func (s *WhDbService) Purge(ctx context.Context, t warhammer.WhType, deletedBefore time.Time) ([]string, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	it, err := txn.Get(string(t), "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var toPurge []*warhammer.Wh
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if wh, ok := obj.(*warhammer.Wh); ok && wh.IsDeleted() && wh.DeletedAt.Before(deletedBefore) {
+			toPurge = append(toPurge, wh)
+		}
+	}
+
+	purgedIds := make([]string, len(toPurge))
+	for i, wh := range toPurge {
+		if err := txn.Delete(string(t), wh); err != nil {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+		purgedIds[i] = wh.Id
+	}
+
+	removedIt, err := txn.Get(removedTable, "type", string(t))
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var toForget []*removedWh
+	for obj := removedIt.Next(); obj != nil; obj = removedIt.Next() {
+		if r, ok := obj.(*removedWh); ok && r.RemovedAt.Before(deletedBefore) {
+			toForget = append(toForget, r)
 		}
 	}
 
-	if wh.OwnerId != userId {
-		return nil
+	for _, r := range toForget {
+		if err := txn.Delete(removedTable, r); err != nil {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
 	}
+	txn.Commit()
+
+	return purgedIds, nil
+}
 
+func (s *WhDbService) DeleteByOwner(ctx context.Context, t warhammer.WhType, ownerId string) ([]string, *domain.DbError) {
 	txn := s.Db.Txn(true)
 	defer txn.Abort()
-	if _, err := txn.DeleteAll(string(t), "id", whId); err != nil {
-		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+
+	it, err := txn.Get(string(t), "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var toDelete []*warhammer.Wh
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if wh, ok := obj.(*warhammer.Wh); ok && wh.OwnerId == ownerId {
+			toDelete = append(toDelete, wh)
+		}
+	}
+
+	now := time.Now().UTC()
+	deletedIds := make([]string, len(toDelete))
+	for i, wh := range toDelete {
+		if err := txn.Delete(string(t), wh); err != nil {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+		if err := txn.Insert(removedTable, &removedWh{WhType: string(t), WhId: wh.Id, RemovedAt: now}); err != nil {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+		deletedIds[i] = wh.Id
 	}
 	txn.Commit()
 
-	return nil
+	return deletedIds, nil
 }
 
 func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, users []string, sharedUsers []string, whIds []string) ([]*warhammer.Wh, *domain.DbError) {
@@ -141,6 +368,9 @@ func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, users []
 		if !ok {
 			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate wh from raw %v", obj)}
 		}
+		if wh.IsDeleted() {
+			continue
+		}
 		if slices.Contains(whIds, wh.Id) || len(whIds) == 0 {
 			if slices.Contains(users, wh.OwnerId) || slices.Contains(sharedUsers, wh.OwnerId) && wh.IsShared() {
 				whs = append(whs, wh.PointToCopy())
@@ -155,32 +385,80 @@ func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, users []
 	return whs, nil
 }
 
-func (s *WhDbService) RetrieveGenerationProps(ctx context.Context) (*warhammer.WhGenerationProps, *domain.DbError) {
+func (s *WhDbService) RetrieveChanged(ctx context.Context, t warhammer.WhType, users []string, sharedUsers []string, since time.Time) ([]*warhammer.Wh, *domain.DbError) {
 	txn := s.Db.Txn(false)
-	raw, err := txn.First(warhammer.WhTypeOther, "id", "generationProps")
+	it, err := txn.Get(string(t), "id")
 	if err != nil {
 		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
 
-	if raw == nil {
-		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("generationProps not found")}
+	whs := make([]*warhammer.Wh, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		wh, ok := obj.(*warhammer.Wh)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate wh from raw %v", obj)}
+		}
+		if wh.UpdatedAt.Before(since) {
+			continue
+		}
+		if slices.Contains(users, wh.OwnerId) || slices.Contains(sharedUsers, wh.OwnerId) && wh.IsShared() {
+			whs = append(whs, wh.PointToCopy())
+		}
 	}
 
-	genProp, ok := raw.(*warhammer.WhGenerationProps)
-	if !ok {
-		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate generationProp from raw %v", raw)}
+	return whs, nil
+}
+
+func (s *WhDbService) RetrieveRemoved(ctx context.Context, t warhammer.WhType, sharedUsers []string, since time.Time) ([]string, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get(string(t), "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
 
-	return genProp.PointToCopy(), nil
-}
+	removedIds := make([]string, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		wh, ok := obj.(*warhammer.Wh)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate wh from raw %v", obj)}
+		}
+		if wh.UpdatedAt.Before(since) {
+			continue
+		}
+		if slices.Contains(sharedUsers, wh.OwnerId) && !wh.IsShared() {
+			removedIds = append(removedIds, wh.Id)
+		}
+	}
 
-func (s *WhDbService) CreateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *domain.DbError) {
-	txn := s.Db.Txn(true)
-	defer txn.Abort()
-	if err := txn.Insert(warhammer.WhTypeOther, gp); err != nil {
+	removedIt, err := txn.Get(removedTable, "type", string(t))
+	if err != nil {
 		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
-	txn.Commit()
 
-	return gp.PointToCopy(), nil
+	for obj := removedIt.Next(); obj != nil; obj = removedIt.Next() {
+		if r, ok := obj.(*removedWh); ok && !r.RemovedAt.Before(since) {
+			removedIds = append(removedIds, r.WhId)
+		}
+	}
+
+	return removedIds, nil
+}
+
+func (s *WhDbService) RetrieveMissingIds(ctx context.Context, t warhammer.WhType, users []string, sharedUsers []string, whIds []string) ([]string, *domain.DbError) {
+	missing := make([]string, 0)
+	for _, id := range whIds {
+		wh, dbErr := getOne(s.Db, t, id)
+		if dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+			return nil, dbErr
+		}
+		if wh == nil || wh.IsDeleted() {
+			missing = append(missing, id)
+			continue
+		}
+		if !slices.Contains(users, wh.OwnerId) && !(slices.Contains(sharedUsers, wh.OwnerId) && wh.IsShared()) {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
 }
@@ -0,0 +1,77 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"sort"
+)
+
+const enumTable = "enum"
+
+func (s *WhDbService) RetrieveEnums(ctx context.Context) ([]*warhammer.WhEnum, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get(enumTable, "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	enums := make([]*warhammer.WhEnum, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		e, ok := obj.(*warhammer.WhEnum)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate enum from raw %v", obj)}
+		}
+		enums = append(enums, e.PointToCopy())
+	}
+
+	sort.Slice(enums, func(i, j int) bool {
+		return enums[i].Name < enums[j].Name
+	})
+
+	return enums, nil
+}
+
+// UpsertEnum stores e, which has to be exactly one version ahead of the stored enumeration. Version 1 creates a new
+// enumeration.
+func (s *WhDbService) UpsertEnum(ctx context.Context, e *warhammer.WhEnum) (*warhammer.WhEnum, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	currentVersion, dbErr := getEnumVersion(txn, e.Name)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if currentVersion != e.Version-1 {
+		return nil, &domain.DbError{Type: domain.DbConflictError, Err: errors.New("enum version mismatch")}
+	}
+
+	if err := txn.Insert(enumTable, e.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return e.PointToCopy(), nil
+}
+
+func getEnumVersion(txn *memdb.Txn, name string) (int, *domain.DbError) {
+	raw, err := txn.First(enumTable, "id", name)
+	if err != nil {
+		return 0, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return 0, nil
+	}
+
+	e, ok := raw.(*warhammer.WhEnum)
+	if !ok {
+		return 0, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate enum from raw %v", raw)}
+	}
+
+	return e.Version, nil
+}
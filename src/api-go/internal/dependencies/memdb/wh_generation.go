@@ -0,0 +1,151 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"sort"
+)
+
+const generationHistoryTable = "generationHistory"
+
+func getGenerationProps(txn *memdb.Txn, ownerId string, name string) (*warhammer.WhGenerationProps, *domain.DbError) {
+	raw, err := txn.First(warhammer.WhTypeOther, "id", ownerId, name)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("generationProps not found")}
+	}
+
+	genProp, ok := raw.(*warhammer.WhGenerationProps)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate generationProp from raw %v", raw)}
+	}
+
+	return genProp, nil
+}
+
+func (s *WhDbService) RetrieveGenerationProps(ctx context.Context, ownerId string, name string) (*warhammer.WhGenerationProps, *domain.DbError) {
+	genProp, dbErr := getGenerationProps(s.Db.Txn(false), ownerId, name)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	return genProp.PointToCopy(), nil
+}
+
+func (s *WhDbService) RetrieveGenerationPropsList(ctx context.Context, userIds []string, sharedUserIds []string) ([]*warhammer.WhGenerationProps, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get(warhammer.WhTypeOther, "id")
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	list := make([]*warhammer.WhGenerationProps, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		gp, ok := obj.(*warhammer.WhGenerationProps)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate generationProp from raw %v", obj)}
+		}
+		if slices.Contains(userIds, gp.OwnerId) || slices.Contains(sharedUserIds, gp.OwnerId) && gp.Shared {
+			list = append(list, gp.PointToCopy())
+		}
+	}
+
+	return list, nil
+}
+
+func (s *WhDbService) CreateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	if _, dbErr := getGenerationProps(txn, gp.OwnerId, gp.Name); dbErr == nil {
+		return nil, &domain.DbError{Type: domain.DbAlreadyExistsError, Err: errors.New("generationProps already exists")}
+	} else if dbErr.Type != domain.DbNotFoundError {
+		return nil, dbErr
+	}
+
+	if err := txn.Insert(warhammer.WhTypeOther, gp.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return gp.PointToCopy(), nil
+}
+
+// UpdateGenerationProps replaces current generation properties with gp, which has to be exactly one version ahead,
+// and moves the replaced version to history.
+func (s *WhDbService) UpdateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	current, dbErr := getGenerationProps(txn, gp.OwnerId, gp.Name)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if current.Version != gp.Version-1 {
+		return nil, &domain.DbError{Type: domain.DbConflictError, Err: errors.New("generationProps version mismatch")}
+	}
+
+	if err := txn.Insert(generationHistoryTable, current.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if err := txn.Insert(warhammer.WhTypeOther, gp.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return gp.PointToCopy(), nil
+}
+
+func (s *WhDbService) DeleteGenerationProps(ctx context.Context, ownerId string, name string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	current, dbErr := getGenerationProps(txn, ownerId, name)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	if err := txn.Delete(warhammer.WhTypeOther, current); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if _, err := txn.DeleteAll(generationHistoryTable, "set", ownerId, name); err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *WhDbService) RetrieveGenerationPropsHistory(ctx context.Context, ownerId string, name string) ([]*warhammer.WhGenerationProps, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get(generationHistoryTable, "set", ownerId, name)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	history := make([]*warhammer.WhGenerationProps, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		gp, ok := obj.(*warhammer.WhGenerationProps)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate generationProp from raw %v", obj)}
+		}
+		history = append(history, gp.PointToCopy())
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Version > history[j].Version
+	})
+
+	return history, nil
+}
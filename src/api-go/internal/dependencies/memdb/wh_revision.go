@@ -0,0 +1,155 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"sort"
+)
+
+type WhRevisionDbService struct {
+	Db *memdb.MemDB
+}
+
+func NewWhRevisionDbService() *WhRevisionDbService {
+	db, err := createNewWhRevisionMemDb()
+	if err != nil {
+		panic(err)
+	}
+
+	return &WhRevisionDbService{Db: db}
+}
+
+func createNewWhRevisionMemDb() (*memdb.MemDB, error) {
+	schema := &memdb.DBSchema{
+		Tables: map[string]*memdb.TableSchema{
+			"revision": {
+				Name: "revision",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:   "id",
+						Unique: true,
+						Indexer: &memdb.CompoundIndex{
+							Indexes: []memdb.Indexer{
+								&memdb.StringFieldIndex{Field: "WhType"},
+								&memdb.StringFieldIndex{Field: "WhId"},
+								&memdb.IntFieldIndex{Field: "Rev"},
+							},
+						},
+					},
+					"wh": {
+						Name:   "wh",
+						Unique: false,
+						Indexer: &memdb.CompoundIndex{
+							Indexes: []memdb.Indexer{
+								&memdb.StringFieldIndex{Field: "WhType"},
+								&memdb.StringFieldIndex{Field: "WhId"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return memdb.NewMemDB(schema)
+}
+
+func (s *WhRevisionDbService) Create(ctx context.Context, r *warhammer.WhRevision) (*warhammer.WhRevision, *domain.DbError) {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Insert("revision", r.PointToCopy()); err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+	txn.Commit()
+
+	return r.PointToCopy(), nil
+}
+
+func (s *WhRevisionDbService) Retrieve(ctx context.Context, t warhammer.WhType, whId string) ([]*warhammer.WhRevision, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	it, err := txn.Get("revision", "wh", string(t), whId)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	revisions := make([]*warhammer.WhRevision, 0)
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		r, ok := obj.(*warhammer.WhRevision)
+		if !ok {
+			return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate revision from raw %v", obj)}
+		}
+		revisions = append(revisions, r.PointToCopy())
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Rev > revisions[j].Rev
+	})
+
+	return revisions, nil
+}
+
+func (s *WhRevisionDbService) RetrieveOne(ctx context.Context, t warhammer.WhType, whId string, rev int) (*warhammer.WhRevision, *domain.DbError) {
+	txn := s.Db.Txn(false)
+	raw, err := txn.First("revision", "id", string(t), whId, rev)
+	if err != nil {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	if raw == nil {
+		return nil, &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("revision not found")}
+	}
+
+	r, ok := raw.(*warhammer.WhRevision)
+	if !ok {
+		return nil, &domain.DbError{Type: domain.DbInternalError, Err: fmt.Errorf("could not populate revision from raw %v", raw)}
+	}
+
+	return r.PointToCopy(), nil
+}
+
+func (s *WhRevisionDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, revs []int) *domain.DbError {
+	if len(revs) == 0 {
+		return nil
+	}
+
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+
+	it, err := txn.Get("revision", "wh", string(t), whId)
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
+	var toDelete []any
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		if r, ok := obj.(*warhammer.WhRevision); ok && slices.Contains(revs, r.Rev) {
+			toDelete = append(toDelete, obj)
+		}
+	}
+
+	for _, obj := range toDelete {
+		if err := txn.Delete("revision", obj); err != nil {
+			return &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+	}
+	txn.Commit()
+
+	return nil
+}
+
+func (s *WhRevisionDbService) DeleteAll(ctx context.Context, t warhammer.WhType, whIds []string) *domain.DbError {
+	txn := s.Db.Txn(true)
+	defer txn.Abort()
+	for _, whId := range whIds {
+		if _, err := txn.DeleteAll("revision", "wh", string(t), whId); err != nil {
+			return &domain.DbError{Type: domain.DbInternalError, Err: err}
+		}
+	}
+	txn.Commit()
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"time"
+)
+
+// UpdateTranslation stores the translation of an object owned by ownerId for locale. An empty translation removes it.
+func (s *WhDbService) UpdateTranslation(ctx context.Context, t warhammer.WhType, whId string, ownerId string, locale string, tr warhammer.WhTranslation, userId string) *domain.DbError {
+	wh, dbErr := getOne(s.Db, t, whId)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	if wh.OwnerId != ownerId || wh.IsDeleted() {
+		return &domain.DbError{Type: domain.DbNotFoundError, Err: errors.New("wh not found")}
+	}
+
+	if tr.IsEmpty() {
+		delete(wh.Translations, locale)
+	} else {
+		if wh.Translations == nil {
+			wh.Translations = warhammer.WhTranslations{}
+		}
+		wh.Translations[locale] = tr.InitAndCopy()
+	}
+	wh.UpdatedAt = time.Now().UTC()
+	wh.LastModifiedBy = userId
+
+	_, dbErr = upsertWh(s.Db, t, wh)
+	return dbErr
+}
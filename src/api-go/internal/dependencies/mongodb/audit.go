@@ -0,0 +1,173 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const auditCollectionName = "audit"
+
+type AuditMongo struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	ActorId    string             `bson:"actorId"`
+	Timestamp  time.Time          `bson:"timestamp"`
+	Type       string             `bson:"type"`
+	ObjectType string             `bson:"objectType"`
+	ObjectId   string             `bson:"objectId"`
+	OwnerId    string             `bson:"ownerId"`
+	Changes    []FieldChangeMongo `bson:"changes"`
+}
+
+// FieldChangeMongo keeps old and new values as JSON so that nested documents round trip without turning into
+// primitive.D.
+type FieldChangeMongo struct {
+	Field string `bson:"field"`
+	Old   string `bson:"old"`
+	New   string `bson:"new"`
+}
+
+type AuditDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewAuditDbService(db *DbService, createIndex bool) *AuditDbService {
+	coll := db.Client.Database(db.DbName).Collection(auditCollectionName)
+
+	if createIndex {
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"objectId", 1}, {"timestamp", -1}}},
+			{Keys: bson.D{{"ownerId", 1}, {"timestamp", -1}}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &AuditDbService{Db: db, Collection: coll}
+}
+
+func (s *AuditDbService) Create(ctx context.Context, e *audit.Event) (*audit.Event, *d.DbError) {
+	eventMongo, err := newMongoFromAuditEvent(e)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if _, err = s.Collection.InsertOne(ctx, eventMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return e.PointToCopy(), nil
+}
+
+func newMongoFromAuditEvent(e *audit.Event) (*AuditMongo, error) {
+	id, err := primitive.ObjectIDFromHex(e.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]FieldChangeMongo, len(e.Changes))
+	for i, c := range e.Changes {
+		oldJson, err := json.Marshal(c.Old)
+		if err != nil {
+			return nil, err
+		}
+		newJson, err := json.Marshal(c.New)
+		if err != nil {
+			return nil, err
+		}
+		changes[i] = FieldChangeMongo{Field: c.Field, Old: string(oldJson), New: string(newJson)}
+	}
+
+	return &AuditMongo{
+		Id:         id,
+		ActorId:    e.ActorId,
+		Timestamp:  e.Timestamp,
+		Type:       e.Type,
+		ObjectType: e.ObjectType,
+		ObjectId:   e.ObjectId,
+		OwnerId:    e.OwnerId,
+		Changes:    changes,
+	}, nil
+}
+
+func newAuditEventFromMongo(e *AuditMongo) (*audit.Event, error) {
+	changes := make([]d.FieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i].Field = c.Field
+		if err := json.Unmarshal([]byte(c.Old), &changes[i].Old); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(c.New), &changes[i].New); err != nil {
+			return nil, err
+		}
+	}
+
+	return &audit.Event{
+		Id:         e.Id.Hex(),
+		ActorId:    e.ActorId,
+		Timestamp:  e.Timestamp,
+		Type:       e.Type,
+		ObjectType: e.ObjectType,
+		ObjectId:   e.ObjectId,
+		OwnerId:    e.OwnerId,
+		Changes:    changes,
+	}, nil
+}
+
+func (s *AuditDbService) Retrieve(ctx context.Context, q *audit.Query) ([]*audit.Event, *d.DbError) {
+	filter := bson.M{}
+	if q.ActorId != "" {
+		filter["actorId"] = q.ActorId
+	}
+	if q.ObjectType != "" {
+		filter["objectType"] = q.ObjectType
+	}
+	if q.ObjectId != "" {
+		filter["objectId"] = q.ObjectId
+	}
+	if q.OwnerId != "" {
+		filter["ownerId"] = q.OwnerId
+	}
+	if !q.Since.IsZero() {
+		filter["timestamp"] = bson.M{"$gte": q.Since}
+	}
+
+	opts := options.Find().SetSort(bson.D{{"timestamp", -1}})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+
+	cur, err := s.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	events := make([]*audit.Event, 0)
+	for cur.Next(ctx) {
+		var eventMongo AuditMongo
+		if err := cur.Decode(&eventMongo); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+
+		e, err := newAuditEventFromMongo(&eventMongo)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
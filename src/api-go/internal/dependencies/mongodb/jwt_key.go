@@ -0,0 +1,67 @@
+package mongodb
+
+import (
+	"context"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const jwtKeyCollectionName = "jwt_key"
+
+type JwtKeyMongo struct {
+	Id         string    `bson:"_id"`
+	Algorithm  string    `bson:"algorithm"`
+	PrivateKey []byte    `bson:"privateKey"`
+	CreatedAt  time.Time `bson:"createdAt"`
+}
+
+type JwtKeyDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewJwtKeyDbService(db *DbService) *JwtKeyDbService {
+	coll := db.Client.Database(db.DbName).Collection(jwtKeyCollectionName)
+	return &JwtKeyDbService{Db: db, Collection: coll}
+}
+
+func (s *JwtKeyDbService) Create(ctx context.Context, key *d.JwtKey) *d.DbError {
+	keyMongo := JwtKeyMongo{Id: key.Id, Algorithm: key.Algorithm, PrivateKey: key.PrivateKey, CreatedAt: key.CreatedAt}
+
+	if _, err := s.Collection.InsertOne(ctx, keyMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return nil
+}
+
+func (s *JwtKeyDbService) RetrieveAll(ctx context.Context, algorithm string) ([]*d.JwtKey, *d.DbError) {
+	cur, err := s.Collection.Find(ctx, bson.M{"algorithm": algorithm})
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	var keysMongo []*JwtKeyMongo
+	if err = cur.All(ctx, &keysMongo); err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	keys := make([]*d.JwtKey, len(keysMongo))
+	for i, k := range keysMongo {
+		keys[i] = &d.JwtKey{Id: k.Id, Algorithm: k.Algorithm, PrivateKey: k.PrivateKey, CreatedAt: k.CreatedAt.UTC()}
+	}
+
+	return keys, nil
+}
+
+func (s *JwtKeyDbService) Delete(ctx context.Context, ids []string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const attemptCollectionName = "attempt"
+
+type AttemptsMongo struct {
+	Key         string    `bson:"_id"`
+	Failures    int       `bson:"failures"`
+	LastFailure time.Time `bson:"lastFailure"`
+}
+
+type AttemptDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewAttemptDbService(db *DbService, createIndex bool) *AttemptDbService {
+	coll := db.Client.Database(db.DbName).Collection(attemptCollectionName)
+
+	if createIndex {
+		mod := mongo.IndexModel{Keys: bson.D{{"lastFailure", 1}}}
+		if _, err := coll.Indexes().CreateOne(context.TODO(), mod); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &AttemptDbService{Db: db, Collection: coll}
+}
+
+func newAttemptsFromMongo(a *AttemptsMongo) *lockout.Attempts {
+	return &lockout.Attempts{Key: a.Key, Failures: a.Failures, LastFailure: a.LastFailure.UTC()}
+}
+
+func (s *AttemptDbService) Retrieve(ctx context.Context, key string) (*lockout.Attempts, *d.DbError) {
+	var attemptsMongo AttemptsMongo
+	if err := s.Collection.FindOne(ctx, bson.M{"_id": key}).Decode(&attemptsMongo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newAttemptsFromMongo(&attemptsMongo), nil
+}
+
+func (s *AttemptDbService) Increment(ctx context.Context, key string, now time.Time, window time.Duration) (*lockout.Attempts, *d.DbError) {
+	cutoff := now.Add(-window)
+
+	// Failures before cutoff are forgotten in the same update that adds the new one, so that parallel increments of
+	// the same key can not overwrite each other.
+	update := mongo.Pipeline{{{"$set", bson.M{
+		"failures":    bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$lastFailure", cutoff}}, bson.M{"$add": bson.A{"$failures", 1}}, 1}},
+		"lastFailure": now,
+	}}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous AttemptsMongo
+	err := s.Collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&previous)
+	if mongo.IsDuplicateKeyError(err) {
+		// Two parallel upserts of a new key, the document exists now.
+		err = s.Collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&previous)
+	}
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		previous = AttemptsMongo{Key: key}
+	}
+
+	if previous.LastFailure.Before(cutoff) {
+		previous.Failures = 0
+	}
+
+	return newAttemptsFromMongo(&previous), nil
+}
+
+func (s *AttemptDbService) Decrement(ctx context.Context, key string) *d.DbError {
+	filter := bson.M{"_id": key, "failures": bson.M{"$gt": 0}}
+	if _, err := s.Collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"failures": -1}}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	return nil
+}
+
+func (s *AttemptDbService) Delete(ctx context.Context, key string) *d.DbError {
+	if _, err := s.Collection.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	return nil
+}
+
+func (s *AttemptDbService) DeleteBefore(ctx context.Context, t time.Time) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"lastFailure": bson.M{"$lt": t}}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	return nil
+}
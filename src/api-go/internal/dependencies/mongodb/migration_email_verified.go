@@ -0,0 +1,14 @@
+package mongodb
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateEmailVerified marks users created before email verification was introduced as verified. It is safe to run
+// repeatedly.
+func (s *UserDbService) MigrateEmailVerified(ctx context.Context) error {
+	filter := bson.M{"emailVerified": bson.M{"$exists": false}}
+	_, err := s.Collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"emailVerified": true}})
+	return err
+}
@@ -0,0 +1,110 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+)
+
+// MigrateLegacySpecies inserts built-in species and replaces legacy species codes stored in characters, careers and
+// generation properties with species ids. It is safe to run repeatedly.
+func (s *WhDbService) MigrateLegacySpecies(ctx context.Context) error {
+	for _, v := range warhammer.NewLegacySpecies() {
+		whBsonM, err := whToBsonM(v)
+		if err != nil {
+			return err
+		}
+		id := whBsonM["_id"]
+		delete(whBsonM, "_id")
+
+		opts := options.Update().SetUpsert(true)
+		if _, err = s.Collections[warhammer.WhTypeSpecies].UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$setOnInsert": whBsonM}, opts); err != nil {
+			return err
+		}
+	}
+
+	characterFilter := bson.M{"object.species": bson.M{"$regex": "^[0-9]{4}$"}}
+	characterUpdate := mongo.Pipeline{{{"$set", bson.M{"object.species": bson.M{"$concat": bson.A{warhammer.LegacySpeciesIdPrefix, "$object.species"}}}}}}
+	if _, err := s.Collections[warhammer.WhTypeCharacter].UpdateMany(ctx, characterFilter, characterUpdate); err != nil {
+		return err
+	}
+
+	for legacy := warhammer.WhCareerSpeciesHuman; legacy <= warhammer.WhCareerSpeciesOgre; legacy++ {
+		id, ok := warhammer.SpeciesIdFromCareerSpecies(legacy)
+		if !ok {
+			continue
+		}
+		filter := bson.M{"object.species": legacy}
+		if _, err := s.Collections[warhammer.WhTypeCareer].UpdateMany(ctx, filter, bson.M{"$set": bson.M{"object.species": id}}); err != nil {
+			return err
+		}
+	}
+
+	for _, coll := range []*mongo.Collection{s.Collections[warhammer.WhTypeOther], s.GenerationHistoryCollection} {
+		if err := migrateGenerationPropsSpecies(ctx, coll); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func migrateGenerationPropsSpecies(ctx context.Context, coll *mongo.Collection) error {
+	cur, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc struct {
+			Id any `bson:"_id"`
+		}
+		if err = cur.Decode(&doc); err != nil {
+			return err
+		}
+
+		var gp warhammer.WhGenerationProps
+		if err = cur.Decode(&gp); err != nil {
+			return err
+		}
+
+		migrated := gp.InitAndCopy()
+		if reflect.DeepEqual(migrated.SpeciesTalents, gp.SpeciesTalents) && reflect.DeepEqual(migrated.SpeciesSkills, gp.SpeciesSkills) {
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"speciestalents": migrated.SpeciesTalents, "speciesskills": migrated.SpeciesSkills}}
+		if _, err = coll.UpdateOne(ctx, bson.M{"_id": doc.Id}, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateLegacyCareerSpecies replaces integer career species of documents not yet migrated so that they can be
+// decoded into string species ids.
+func migrateLegacyCareerSpecies(object any) {
+	objectMap, ok := object.(bson.M)
+	if !ok {
+		return
+	}
+
+	var legacy int
+	switch v := objectMap["species"].(type) {
+	case int32:
+		legacy = int(v)
+	case int64:
+		legacy = int(v)
+	default:
+		return
+	}
+
+	if id, ok := warhammer.SpeciesIdFromCareerSpecies(legacy); ok {
+		objectMap["species"] = id
+	}
+}
@@ -0,0 +1,23 @@
+package mongodb
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateUserRoles replaces the admin flag stored before roles were introduced with the admin role. It is safe to run
+// repeatedly.
+func (s *UserDbService) MigrateUserRoles(ctx context.Context) error {
+	admins := bson.M{"roles": bson.M{"$exists": false}, "admin": true}
+	if _, err := s.Collection.UpdateMany(ctx, admins, bson.M{"$set": bson.M{"roles": bson.A{"admin"}}}); err != nil {
+		return err
+	}
+
+	others := bson.M{"roles": bson.M{"$exists": false}}
+	if _, err := s.Collection.UpdateMany(ctx, others, bson.M{"$set": bson.M{"roles": bson.A{}}}); err != nil {
+		return err
+	}
+
+	_, err := s.Collection.UpdateMany(ctx, bson.M{"admin": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"admin": ""}})
+	return err
+}
@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateWhTimestamps sets createdat and updatedat of objects stored before they were tracked to the creation time of
+// the object id, so that such objects are included when listing changes. It is safe to run repeatedly.
+func (s *WhDbService) MigrateWhTimestamps(ctx context.Context) error {
+	// Ids of built-in objects are not generated, their timestamp is capped so that they do not appear changed forever.
+	createdAt := bson.M{"$min": bson.A{bson.M{"$toDate": "$_id"}, "$$NOW"}}
+
+	filter := bson.M{"$or": bson.A{bson.M{"createdat": bson.M{"$exists": false}}, bson.M{"updatedat": bson.M{"$exists": false}}}}
+	update := mongo.Pipeline{{{"$set", bson.M{
+		"createdat": bson.M{"$ifNull": bson.A{"$createdat", createdAt}},
+		"updatedat": bson.M{"$ifNull": bson.A{"$updatedat", "$createdat", createdAt}},
+	}}}}
+
+	for _, t := range warhammer.WhApiTypes {
+		if _, err := s.Collections[t].UpdateMany(ctx, filter, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
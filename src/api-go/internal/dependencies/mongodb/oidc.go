@@ -0,0 +1,180 @@
+package mongodb
+
+import (
+	"context"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const (
+	oidcLinkCollectionName       = "oidc_link"
+	oidcLoginStateCollectionName = "oidc_login_state"
+)
+
+type OidcLinkMongo struct {
+	Provider  string    `bson:"provider"`
+	Subject   string    `bson:"subject"`
+	UserId    string    `bson:"userId"`
+	Email     string    `bson:"email"`
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+type OidcLinkDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewOidcLinkDbService(db *DbService, createIndex bool) *OidcLinkDbService {
+	coll := db.Client.Database(db.DbName).Collection(oidcLinkCollectionName)
+
+	if createIndex {
+		unique := true
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"provider", 1}, {"subject", 1}}, Options: &options.IndexOptions{Unique: &unique}},
+			{Keys: bson.D{{"userId", 1}, {"provider", 1}}, Options: &options.IndexOptions{Unique: &unique}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &OidcLinkDbService{Db: db, Collection: coll}
+}
+
+func newOidcLinkFromMongo(l *OidcLinkMongo) *oidc.Link {
+	return &oidc.Link{
+		Provider:  l.Provider,
+		Subject:   l.Subject,
+		UserId:    l.UserId,
+		Email:     l.Email,
+		CreatedAt: l.CreatedAt.UTC(),
+	}
+}
+
+func (s *OidcLinkDbService) Create(ctx context.Context, l *oidc.Link) (*oidc.Link, *d.DbError) {
+	linkMongo := OidcLinkMongo{Provider: l.Provider, Subject: l.Subject, UserId: l.UserId, Email: l.Email, CreatedAt: l.CreatedAt}
+
+	if _, err := s.Collection.InsertOne(ctx, linkMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return l.PointToCopy(), nil
+}
+
+func (s *OidcLinkDbService) Retrieve(ctx context.Context, provider string, subject string) (*oidc.Link, *d.DbError) {
+	var linkMongo OidcLinkMongo
+	if err := s.Collection.FindOne(ctx, bson.M{"provider": provider, "subject": subject}).Decode(&linkMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newOidcLinkFromMongo(&linkMongo), nil
+}
+
+func (s *OidcLinkDbService) RetrieveByUser(ctx context.Context, userId string) ([]*oidc.Link, *d.DbError) {
+	cur, err := s.Collection.Find(ctx, bson.M{"userId": userId}, options.Find().SetSort(bson.D{{"provider", 1}}))
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	var linksMongo []*OidcLinkMongo
+	if err = cur.All(ctx, &linksMongo); err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	links := make([]*oidc.Link, len(linksMongo))
+	for i, l := range linksMongo {
+		links[i] = newOidcLinkFromMongo(l)
+	}
+
+	return links, nil
+}
+
+func (s *OidcLinkDbService) Delete(ctx context.Context, userId string, provider string) *d.DbError {
+	res, err := s.Collection.DeleteOne(ctx, bson.M{"userId": userId, "provider": provider})
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	if res.DeletedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, mongo.ErrNoDocuments)
+	}
+
+	return nil
+}
+
+func (s *OidcLinkDbService) DeleteByUser(ctx context.Context, userId string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"userId": userId}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+type OidcLoginStateMongo struct {
+	State        string    `bson:"_id"`
+	Provider     string    `bson:"provider"`
+	CodeVerifier string    `bson:"codeVerifier"`
+	Nonce        string    `bson:"nonce"`
+	ExpiresAt    time.Time `bson:"expiresAt"`
+}
+
+type OidcLoginStateDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewOidcLoginStateDbService(db *DbService, createIndex bool) *OidcLoginStateDbService {
+	coll := db.Client.Database(db.DbName).Collection(oidcLoginStateCollectionName)
+
+	if createIndex {
+		// Abandoned logins are removed by mongo.
+		expireAfter := int32(0)
+		mod := mongo.IndexModel{Keys: bson.D{{"expiresAt", 1}}, Options: &options.IndexOptions{ExpireAfterSeconds: &expireAfter}}
+		if _, err := coll.Indexes().CreateOne(context.TODO(), mod); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &OidcLoginStateDbService{Db: db, Collection: coll}
+}
+
+func (s *OidcLoginStateDbService) Create(ctx context.Context, st *oidc.LoginState) *d.DbError {
+	stateMongo := OidcLoginStateMongo{State: st.State, Provider: st.Provider, CodeVerifier: st.CodeVerifier, Nonce: st.Nonce, ExpiresAt: st.ExpiresAt}
+
+	if _, err := s.Collection.InsertOne(ctx, stateMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return nil
+}
+
+func (s *OidcLoginStateDbService) Take(ctx context.Context, state string) (*oidc.LoginState, *d.DbError) {
+	var stateMongo OidcLoginStateMongo
+	if err := s.Collection.FindOneAndDelete(ctx, bson.M{"_id": state}).Decode(&stateMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return &oidc.LoginState{
+		State:        stateMongo.State,
+		Provider:     stateMongo.Provider,
+		CodeVerifier: stateMongo.CodeVerifier,
+		Nonce:        stateMongo.Nonce,
+		ExpiresAt:    stateMongo.ExpiresAt.UTC(),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"context"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const patCollectionName = "pat"
+
+type PatMongo struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	UserId     string             `bson:"userId"`
+	Name       string             `bson:"name"`
+	Scopes     []string           `bson:"scopes"`
+	TokenHash  string             `bson:"tokenHash"`
+	CreatedAt  time.Time          `bson:"createdAt"`
+	LastUsedAt time.Time          `bson:"lastUsedAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt"`
+}
+
+type PatDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewPatDbService(db *DbService, createIndex bool) *PatDbService {
+	coll := db.Client.Database(db.DbName).Collection(patCollectionName)
+
+	if createIndex {
+		unique := true
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"tokenHash", 1}}, Options: &options.IndexOptions{Unique: &unique}},
+			{Keys: bson.D{{"userId", 1}}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &PatDbService{Db: db, Collection: coll}
+}
+
+func newPatFromMongo(t *PatMongo) *pat.Token {
+	scopes := t.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &pat.Token{
+		Id:         t.Id.Hex(),
+		UserId:     t.UserId,
+		Name:       t.Name,
+		Scopes:     scopes,
+		TokenHash:  t.TokenHash,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+}
+
+func (s *PatDbService) Create(ctx context.Context, t *pat.Token) (*pat.Token, *d.DbError) {
+	id, err := primitive.ObjectIDFromHex(t.Id)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	patMongo := PatMongo{
+		Id:         id,
+		UserId:     t.UserId,
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		TokenHash:  t.TokenHash,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+
+	if _, err = s.Collection.InsertOne(ctx, patMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return t.PointToCopy(), nil
+}
+
+func (s *PatDbService) RetrieveByHash(ctx context.Context, tokenHash string) (*pat.Token, *d.DbError) {
+	var patMongo PatMongo
+	if err := s.Collection.FindOne(ctx, bson.M{"tokenHash": tokenHash}).Decode(&patMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newPatFromMongo(&patMongo), nil
+}
+
+func (s *PatDbService) RetrieveByUser(ctx context.Context, userId string) ([]*pat.Token, *d.DbError) {
+	cur, err := s.Collection.Find(ctx, bson.M{"userId": userId}, options.Find().SetSort(bson.D{{"createdAt", 1}}))
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	tokens := make([]*pat.Token, 0)
+	for cur.Next(ctx) {
+		var patMongo PatMongo
+		if err := cur.Decode(&patMongo); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		tokens = append(tokens, newPatFromMongo(&patMongo))
+	}
+
+	return tokens, nil
+}
+
+func (s *PatDbService) Touch(ctx context.Context, id string, lastUsedAt time.Time) *d.DbError {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	if _, err = s.Collection.UpdateOne(ctx, bson.M{"_id": objectId}, bson.M{"$set": bson.M{"lastUsedAt": lastUsedAt}}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *PatDbService) Delete(ctx context.Context, userId string, id string) *d.DbError {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	res, err := s.Collection.DeleteOne(ctx, bson.M{"_id": objectId, "userId": userId})
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	if res.DeletedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, mongo.ErrNoDocuments)
+	}
+
+	return nil
+}
+
+func (s *PatDbService) DeleteByUser(ctx context.Context, userId string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"userId": userId}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
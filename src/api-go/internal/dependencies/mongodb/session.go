@@ -0,0 +1,161 @@
+package mongodb
+
+import (
+	"context"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const sessionCollectionName = "session"
+
+type SessionMongo struct {
+	Id              primitive.ObjectID `bson:"_id"`
+	UserId          string             `bson:"userId"`
+	TokenHash       string             `bson:"tokenHash"`
+	UsedTokenHashes []string           `bson:"usedTokenHashes"`
+	CreatedAt       time.Time          `bson:"createdAt"`
+	RefreshedAt     time.Time          `bson:"refreshedAt"`
+	ExpiresAt       time.Time          `bson:"expiresAt"`
+	RevokedAt       time.Time          `bson:"revokedAt"`
+	RevokedReason   string             `bson:"revokedReason"`
+}
+
+type SessionDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewSessionDbService(db *DbService, createIndex bool) *SessionDbService {
+	coll := db.Client.Database(db.DbName).Collection(sessionCollectionName)
+
+	if createIndex {
+		// Expired sessions are removed by mongo, tokens referring to them are treated as revoked.
+		expireAfter := int32(0)
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"userId", 1}}},
+			{Keys: bson.D{{"expiresAt", 1}}, Options: &options.IndexOptions{ExpireAfterSeconds: &expireAfter}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &SessionDbService{Db: db, Collection: coll}
+}
+
+func newSessionFromMongo(s *SessionMongo) *session.Session {
+	usedTokenHashes := s.UsedTokenHashes
+	if usedTokenHashes == nil {
+		usedTokenHashes = []string{}
+	}
+
+	return &session.Session{
+		Id:              s.Id.Hex(),
+		UserId:          s.UserId,
+		TokenHash:       s.TokenHash,
+		UsedTokenHashes: usedTokenHashes,
+		CreatedAt:       s.CreatedAt,
+		RefreshedAt:     s.RefreshedAt,
+		ExpiresAt:       s.ExpiresAt,
+		RevokedAt:       s.RevokedAt,
+		RevokedReason:   s.RevokedReason,
+	}
+}
+
+func (s *SessionDbService) Create(ctx context.Context, sess *session.Session) (*session.Session, *d.DbError) {
+	id, err := primitive.ObjectIDFromHex(sess.Id)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	sessionMongo := SessionMongo{
+		Id:              id,
+		UserId:          sess.UserId,
+		TokenHash:       sess.TokenHash,
+		UsedTokenHashes: sess.UsedTokenHashes,
+		CreatedAt:       sess.CreatedAt,
+		RefreshedAt:     sess.RefreshedAt,
+		ExpiresAt:       sess.ExpiresAt,
+		RevokedAt:       sess.RevokedAt,
+		RevokedReason:   sess.RevokedReason,
+	}
+
+	if _, err = s.Collection.InsertOne(ctx, sessionMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return sess.PointToCopy(), nil
+}
+
+func (s *SessionDbService) Retrieve(ctx context.Context, id string) (*session.Session, *d.DbError) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	var sessionMongo SessionMongo
+	if err = s.Collection.FindOne(ctx, bson.M{"_id": objectId}).Decode(&sessionMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newSessionFromMongo(&sessionMongo), nil
+}
+
+func (s *SessionDbService) Rotate(ctx context.Context, id string, oldHash string, newHash string, refreshedAt time.Time, expiresAt time.Time) (*session.Session, *d.DbError) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	filter := bson.M{"_id": objectId, "tokenHash": oldHash}
+	update := bson.M{
+		"$set":  bson.M{"tokenHash": newHash, "refreshedAt": refreshedAt, "expiresAt": expiresAt},
+		"$push": bson.M{"usedTokenHashes": bson.M{"$each": []string{oldHash}, "$slice": -session.MaxUsedTokenHashes}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var sessionMongo SessionMongo
+	if err = s.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&sessionMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbConflictError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newSessionFromMongo(&sessionMongo), nil
+}
+
+func (s *SessionDbService) Revoke(ctx context.Context, id string, revokedAt time.Time, reason string) *d.DbError {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	filter := bson.M{"_id": objectId, "revokedAt": time.Time{}}
+	update := bson.M{"$set": bson.M{"revokedAt": revokedAt, "revokedReason": reason}}
+	if _, err = s.Collection.UpdateOne(ctx, filter, update); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *SessionDbService) DeleteByUser(ctx context.Context, userId string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"userId": userId}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
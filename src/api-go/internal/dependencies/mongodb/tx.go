@@ -0,0 +1,61 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TxService struct {
+	Db *DbService
+}
+
+func NewTxService(db *DbService) *TxService {
+	return &TxService{Db: db}
+}
+
+// WithTransaction runs fn in a transaction on replica sets and sharded clusters. Standalone servers do not support
+// transactions, there fn is run as is.
+func (s *TxService) WithTransaction(ctx context.Context, fn func(ctx context.Context) *domain.DbError) *domain.DbError {
+	supported, err := s.transactionsSupported(ctx)
+	if err != nil {
+		return domain.CreateDbError(domain.DbInternalError, err)
+	}
+	if !supported {
+		return fn(ctx)
+	}
+
+	session, err := s.Db.Client.StartSession()
+	if err != nil {
+		return domain.CreateDbError(domain.DbInternalError, err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if dbErr := fn(sc); dbErr != nil {
+			return nil, dbErr
+		}
+		return nil, nil
+	})
+	if err != nil {
+		var dbErr *domain.DbError
+		if errors.As(err, &dbErr) {
+			return dbErr
+		}
+		return domain.CreateDbError(domain.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *TxService) transactionsSupported(ctx context.Context) (bool, error) {
+	var hello bson.M
+	if err := s.Db.Client.Database("admin").RunCommand(ctx, bson.D{{"hello", 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+
+	_, isReplicaSet := hello["setName"]
+	return isReplicaSet || hello["msg"] == "isdbgrid", nil
+}
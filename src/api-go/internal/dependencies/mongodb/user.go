@@ -19,12 +19,24 @@ const userCollectionName = "user"
 type Mongo struct {
 	Id                 primitive.ObjectID   `bson:"_id"`
 	Username           string               `bson:"username"`
+	EmailVerified      bool                 `bson:"emailVerified"`
+	PendingUsername    string               `bson:"pendingUsername"`
 	PasswordHash       []byte               `bson:"passwordHash"`
-	Admin              bool                 `bson:"admin"`
+	Roles              []string             `bson:"roles"`
 	SharedAccountIds   []primitive.ObjectID `bson:"sharedAccountIds"`
 	SharedAccountNames []string             `bson:"sharedAccountNames,omitempty"`
+	Locale             string               `bson:"locale"`
 	CreatedOn          time.Time            `bson:"createdOn"`
 	LastAuthOn         time.Time            `bson:"lastAuthOn"`
+	Totp               TotpMongo            `bson:"totp"`
+}
+
+type TotpMongo struct {
+	Secret             string   `bson:"secret"`
+	Enabled            bool     `bson:"enabled"`
+	Required           bool     `bson:"required"`
+	LastStep           int64    `bson:"lastStep"`
+	RecoveryCodeHashes []string `bson:"recoveryCodeHashes"`
 }
 
 type UserDbService struct {
@@ -77,8 +89,12 @@ func (s *UserDbService) Retrieve(ctx context.Context, fieldName string, fieldVal
 		{"sharedAccountIds", bson.D{{"$push", bson.D{{"$arrayElemAt", bson.A{"$sharedAcc._id", 0}}}}}},
 		{"sharedAccountNames", bson.D{{"$push", bson.D{{"$arrayElemAt", bson.A{"$sharedAcc.username", 0}}}}}},
 		{"username", bson.D{{"$first", "$username"}}},
+		{"emailVerified", bson.D{{"$first", "$emailVerified"}}},
+		{"pendingUsername", bson.D{{"$first", "$pendingUsername"}}},
 		{"passwordHash", bson.D{{"$first", "$passwordHash"}}},
-		{"admin", bson.D{{"$first", "$admin"}}},
+		{"roles", bson.D{{"$first", "$roles"}}},
+		{"locale", bson.D{{"$first", "$locale"}}},
+		{"totp", bson.D{{"$first", "$totp"}}},
 		{"createdOn", bson.D{{"$first", "$createdOn"}}},
 		{"lastAuthOn", bson.D{{"$first", "$lastAuthOn"}}},
 	}}}
@@ -207,11 +223,21 @@ func newMongoFromUser(u *user.User, linkedUsers []*Mongo) (*Mongo, error) {
 	userMongo := Mongo{
 		Id:               id,
 		Username:         u.Username,
+		EmailVerified:    u.EmailVerified,
+		PendingUsername:  u.PendingUsername,
 		PasswordHash:     u.PasswordHash,
-		Admin:            u.Admin,
+		Roles:            u.Roles,
 		SharedAccountIds: usernamesToIds(u.SharedAccountNames, linkedUsers),
+		Locale:           u.Locale,
 		CreatedOn:        u.CreatedOn,
 		LastAuthOn:       u.LastAuthOn,
+		Totp: TotpMongo{
+			Secret:             u.Totp.Secret,
+			Enabled:            u.Totp.Enabled,
+			Required:           u.Totp.Required,
+			LastStep:           u.Totp.LastStep,
+			RecoveryCodeHashes: u.Totp.RecoveryCodeHashes,
+		},
 	}
 
 	return &userMongo, nil
@@ -246,7 +272,11 @@ func newUserFromMongo(u *Mongo, linkedUsers []*Mongo) *user.User {
 	user := user.EmptyUser()
 	user.Id = u.Id.Hex()
 	user.Username = u.Username
-	user.Admin = u.Admin
+	user.EmailVerified = u.EmailVerified
+	user.PendingUsername = u.PendingUsername
+	if u.Roles != nil {
+		user.Roles = u.Roles
+	}
 	user.SharedAccountIds = sharedAccountIds
 	if linkedUsers != nil {
 		user.SharedAccountNames = idsToUsernames(u.SharedAccountIds, linkedUsers)
@@ -256,10 +286,27 @@ func newUserFromMongo(u *Mongo, linkedUsers []*Mongo) *user.User {
 	if u.PasswordHash != nil {
 		user.PasswordHash = u.PasswordHash
 	}
+	user.Locale = u.Locale
 	user.CreatedOn = u.CreatedOn
 	user.LastAuthOn = u.LastAuthOn
+	user.Totp = userTotpFromMongo(&u.Totp)
+
+	return &user
+}
+
+func userTotpFromMongo(t *TotpMongo) user.Totp {
+	recoveryCodeHashes := t.RecoveryCodeHashes
+	if recoveryCodeHashes == nil {
+		recoveryCodeHashes = []string{}
+	}
 
-	return user
+	return user.Totp{
+		Secret:             t.Secret,
+		Enabled:            t.Enabled,
+		Required:           t.Required,
+		LastStep:           t.LastStep,
+		RecoveryCodeHashes: recoveryCodeHashes,
+	}
 }
 
 func idsToUsernames(ids []primitive.ObjectID, users []*Mongo) []string {
@@ -311,5 +358,10 @@ func (s *UserDbService) Delete(ctx context.Context, id string) *domain.DbError {
 		return &domain.DbError{Type: domain.DbInternalError, Err: err}
 	}
 
+	_, err = s.Collection.UpdateMany(ctx, bson.D{{"sharedAccountIds", idObject}}, bson.D{{"$pull", bson.D{{"sharedAccountIds", idObject}}}})
+	if err != nil {
+		return &domain.DbError{Type: domain.DbInternalError, Err: err}
+	}
+
 	return nil
 }
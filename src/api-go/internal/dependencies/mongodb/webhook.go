@@ -0,0 +1,348 @@
+package mongodb
+
+import (
+	"context"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+const (
+	webhookCollectionName  = "webhook"
+	deliveryCollectionName = "webhook_delivery"
+)
+
+type WebhookMongo struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	OwnerId    string             `bson:"ownerId"`
+	Url        string             `bson:"url"`
+	Secret     string             `bson:"secret"`
+	EventTypes []string           `bson:"eventTypes"`
+	Active     bool               `bson:"active"`
+	CreatedAt  time.Time          `bson:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt"`
+}
+
+type WebhookDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewWebhookDbService(db *DbService, createIndex bool) *WebhookDbService {
+	coll := db.Client.Database(db.DbName).Collection(webhookCollectionName)
+
+	if createIndex {
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"ownerId", 1}}},
+			{Keys: bson.D{{"eventTypes", 1}, {"active", 1}}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &WebhookDbService{Db: db, Collection: coll}
+}
+
+func newMongoFromWebhook(w *webhook.Webhook) (*WebhookMongo, error) {
+	id, err := primitive.ObjectIDFromHex(w.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookMongo{
+		Id:         id,
+		OwnerId:    w.OwnerId,
+		Url:        w.Url,
+		Secret:     w.Secret,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}, nil
+}
+
+func newWebhookFromMongo(w *WebhookMongo) *webhook.Webhook {
+	eventTypes := w.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &webhook.Webhook{
+		Id:         w.Id.Hex(),
+		OwnerId:    w.OwnerId,
+		Url:        w.Url,
+		Secret:     w.Secret,
+		EventTypes: eventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+func (s *WebhookDbService) Create(ctx context.Context, w *webhook.Webhook) (*webhook.Webhook, *d.DbError) {
+	webhookMongo, err := newMongoFromWebhook(w)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if _, err = s.Collection.InsertOne(ctx, webhookMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return w.PointToCopy(), nil
+}
+
+func (s *WebhookDbService) Update(ctx context.Context, w *webhook.Webhook) (*webhook.Webhook, *d.DbError) {
+	webhookMongo, err := newMongoFromWebhook(w)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	res, err := s.Collection.ReplaceOne(ctx, bson.M{"_id": webhookMongo.Id}, webhookMongo)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, d.CreateDbError(d.DbNotFoundError, mongo.ErrNoDocuments)
+	}
+
+	return w.PointToCopy(), nil
+}
+
+func (s *WebhookDbService) Retrieve(ctx context.Context, id string) (*webhook.Webhook, *d.DbError) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	var webhookMongo WebhookMongo
+	if err = s.Collection.FindOne(ctx, bson.M{"_id": objectId}).Decode(&webhookMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newWebhookFromMongo(&webhookMongo), nil
+}
+
+func (s *WebhookDbService) RetrieveByOwner(ctx context.Context, ownerId string) ([]*webhook.Webhook, *d.DbError) {
+	return s.find(ctx, bson.M{"ownerId": ownerId})
+}
+
+func (s *WebhookDbService) RetrieveByEventType(ctx context.Context, eventType string) ([]*webhook.Webhook, *d.DbError) {
+	return s.find(ctx, bson.M{"eventTypes": eventType, "active": true})
+}
+
+func (s *WebhookDbService) find(ctx context.Context, filter bson.M) ([]*webhook.Webhook, *d.DbError) {
+	cur, err := s.Collection.Find(ctx, filter, options.Find().SetSort(bson.D{{"createdAt", 1}}))
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	webhooks := make([]*webhook.Webhook, 0)
+	for cur.Next(ctx) {
+		var webhookMongo WebhookMongo
+		if err := cur.Decode(&webhookMongo); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		webhooks = append(webhooks, newWebhookFromMongo(&webhookMongo))
+	}
+
+	return webhooks, nil
+}
+
+func (s *WebhookDbService) Delete(ctx context.Context, id string) *d.DbError {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return d.CreateDbError(d.DbNotFoundError, err)
+	}
+
+	res, err := s.Collection.DeleteOne(ctx, bson.M{"_id": objectId})
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	if res.DeletedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, mongo.ErrNoDocuments)
+	}
+
+	return nil
+}
+
+type DeliveryMongo struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	WebhookId      string             `bson:"webhookId"`
+	OwnerId        string             `bson:"ownerId"`
+	EventId        string             `bson:"eventId"`
+	EventType      string             `bson:"eventType"`
+	Payload        string             `bson:"payload"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+	NextAttemptAt  time.Time          `bson:"nextAttemptAt"`
+	LastAttemptAt  time.Time          `bson:"lastAttemptAt"`
+	ResponseStatus int                `bson:"responseStatus"`
+	Error          string             `bson:"error"`
+}
+
+type WebhookDeliveryDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewWebhookDeliveryDbService(db *DbService, createIndex bool) *WebhookDeliveryDbService {
+	coll := db.Client.Database(db.DbName).Collection(deliveryCollectionName)
+
+	if createIndex {
+		mods := []mongo.IndexModel{
+			{Keys: bson.D{{"webhookId", 1}, {"createdAt", -1}}},
+			{Keys: bson.D{{"status", 1}, {"nextAttemptAt", 1}}},
+			{Keys: bson.D{{"ownerId", 1}}},
+		}
+		if _, err := coll.Indexes().CreateMany(context.TODO(), mods); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &WebhookDeliveryDbService{Db: db, Collection: coll}
+}
+
+func newMongoFromDelivery(dl *webhook.Delivery) (*DeliveryMongo, error) {
+	id, err := primitive.ObjectIDFromHex(dl.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryMongo{
+		Id:             id,
+		WebhookId:      dl.WebhookId,
+		OwnerId:        dl.OwnerId,
+		EventId:        dl.EventId,
+		EventType:      dl.EventType,
+		Payload:        dl.Payload,
+		Status:         dl.Status,
+		Attempts:       dl.Attempts,
+		CreatedAt:      dl.CreatedAt,
+		NextAttemptAt:  dl.NextAttemptAt,
+		LastAttemptAt:  dl.LastAttemptAt,
+		ResponseStatus: dl.ResponseStatus,
+		Error:          dl.Error,
+	}, nil
+}
+
+func newDeliveryFromMongo(dl *DeliveryMongo) *webhook.Delivery {
+	return &webhook.Delivery{
+		Id:             dl.Id.Hex(),
+		WebhookId:      dl.WebhookId,
+		OwnerId:        dl.OwnerId,
+		EventId:        dl.EventId,
+		EventType:      dl.EventType,
+		Payload:        dl.Payload,
+		Status:         dl.Status,
+		Attempts:       dl.Attempts,
+		CreatedAt:      dl.CreatedAt,
+		NextAttemptAt:  dl.NextAttemptAt,
+		LastAttemptAt:  dl.LastAttemptAt,
+		ResponseStatus: dl.ResponseStatus,
+		Error:          dl.Error,
+	}
+}
+
+func (s *WebhookDeliveryDbService) Create(ctx context.Context, dl *webhook.Delivery) (*webhook.Delivery, *d.DbError) {
+	deliveryMongo, err := newMongoFromDelivery(dl)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if _, err = s.Collection.InsertOne(ctx, deliveryMongo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return dl.PointToCopy(), nil
+}
+
+func (s *WebhookDeliveryDbService) Update(ctx context.Context, dl *webhook.Delivery) (*webhook.Delivery, *d.DbError) {
+	deliveryMongo, err := newMongoFromDelivery(dl)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	res, err := s.Collection.ReplaceOne(ctx, bson.M{"_id": deliveryMongo.Id}, deliveryMongo)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, d.CreateDbError(d.DbNotFoundError, mongo.ErrNoDocuments)
+	}
+
+	return dl.PointToCopy(), nil
+}
+
+func (s *WebhookDeliveryDbService) Retrieve(ctx context.Context, webhookId string, limit int) ([]*webhook.Delivery, *d.DbError) {
+	opts := options.Find().SetSort(bson.D{{"createdAt", -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cur, err := s.Collection.Find(ctx, bson.M{"webhookId": webhookId}, opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	deliveries := make([]*webhook.Delivery, 0)
+	for cur.Next(ctx) {
+		var deliveryMongo DeliveryMongo
+		if err := cur.Decode(&deliveryMongo); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		deliveries = append(deliveries, newDeliveryFromMongo(&deliveryMongo))
+	}
+
+	return deliveries, nil
+}
+
+func (s *WebhookDeliveryDbService) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*webhook.Delivery, *d.DbError) {
+	filter := bson.M{"status": webhook.DeliveryStatusPending, "nextAttemptAt": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": leaseUntil}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{"nextAttemptAt", 1}}).SetReturnDocument(options.After)
+
+	var deliveryMongo DeliveryMongo
+	if err := s.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&deliveryMongo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return newDeliveryFromMongo(&deliveryMongo), nil
+}
+
+func (s *WebhookDeliveryDbService) DeleteAll(ctx context.Context, webhookId string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"webhookId": webhookId}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *WebhookDeliveryDbService) DeleteByOwner(ctx context.Context, ownerId string) *d.DbError {
+	if _, err := s.Collection.DeleteMany(ctx, bson.M{"ownerId": ownerId}); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
@@ -8,11 +8,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
+const generationHistoryCollectionName = "generationHistory"
+const enumCollectionName = "enum"
+const removedCollectionName = "removed"
+
 type WhDbService struct {
-	Db          *DbService
-	Collections map[warhammer.WhType]*mongo.Collection
+	Db                          *DbService
+	Collections                 map[warhammer.WhType]*mongo.Collection
+	GenerationHistoryCollection *mongo.Collection
+	EnumCollection              *mongo.Collection
+	RemovedCollection           *mongo.Collection
 }
 
 func NewWhDbService(db *DbService) *WhDbService {
@@ -23,7 +32,17 @@ func NewWhDbService(db *DbService) *WhDbService {
 	}
 	collections[warhammer.WhTypeOther] = db.Client.Database(db.DbName).Collection(warhammer.WhTypeOther)
 
-	return &WhDbService{Db: db, Collections: collections}
+	historyCollection := db.Client.Database(db.DbName).Collection(generationHistoryCollectionName)
+	enumCollection := db.Client.Database(db.DbName).Collection(enumCollectionName)
+	removedCollection := db.Client.Database(db.DbName).Collection(removedCollectionName)
+
+	return &WhDbService{
+		Db:                          db,
+		Collections:                 collections,
+		GenerationHistoryCollection: historyCollection,
+		EnumCollection:              enumCollection,
+		RemovedCollection:           removedCollection,
+	}
 }
 
 func allAllowedOwnersQuery(userIds []string, sharedUserIds []string) bson.M {
@@ -37,11 +56,19 @@ func allAllowedOwnersQuery(userIds []string, sharedUserIds []string) bson.M {
 		for _, v := range sharedUserIds {
 			sharedOwners = append(sharedOwners, bson.M{"ownerid": v})
 		}
-		owners = append(owners, bson.M{"$and": bson.A{bson.M{"shared": true}, bson.M{"$or": sharedOwners}}})
+		owners = append(owners, bson.M{"$and": bson.A{bson.M{"shared": true}, bson.M{"hidden": bson.M{"$ne": true}}, bson.M{"$or": sharedOwners}}})
 	}
 	return bson.M{"$or": owners}
 }
 
+func notDeletedQuery() bson.M {
+	return bson.M{"deletedat": nil}
+}
+
+func deletedQuery() bson.M {
+	return bson.M{"deletedat": bson.M{"$ne": nil}}
+}
+
 func idsQuery(whIds []string) (bson.M, error) {
 	ids := bson.A{}
 	for _, v := range whIds {
@@ -74,6 +101,56 @@ func bsonMToWh(whMap bson.M, t warhammer.WhType) (*warhammer.Wh, error) {
 	wh.OwnerId = ownerId
 	wh.CanEdit = false
 
+	switch version := whMap["version"].(type) {
+	case int32:
+		wh.Version = int(version)
+	case int64:
+		wh.Version = int(version)
+	}
+
+	if createdAt, ok := whMap["createdat"].(primitive.DateTime); ok {
+		wh.CreatedAt = createdAt.Time().UTC()
+	}
+
+	if updatedAt, ok := whMap["updatedat"].(primitive.DateTime); ok {
+		wh.UpdatedAt = updatedAt.Time().UTC()
+	}
+
+	if lastModifiedBy, ok := whMap["lastmodifiedby"].(string); ok {
+		wh.LastModifiedBy = lastModifiedBy
+	}
+
+	if deletedAt, ok := whMap["deletedat"].(primitive.DateTime); ok {
+		deletedAtTime := deletedAt.Time().UTC()
+		wh.DeletedAt = &deletedAtTime
+	}
+
+	if hidden, ok := whMap["hidden"].(bool); ok {
+		wh.Hidden = hidden
+	}
+
+	if hiddenBy, ok := whMap["hiddenby"].(string); ok {
+		wh.HiddenBy = hiddenBy
+	}
+
+	if hiddenReason, ok := whMap["hiddenreason"].(string); ok {
+		wh.HiddenReason = hiddenReason
+	}
+
+	if translations := whMap["translations"]; translations != nil {
+		bsonRaw, err := bson.Marshal(translations)
+		if err != nil {
+			return nil, errors.New("error marshaling translations")
+		}
+		if err = bson.Unmarshal(bsonRaw, &wh.Translations); err != nil {
+			return nil, errors.New("error unmarshalling translations")
+		}
+	}
+
+	if t == warhammer.WhTypeCareer {
+		migrateLegacyCareerSpecies(whMap["object"])
+	}
+
 	bsonRaw, err := bson.Marshal(whMap["object"])
 	if err != nil {
 		return nil, errors.New("error marshaling object")
@@ -127,45 +204,310 @@ func whToBsonM(w *warhammer.Wh) (bson.M, error) {
 	return whMap, err
 }
 
-func (s *WhDbService) Update(ctx context.Context, t warhammer.WhType, w *warhammer.Wh, userId string) (*warhammer.Wh, *d.DbError) {
+func (s *WhDbService) Update(ctx context.Context, t warhammer.WhType, w *warhammer.Wh, ownerId string) (*warhammer.Wh, *d.DbError) {
 	id, err := primitive.ObjectIDFromHex(w.Id)
 	if err != nil {
 		return nil, d.CreateDbError(d.DbInternalError, err)
 	}
 
-	whBsonM, err := whToBsonM(w)
+	updatedWh := w.PointToCopy()
+	updatedWh.Version++
+
+	whBsonM, err := whToBsonM(updatedWh)
 	if err != nil {
 		return nil, d.CreateDbError(d.DbWriteToDbError, err)
 	}
 
-	findByIdQuery := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": userId}}}
+	findByIdQuery := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": ownerId}, notDeletedQuery()}}
+	findByVersionQuery := bson.M{"$and": bson.A{findByIdQuery, versionQuery(w.Version)}}
 
-	result, err := s.Collections[t].UpdateOne(ctx, findByIdQuery, bson.M{"$set": whBsonM})
+	result, err := s.Collections[t].UpdateOne(ctx, findByVersionQuery, bson.M{"$set": whBsonM})
 	if err != nil {
 		return nil, d.CreateDbError(d.DbInternalError, err)
 	}
 
 	if result.MatchedCount == 0 {
-		return nil, d.CreateDbError(d.DbNotFoundError, err)
+		count, err := s.Collections[t].CountDocuments(ctx, findByIdQuery)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		if count > 0 {
+			return nil, d.CreateDbError(d.DbConflictError, errors.New("wh version mismatch"))
+		}
+		return nil, d.CreateDbError(d.DbNotFoundError, errors.New("wh not found"))
 	}
 
-	return w, nil
+	return updatedWh, nil
 }
 
-func (s *WhDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, userId string) *d.DbError {
+// versionQuery treats documents stored before versioning was introduced as version 0.
+func versionQuery(version int) bson.M {
+	if version == 0 {
+		return bson.M{"$or": bson.A{bson.M{"version": 0}, bson.M{"version": bson.M{"$exists": false}}}}
+	}
+	return bson.M{"version": version}
+}
+
+func (s *WhDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, ownerId string, userId string) *d.DbError {
 	id, err := primitive.ObjectIDFromHex(whId)
 	if err != nil {
 		return d.CreateDbError(d.DbInternalError, err)
 	}
 
-	_, err = s.Collections[t].DeleteOne(ctx, bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": userId}}})
+	now := time.Now().UTC()
+	filter := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": ownerId}, notDeletedQuery()}}
+	update := bson.M{
+		"$set": bson.M{"deletedat": now, "updatedat": now, "lastmodifiedby": userId},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := s.Collections[t].UpdateOne(ctx, filter, update)
 	if err != nil {
 		return d.CreateDbError(d.DbInternalError, err)
 	}
 
+	if result.MatchedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, errors.New("wh not found"))
+	}
+
 	return nil
 }
 
+func (s *WhDbService) Restore(ctx context.Context, t warhammer.WhType, whId string, ownerId string, userId string) (*warhammer.Wh, *d.DbError) {
+	id, err := primitive.ObjectIDFromHex(whId)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	filter := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": ownerId}, deletedQuery()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	update := bson.M{
+		"$set": bson.M{"deletedat": nil, "updatedat": time.Now().UTC(), "lastmodifiedby": userId},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var whMap bson.M
+	err = s.Collections[t].FindOneAndUpdate(ctx, filter, update, opts).Decode(&whMap)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	wh, err := bsonMToWh(whMap, t)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return wh, nil
+}
+
+// UpdateHidden hides or unhides an object. Objects owned by admin can not be hidden.
+func (s *WhDbService) UpdateHidden(ctx context.Context, t warhammer.WhType, whId string, hidden bool, reason string, userId string) (*warhammer.Wh, *d.DbError) {
+	id, err := primitive.ObjectIDFromHex(whId)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	filter := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": bson.M{"$ne": "admin"}}, notDeletedQuery()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	hiddenBy := ""
+	if hidden {
+		hiddenBy = userId
+	} else {
+		reason = ""
+	}
+	update := bson.M{
+		"$set": bson.M{"hidden": hidden, "hiddenby": hiddenBy, "hiddenreason": reason, "updatedat": time.Now().UTC()},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var whMap bson.M
+	err = s.Collections[t].FindOneAndUpdate(ctx, filter, update, opts).Decode(&whMap)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	wh, err := bsonMToWh(whMap, t)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return wh, nil
+}
+
+func (s *WhDbService) RetrieveDeleted(ctx context.Context, t warhammer.WhType, ownerId string) ([]*warhammer.Wh, *d.DbError) {
+	filter := bson.M{"$and": bson.A{bson.M{"ownerid": ownerId}, deletedQuery()}}
+	return findWh(ctx, s.Collections[t], t, filter)
+}
+
+func findWh(ctx context.Context, coll *mongo.Collection, t warhammer.WhType, filter bson.M) ([]*warhammer.Wh, *d.DbError) {
+	cur, err := coll.Find(ctx, filter)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	whList := make([]*warhammer.Wh, 0)
+	for cur.Next(ctx) {
+		var whMap bson.M
+		if err := cur.Decode(&whMap); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+
+		wh, err := bsonMToWh(whMap, t)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+
+		whList = append(whList, wh)
+	}
+
+	return whList, nil
+}
+
+func (s *WhDbService) RetrieveChanged(ctx context.Context, t warhammer.WhType, userIds []string, sharedUserIds []string, since time.Time) ([]*warhammer.Wh, *d.DbError) {
+	filter := bson.M{"$and": bson.A{allAllowedOwnersQuery(userIds, sharedUserIds), bson.M{"updatedat": bson.M{"$gte": since}}}}
+	return findWh(ctx, s.Collections[t], t, filter)
+}
+
+func (s *WhDbService) Purge(ctx context.Context, t warhammer.WhType, deletedBefore time.Time) ([]string, *d.DbError) {
+	if dbErr := s.forgetRemoved(ctx, t, deletedBefore); dbErr != nil {
+		return nil, dbErr
+	}
+
+	filter := bson.M{"deletedat": bson.M{"$lt": deletedBefore}}
+
+	toPurge, dbErr := findWh(ctx, s.Collections[t], t, filter)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if len(toPurge) == 0 {
+		return []string{}, nil
+	}
+
+	purgedIds := make([]string, len(toPurge))
+	ids := bson.A{}
+	for i, v := range toPurge {
+		purgedIds[i] = v.Id
+		id, err := primitive.ObjectIDFromHex(v.Id)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if _, err := s.Collections[t].DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return purgedIds, nil
+}
+
+func (s *WhDbService) forgetRemoved(ctx context.Context, t warhammer.WhType, removedBefore time.Time) *d.DbError {
+	filter := bson.M{"whType": string(t), "removedAt": bson.M{"$lt": removedBefore}}
+	if _, err := s.RemovedCollection.DeleteMany(ctx, filter); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+	return nil
+}
+
+func (s *WhDbService) DeleteByOwner(ctx context.Context, t warhammer.WhType, ownerId string) ([]string, *d.DbError) {
+	filter := bson.M{"ownerid": ownerId}
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cur, err := s.Collections[t].Find(ctx, filter, opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	deletedIds := make([]string, 0)
+	for cur.Next(ctx) {
+		var doc struct {
+			Id primitive.ObjectID `bson:"_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		deletedIds = append(deletedIds, doc.Id.Hex())
+	}
+
+	if len(deletedIds) == 0 {
+		return deletedIds, nil
+	}
+
+	if _, err := s.Collections[t].DeleteMany(ctx, filter); err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	now := time.Now().UTC()
+	removed := make([]any, len(deletedIds))
+	for i, id := range deletedIds {
+		removed[i] = bson.M{"whType": string(t), "whId": id, "removedAt": now}
+	}
+	if _, err := s.RemovedCollection.InsertMany(ctx, removed); err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return deletedIds, nil
+}
+
+func (s *WhDbService) RetrieveRemoved(ctx context.Context, t warhammer.WhType, sharedUserIds []string, since time.Time) ([]string, *d.DbError) {
+	removedIds := make([]string, 0)
+
+	if len(sharedUserIds) > 0 {
+		filter := bson.M{"$and": bson.A{
+			bson.M{"ownerid": bson.M{"$in": sharedUserIds}},
+			bson.M{"updatedat": bson.M{"$gte": since}},
+			bson.M{"$or": bson.A{bson.M{"shared": bson.M{"$ne": true}}, bson.M{"hidden": true}}},
+		}}
+
+		opts := options.Find().SetProjection(bson.M{"_id": 1})
+		cur, err := s.Collections[t].Find(ctx, filter, opts)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		defer cur.Close(ctx)
+
+		for cur.Next(ctx) {
+			var doc struct {
+				Id primitive.ObjectID `bson:"_id"`
+			}
+			if err := cur.Decode(&doc); err != nil {
+				return nil, d.CreateDbError(d.DbInternalError, err)
+			}
+			removedIds = append(removedIds, doc.Id.Hex())
+		}
+	}
+
+	filter := bson.M{"whType": string(t), "removedAt": bson.M{"$gte": since}}
+	cur, err := s.RemovedCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc struct {
+			WhId string `bson:"whId"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		removedIds = append(removedIds, doc.WhId)
+	}
+
+	return removedIds, nil
+}
+
 func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, userIds []string, sharedUserIds []string, whIds []string) ([]*warhammer.Wh, *d.DbError) {
 	var filter bson.M
 
@@ -174,9 +516,9 @@ func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, userIds
 		if err != nil {
 			return nil, d.CreateDbError(d.DbInternalError, err)
 		}
-		filter = bson.M{"$and": bson.A{ids, allAllowedOwnersQuery(userIds, sharedUserIds)}}
+		filter = bson.M{"$and": bson.A{ids, allAllowedOwnersQuery(userIds, sharedUserIds), notDeletedQuery()}}
 	} else {
-		filter = allAllowedOwnersQuery(userIds, sharedUserIds)
+		filter = bson.M{"$and": bson.A{allAllowedOwnersQuery(userIds, sharedUserIds), notDeletedQuery()}}
 	}
 
 	cur, err := s.Collections[t].Find(ctx, filter)
@@ -210,30 +552,41 @@ func (s *WhDbService) Retrieve(ctx context.Context, t warhammer.WhType, userIds
 	return whList, nil
 }
 
-func (s *WhDbService) RetrieveGenerationProps(ctx context.Context) (*warhammer.WhGenerationProps, *d.DbError) {
-	filter := bson.M{"name": "generationProps"}
-	var genProps warhammer.WhGenerationProps
+func (s *WhDbService) RetrieveMissingIds(ctx context.Context, t warhammer.WhType, userIds []string, sharedUserIds []string, whIds []string) ([]string, *d.DbError) {
+	if len(whIds) == 0 {
+		return []string{}, nil
+	}
+
+	ids, err := idsQuery(whIds)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
 
-	err := s.Collections[warhammer.WhTypeOther].FindOne(ctx, filter).Decode(&genProps)
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	filter := bson.M{"$and": bson.A{ids, allAllowedOwnersQuery(userIds, sharedUserIds), notDeletedQuery()}}
+	cur, err := s.Collections[t].Find(ctx, filter, opts)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, d.CreateDbError(d.DbNotFoundError, err)
-		} else {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	found := map[string]bool{}
+	for cur.Next(ctx) {
+		var doc struct {
+			Id primitive.ObjectID `bson:"_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
 			return nil, d.CreateDbError(d.DbInternalError, err)
 		}
+		found[doc.Id.Hex()] = true
 	}
 
-	return &genProps, nil
-}
-
-func (s *WhDbService) CreateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *d.DbError) {
-	_, err := s.Collections[warhammer.WhTypeOther].InsertOne(ctx, gp)
-	if err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+	missing := make([]string, 0)
+	for _, v := range whIds {
+		if !found[v] {
+			missing = append(missing, v)
 		}
-		return nil, d.CreateDbError(d.DbWriteToDbError, err)
 	}
 
-	return gp, nil
+	return missing, nil
 }
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *WhDbService) RetrieveEnums(ctx context.Context) ([]*warhammer.WhEnum, *d.DbError) {
+	opts := options.Find().SetSort(bson.M{"name": 1})
+	cur, err := s.EnumCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	enums := make([]*warhammer.WhEnum, 0)
+	for cur.Next(ctx) {
+		var e warhammer.WhEnum
+		if err = cur.Decode(&e); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		enums = append(enums, e.PointToCopy())
+	}
+
+	return enums, nil
+}
+
+// UpsertEnum stores e, which has to be exactly one version ahead of the stored enumeration. Version 1 creates a new
+// enumeration.
+func (s *WhDbService) UpsertEnum(ctx context.Context, e *warhammer.WhEnum) (*warhammer.WhEnum, *d.DbError) {
+	if e.Version == 1 {
+		count, err := s.EnumCollection.CountDocuments(ctx, bson.M{"name": e.Name})
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+
+		if count > 0 {
+			return nil, d.CreateDbError(d.DbConflictError, errors.New("enum version mismatch"))
+		}
+
+		if _, err = s.EnumCollection.InsertOne(ctx, e); err != nil {
+			return nil, d.CreateDbError(d.DbWriteToDbError, err)
+		}
+
+		return e.PointToCopy(), nil
+	}
+
+	filter := bson.M{"$and": bson.A{bson.M{"name": e.Name}, versionQuery(e.Version - 1)}}
+	result, err := s.EnumCollection.ReplaceOne(ctx, filter, e)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, d.CreateDbError(d.DbConflictError, errors.New("enum version mismatch"))
+	}
+
+	return e.PointToCopy(), nil
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/exp/slices"
+)
+
+// generationPropsQuery treats the rule set stored before owners were introduced as owned by admin.
+func generationPropsQuery(ownerId string, name string) bson.M {
+	if ownerId == "admin" {
+		owner := bson.M{"$or": bson.A{bson.M{"ownerid": "admin"}, bson.M{"ownerid": bson.M{"$exists": false}}}}
+		return bson.M{"$and": bson.A{bson.M{"name": name}, owner}}
+	}
+	return bson.M{"name": name, "ownerid": ownerId}
+}
+
+func decodeGenerationProps(decode func(any) error) (*warhammer.WhGenerationProps, error) {
+	var gp warhammer.WhGenerationProps
+	if err := decode(&gp); err != nil {
+		return nil, err
+	}
+
+	if gp.OwnerId == "" {
+		gp.OwnerId = "admin"
+	}
+
+	return gp.PointToCopy(), nil
+}
+
+func (s *WhDbService) RetrieveGenerationProps(ctx context.Context, ownerId string, name string) (*warhammer.WhGenerationProps, *d.DbError) {
+	gp, err := decodeGenerationProps(s.Collections[warhammer.WhTypeOther].FindOne(ctx, generationPropsQuery(ownerId, name)).Decode)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return gp, nil
+}
+
+func (s *WhDbService) RetrieveGenerationPropsList(ctx context.Context, userIds []string, sharedUserIds []string) ([]*warhammer.WhGenerationProps, *d.DbError) {
+	owners := allAllowedOwnersQuery(userIds, sharedUserIds)
+	if slices.Contains(userIds, "admin") {
+		owners = bson.M{"$or": bson.A{owners, bson.M{"ownerid": bson.M{"$exists": false}}}}
+	}
+
+	cur, err := s.Collections[warhammer.WhTypeOther].Find(ctx, owners)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	list := make([]*warhammer.WhGenerationProps, 0)
+	for cur.Next(ctx) {
+		gp, err := decodeGenerationProps(cur.Decode)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		list = append(list, gp)
+	}
+
+	return list, nil
+}
+
+func (s *WhDbService) CreateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *d.DbError) {
+	count, err := s.Collections[warhammer.WhTypeOther].CountDocuments(ctx, generationPropsQuery(gp.OwnerId, gp.Name))
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if count > 0 {
+		return nil, d.CreateDbError(d.DbAlreadyExistsError, errors.New("generationProps already exists"))
+	}
+
+	if _, err = s.Collections[warhammer.WhTypeOther].InsertOne(ctx, gp); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return gp, nil
+}
+
+// UpdateGenerationProps replaces current generation properties with gp, which has to be exactly one version ahead,
+// and moves the replaced version to history.
+func (s *WhDbService) UpdateGenerationProps(ctx context.Context, gp *warhammer.WhGenerationProps) (*warhammer.WhGenerationProps, *d.DbError) {
+	current, dbErr := s.RetrieveGenerationProps(ctx, gp.OwnerId, gp.Name)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if current.Version != gp.Version-1 {
+		return nil, d.CreateDbError(d.DbConflictError, errors.New("generationProps version mismatch"))
+	}
+
+	filter := bson.M{"$and": bson.A{generationPropsQuery(gp.OwnerId, gp.Name), versionQuery(current.Version)}}
+	result, err := s.Collections[warhammer.WhTypeOther].ReplaceOne(ctx, filter, gp)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, d.CreateDbError(d.DbConflictError, errors.New("generationProps version mismatch"))
+	}
+
+	if _, err = s.GenerationHistoryCollection.InsertOne(ctx, current); err != nil {
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return gp.PointToCopy(), nil
+}
+
+func (s *WhDbService) DeleteGenerationProps(ctx context.Context, ownerId string, name string) *d.DbError {
+	result, err := s.Collections[warhammer.WhTypeOther].DeleteOne(ctx, generationPropsQuery(ownerId, name))
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if result.DeletedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, errors.New("generationProps not found"))
+	}
+
+	if _, err = s.GenerationHistoryCollection.DeleteMany(ctx, generationPropsQuery(ownerId, name)); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *WhDbService) RetrieveGenerationPropsHistory(ctx context.Context, ownerId string, name string) ([]*warhammer.WhGenerationProps, *d.DbError) {
+	opts := options.Find().SetSort(bson.D{{"version", -1}})
+
+	cur, err := s.GenerationHistoryCollection.Find(ctx, generationPropsQuery(ownerId, name), opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	history := make([]*warhammer.WhGenerationProps, 0)
+	for cur.Next(ctx) {
+		gp, err := decodeGenerationProps(cur.Decode)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		history = append(history, gp)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+const revisionCollectionName = "revision"
+
+type WhRevisionDbService struct {
+	Db         *DbService
+	Collection *mongo.Collection
+}
+
+func NewWhRevisionDbService(db *DbService, createIndex bool) *WhRevisionDbService {
+	coll := db.Client.Database(db.DbName).Collection(revisionCollectionName)
+
+	if createIndex {
+		unique := true
+		mod := mongo.IndexModel{
+			Keys:    bson.D{{"whType", 1}, {"whId", 1}, {"rev", -1}},
+			Options: &options.IndexOptions{Unique: &unique},
+		}
+		if _, err := coll.Indexes().CreateOne(context.TODO(), mod); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return &WhRevisionDbService{Db: db, Collection: coll}
+}
+
+func (s *WhRevisionDbService) Create(ctx context.Context, r *warhammer.WhRevision) (*warhammer.WhRevision, *d.DbError) {
+	doc := bson.M{
+		"whType":     string(r.WhType),
+		"whId":       r.WhId,
+		"rev":        r.Rev,
+		"ownerId":    r.OwnerId,
+		"replacedOn": r.ReplacedOn,
+		"replacedBy": r.ReplacedBy,
+		"object":     r.Object,
+	}
+
+	if _, err := s.Collection.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, d.CreateDbError(d.DbAlreadyExistsError, err)
+		}
+		return nil, d.CreateDbError(d.DbWriteToDbError, err)
+	}
+
+	return r.PointToCopy(), nil
+}
+
+func bsonMToRevision(rMap bson.M, t warhammer.WhType) (*warhammer.WhRevision, error) {
+	whId, ok := rMap["whId"].(string)
+	if !ok {
+		return nil, errors.New("invalid wh id")
+	}
+
+	var rev int
+	switch v := rMap["rev"].(type) {
+	case int32:
+		rev = int(v)
+	case int64:
+		rev = int(v)
+	default:
+		return nil, errors.New("invalid revision number")
+	}
+
+	replacedOn, ok := rMap["replacedOn"].(primitive.DateTime)
+	if !ok {
+		return nil, errors.New("invalid replaced on")
+	}
+
+	ownerId, _ := rMap["ownerId"].(string)
+	replacedBy, _ := rMap["replacedBy"].(string)
+
+	wh, err := warhammer.NewApiWh(t)
+	if err != nil {
+		return nil, err
+	}
+
+	bsonRaw, err := bson.Marshal(rMap["object"])
+	if err != nil {
+		return nil, errors.New("error marshaling object")
+	}
+
+	if err = bson.Unmarshal(bsonRaw, wh.Object); err != nil {
+		return nil, errors.New("error unmarshalling object")
+	}
+
+	return &warhammer.WhRevision{
+		WhType:     t,
+		WhId:       whId,
+		Rev:        rev,
+		OwnerId:    ownerId,
+		ReplacedOn: replacedOn.Time(),
+		ReplacedBy: replacedBy,
+		Object:     wh.Object,
+	}, nil
+}
+
+func (s *WhRevisionDbService) Retrieve(ctx context.Context, t warhammer.WhType, whId string) ([]*warhammer.WhRevision, *d.DbError) {
+	filter := bson.M{"whType": string(t), "whId": whId}
+	opts := options.Find().SetSort(bson.D{{"rev", -1}})
+
+	cur, err := s.Collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+	defer cur.Close(ctx)
+
+	revisions := make([]*warhammer.WhRevision, 0)
+	for cur.Next(ctx) {
+		var rMap bson.M
+		if err := cur.Decode(&rMap); err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+
+		r, err := bsonMToRevision(rMap, t)
+		if err != nil {
+			return nil, d.CreateDbError(d.DbInternalError, err)
+		}
+		revisions = append(revisions, r)
+	}
+
+	return revisions, nil
+}
+
+func (s *WhRevisionDbService) RetrieveOne(ctx context.Context, t warhammer.WhType, whId string, rev int) (*warhammer.WhRevision, *d.DbError) {
+	filter := bson.M{"whType": string(t), "whId": whId, "rev": rev}
+
+	var rMap bson.M
+	if err := s.Collection.FindOne(ctx, filter).Decode(&rMap); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, d.CreateDbError(d.DbNotFoundError, err)
+		}
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	r, err := bsonMToRevision(rMap, t)
+	if err != nil {
+		return nil, d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return r, nil
+}
+
+func (s *WhRevisionDbService) Delete(ctx context.Context, t warhammer.WhType, whId string, revs []int) *d.DbError {
+	if len(revs) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"whType": string(t), "whId": whId, "rev": bson.M{"$in": revs}}
+	if _, err := s.Collection.DeleteMany(ctx, filter); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
+
+func (s *WhRevisionDbService) DeleteAll(ctx context.Context, t warhammer.WhType, whIds []string) *d.DbError {
+	if len(whIds) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"whType": string(t), "whId": bson.M{"$in": whIds}}
+	if _, err := s.Collection.DeleteMany(ctx, filter); err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	d "github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// UpdateTranslation stores the translation of an object owned by ownerId for locale. An empty translation removes it.
+func (s *WhDbService) UpdateTranslation(ctx context.Context, t warhammer.WhType, whId string, ownerId string, locale string, tr warhammer.WhTranslation, userId string) *d.DbError {
+	id, err := primitive.ObjectIDFromHex(whId)
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	field := "translations." + locale
+	set := bson.M{"updatedat": time.Now().UTC(), "lastmodifiedby": userId}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if tr.IsEmpty() {
+		update["$unset"] = bson.M{field: ""}
+	} else {
+		set[field] = tr
+	}
+
+	filter := bson.M{"$and": bson.A{bson.M{"_id": id}, bson.M{"ownerid": ownerId}, notDeletedQuery()}}
+	result, err := s.Collections[t].UpdateOne(ctx, filter, update)
+	if err != nil {
+		return d.CreateDbError(d.DbInternalError, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return d.CreateDbError(d.DbNotFoundError, errors.New("wh not found"))
+	}
+
+	return nil
+}
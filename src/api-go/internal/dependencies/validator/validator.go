@@ -3,43 +3,74 @@ package validator
 import (
 	v "github.com/go-playground/validator/v10"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"reflect"
+	"strconv"
 )
 
-func NewValidator() *v.Validate {
+func NewValidator(enums *warhammer.WhEnumRegistry) *v.Validate {
 	validate := v.New()
-	configure(validate)
+	configure(validate, enums)
 	return validate
 }
 
-func configure(v *v.Validate) {
-	for k, r := range warhammer.GetWhCommonValidationAliases() {
+func configure(v *v.Validate, enums *warhammer.WhEnumRegistry) {
+	if err := v.RegisterValidation("wh_enum", whEnumValidation(enums)); err != nil {
+		panic(err)
+	}
+	for k, r := range Aliases() {
 		v.RegisterAlias(k, r)
 	}
+}
+
+// Aliases returns validation aliases of all domain types.
+func Aliases() map[string]string {
+	aliases := map[string]string{}
+	for k, r := range warhammer.GetWhCommonValidationAliases() {
+		aliases[k] = r
+	}
 	for k, r := range warhammer.GetWhSourceValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhAttributeValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhMutationValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhPropertyValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhItemValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhSkillValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhCareerValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhCharacterValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
+	}
+	for k, r := range warhammer.GetWhSpeciesValidationAliases() {
+		aliases[k] = r
 	}
 	for k, r := range warhammer.GetWhGenerationPropsValidationAliases() {
-		v.RegisterAlias(k, r)
+		aliases[k] = r
+	}
+	return aliases
+}
+
+func whEnumValidation(enums *warhammer.WhEnumRegistry) v.Func {
+	return func(fl v.FieldLevel) bool {
+		field := fl.Field()
+		switch field.Kind() {
+		case reflect.String:
+			return enums.Contains(fl.Param(), field.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return enums.Contains(fl.Param(), strconv.FormatInt(field.Int(), 10))
+		default:
+			return false
+		}
 	}
 }
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"strings"
+	"time"
+)
+
+const (
+	EventTypeCreate            = "create"
+	EventTypeUpdate            = "update"
+	EventTypeDelete            = "delete"
+	EventTypeRestore           = "restore"
+	EventTypeUpdateCredentials = "update_credentials"
+	EventTypeUpdateClaims      = "update_claims"
+	EventTypeResetPassword     = "reset_password"
+	EventTypeUpdateTotp        = "update_totp"
+	EventTypeVerifyEmail       = "verify_email"
+	EventTypeHide              = "hide"
+	EventTypeUnhide            = "unhide"
+)
+
+const ObjectTypeUser = "user"
+
+type Event struct {
+	Id         string
+	ActorId    string
+	Timestamp  time.Time
+	Type       string
+	ObjectType string
+	ObjectId   string
+	OwnerId    string
+	Changes    []domain.FieldChange
+}
+
+func (e Event) Copy() Event {
+	changes := make([]domain.FieldChange, len(e.Changes))
+	copy(changes, e.Changes)
+
+	return Event{
+		Id:         strings.Clone(e.Id),
+		ActorId:    strings.Clone(e.ActorId),
+		Timestamp:  e.Timestamp.UTC(),
+		Type:       strings.Clone(e.Type),
+		ObjectType: strings.Clone(e.ObjectType),
+		ObjectId:   strings.Clone(e.ObjectId),
+		OwnerId:    strings.Clone(e.OwnerId),
+		Changes:    changes,
+	}
+}
+
+func (e Event) PointToCopy() *Event {
+	cpy := e.Copy()
+	return &cpy
+}
+
+type Query struct {
+	ActorId    string
+	ObjectType string
+	ObjectId   string
+	OwnerId    string
+	Since      time.Time
+	Limit      int
+}
+
+func (q Query) Matches(e *Event) bool {
+	if q.ActorId != "" && q.ActorId != e.ActorId {
+		return false
+	}
+	if q.ObjectType != "" && q.ObjectType != e.ObjectType {
+		return false
+	}
+	if q.ObjectId != "" && q.ObjectId != e.ObjectId {
+		return false
+	}
+	if q.OwnerId != "" && q.OwnerId != e.OwnerId {
+		return false
+	}
+	if !q.Since.IsZero() && e.Timestamp.Before(q.Since) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,22 @@
+package audit
+
+import "fmt"
+
+const (
+	AuditInvalidArgumentsError = iota
+	AuditInternalError
+	AuditUnauthorizedError
+)
+
+type AuditError struct {
+	Type int
+	Err  error
+}
+
+func (e *AuditError) Unwrap() error {
+	return e.Err
+}
+
+func (e *AuditError) Error() string {
+	return fmt.Sprintf("audit error, %s", e.Err)
+}
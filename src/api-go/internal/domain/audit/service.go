@@ -0,0 +1,16 @@
+package audit
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, e *Event) *AuditError
+	Get(ctx context.Context, c *domain.Claims, q *Query) ([]*Event, *AuditError)
+}
+
+type AuditDbService interface {
+	Create(ctx context.Context, e *Event) (*Event, *domain.DbError)
+	Retrieve(ctx context.Context, q *Query) ([]*Event, *domain.DbError)
+}
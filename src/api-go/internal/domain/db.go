@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const (
 	DbNotFoundError = iota
@@ -9,6 +12,7 @@ const (
 	DbInvalidUserFieldError
 	DbNotImplementedError
 	DbWriteToDbError
+	DbConflictError
 )
 
 type DbError struct {
@@ -30,3 +34,9 @@ func CreateDbError(t int, e error) *DbError {
 		Err:  e,
 	}
 }
+
+// TxService runs fn in a single transaction where the database supports it. Db services called from fn have to be
+// given the context passed to fn to take part in the transaction.
+type TxService interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) *DbError) *DbError
+}
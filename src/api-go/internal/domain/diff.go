@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Diff compares JSON representations of before and after and returns changed leaf fields as dotted paths. Arrays
+// are compared as a whole. A nil before or after means the object did not exist.
+func Diff(before any, after any) ([]FieldChange, error) {
+	beforeFlat, err := flattenJson(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFlat, err := flattenJson(after)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(beforeFlat)+len(afterFlat))
+	for k := range beforeFlat {
+		fields = append(fields, k)
+	}
+	for k := range afterFlat {
+		if _, ok := beforeFlat[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]FieldChange, 0)
+	for _, f := range fields {
+		oldValue := beforeFlat[f]
+		newValue := afterFlat[f]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: f, Old: oldValue, New: newValue})
+		}
+	}
+
+	return changes, nil
+}
+
+func flattenJson(v any) (map[string]any, error) {
+	flat := map[string]any{}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return flat, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err = json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	flattenInto(flat, "", generic)
+	return flat, nil
+}
+
+func flattenInto(flat map[string]any, prefix string, v any) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		flat[prefix] = v
+		return
+	}
+
+	for k, child := range m {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		flattenInto(flat, path, child)
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type GraphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphqlService executes queries and returns the result in the standard GraphQL response shape, i.e. with data and
+// errors keys. Errors of individual fields do not fail the whole query.
+type GraphqlService interface {
+	Execute(ctx context.Context, r *GraphqlRequest, c *Claims) any
+}
@@ -1,22 +1,97 @@
 package domain
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ScopeRead     = "read"
+	ScopeWrite    = "write"
+	ScopeWildcard = "*"
 )
 
 type Claims struct {
 	Id             string
-	Admin          bool
+	Roles          []string
 	SharedAccounts []string
 	ResetPassword  bool
+	VerifyEmail    string
+	Locale         string
+	SessionId      string
+	// Scopes restrict what the claims allow, each is a resource followed by ":read" or ":write". Nil means the claims
+	// are not restricted, which is the case for users signed in with a password.
+	Scopes []string
+	// ExpiresAt is the expiry of the token the claims were read from, zero means the token does not expire.
+	ExpiresAt time.Time
+}
+
+// Allows reports whether the claims grant read or write access to resource. Write access implies read access.
+func (c *Claims) Allows(resource string, write bool) bool {
+	if c.Scopes == nil {
+		return true
+	}
+
+	for _, scope := range c.Scopes {
+		scopeResource, access, _ := strings.Cut(scope, ":")
+		if scopeResource != resource && scopeResource != ScopeWildcard {
+			continue
+		}
+		if access == ScopeWrite || (access == ScopeRead && !write) {
+			return true
+		}
+	}
+
+	return false
 }
 
 type JwtService interface {
 	GenerateAccessToken(claims *Claims) (string, error)
 	GenerateResetPasswordToken(claims *Claims) (string, error)
+	GenerateVerifyEmailToken(claims *Claims) (string, error)
 	ParseToken(token string) (*Claims, error)
 }
 
+// TokenRevocationChecker is implemented by JwtServices that keep server-side sessions. Tokens of revoked sessions are
+// rejected even though their signature and expiry are valid.
+type TokenRevocationChecker interface {
+	IsRevoked(ctx context.Context, c *Claims) bool
+}
+
+// JwtKey is a private signing key of a key ring. Keys are kept in the database so that all instances of the api sign
+// and verify with the same ring.
+type JwtKey struct {
+	Id         string
+	Algorithm  string
+	PrivateKey []byte // PKCS #8, DER encoded
+	CreatedAt  time.Time
+}
+
+type JwtKeyDbService interface {
+	Create(ctx context.Context, key *JwtKey) *DbError
+	RetrieveAll(ctx context.Context, algorithm string) ([]*JwtKey, *DbError)
+	Delete(ctx context.Context, ids []string) *DbError
+}
+
+// Jwk is a public key in JSON Web Key format (RFC 7517).
+type Jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JwksProvider publishes the public keys that tokens can be verified with.
+type JwksProvider interface {
+	PublicKeys() []Jwk
+}
+
 type InvalidTokenError struct {
 	Err error
 }
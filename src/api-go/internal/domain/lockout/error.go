@@ -0,0 +1,20 @@
+package lockout
+
+import "fmt"
+
+const (
+	LockoutInternalError = iota
+)
+
+type LockoutError struct {
+	Type int
+	Err  error
+}
+
+func (e *LockoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("lockout error, %s", e.Err)
+}
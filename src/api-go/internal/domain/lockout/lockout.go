@@ -0,0 +1,32 @@
+package lockout
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	AccountKeyPrefix = "account:"
+	AddressKeyPrefix = "address:"
+)
+
+// Attempts counts the consecutive failed sign-ins of a key, which is an account or a client address prefixed with
+// AccountKeyPrefix or AddressKeyPrefix. Accounts are tracked by the submitted username whether the user exists or not.
+type Attempts struct {
+	Key         string
+	Failures    int
+	LastFailure time.Time
+}
+
+func (a Attempts) Copy() Attempts {
+	return Attempts{
+		Key:         strings.Clone(a.Key),
+		Failures:    a.Failures,
+		LastFailure: a.LastFailure.UTC(),
+	}
+}
+
+func (a Attempts) PointToCopy() *Attempts {
+	aCopy := a.Copy()
+	return &aCopy
+}
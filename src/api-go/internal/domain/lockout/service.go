@@ -0,0 +1,37 @@
+package lockout
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"time"
+)
+
+// LockoutService slows down password guessing. Attempts of an account and of a client address are delayed with
+// exponential backoff after a number of failures and blocked altogether once the lockout threshold is reached.
+type LockoutService interface {
+	// Begin records a sign-in attempt of username from address as failed before the credentials are checked, so that
+	// parallel attempts can not all pass. It returns how long the attempt has to wait, such attempt is rejected and not
+	// counted. An empty username limits only the address. Attempts that do not fail are ended with Succeed or Release.
+	Begin(ctx context.Context, username string, address string) (time.Duration, *LockoutError)
+	// Fail confirms that the attempt failed, the owner of username is notified by email when the account gets locked.
+	Fail(ctx context.Context, username string, address string) *LockoutError
+	// Succeed forgets the failures of username and takes back the attempt of address, earlier failures of the address
+	// are kept until they expire.
+	Succeed(ctx context.Context, username string, address string) *LockoutError
+	// Release takes back an attempt that neither failed nor succeeded, for example because a TOTP code is missing.
+	Release(ctx context.Context, username string, address string) *LockoutError
+}
+
+// AttemptDbService is the store of failed attempts. It is shared by all instances of the api, otherwise each instance
+// would allow the full number of attempts.
+type AttemptDbService interface {
+	Retrieve(ctx context.Context, key string) (*Attempts, *domain.DbError)
+	// Increment atomically adds a failure at now and returns the attempts as they were before. Failures that happened
+	// before now minus window are forgotten first, they are not included in the returned attempts.
+	Increment(ctx context.Context, key string, now time.Time, window time.Duration) (*Attempts, *domain.DbError)
+	// Decrement atomically removes a failure, if there is any.
+	Decrement(ctx context.Context, key string) *domain.DbError
+	Delete(ctx context.Context, key string) *domain.DbError
+	// DeleteBefore removes attempts whose last failure happened before t.
+	DeleteBefore(ctx context.Context, t time.Time) *domain.DbError
+}
@@ -0,0 +1,5 @@
+package domain
+
+type MarkdownRenderer interface {
+	Render(markdown string) string
+}
@@ -0,0 +1,28 @@
+package oidc
+
+import "fmt"
+
+const (
+	OidcProviderNotFoundError = iota
+	OidcInvalidStateError
+	OidcInvalidArgumentsError
+	OidcAlreadyLinkedError
+	OidcNotFoundError
+	OidcUnauthorizedError
+	OidcTotpRequiredError
+	OidcIncorrectTotpError
+	OidcInternalError
+)
+
+type OidcError struct {
+	Type int
+	Err  error
+}
+
+func (e *OidcError) Unwrap() error {
+	return e.Err
+}
+
+func (e *OidcError) Error() string {
+	return fmt.Sprintf("oidc error, %s", e.Err)
+}
@@ -0,0 +1,63 @@
+package oidc
+
+import (
+	"strings"
+	"time"
+)
+
+// Identity is the user as asserted by an identity provider.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// Link connects an external identity to a Hammergen user. A user can have one link per provider.
+type Link struct {
+	Provider  string
+	Subject   string
+	UserId    string
+	Email     string
+	CreatedAt time.Time
+}
+
+func (l Link) Copy() Link {
+	return Link{
+		Provider:  strings.Clone(l.Provider),
+		Subject:   strings.Clone(l.Subject),
+		UserId:    strings.Clone(l.UserId),
+		Email:     strings.Clone(l.Email),
+		CreatedAt: l.CreatedAt.UTC(),
+	}
+}
+
+func (l Link) PointToCopy() *Link {
+	cpy := l.Copy()
+	return &cpy
+}
+
+// LoginState is kept between redirecting the user to the provider and exchanging the authorization code. It can be
+// used only once.
+type LoginState struct {
+	State        string
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+func (s LoginState) Copy() LoginState {
+	return LoginState{
+		State:        strings.Clone(s.State),
+		Provider:     strings.Clone(s.Provider),
+		CodeVerifier: strings.Clone(s.CodeVerifier),
+		Nonce:        strings.Clone(s.Nonce),
+		ExpiresAt:    s.ExpiresAt.UTC(),
+	}
+}
+
+func (s LoginState) PointToCopy() *LoginState {
+	cpy := s.Copy()
+	return &cpy
+}
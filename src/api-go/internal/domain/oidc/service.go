@@ -0,0 +1,42 @@
+package oidc
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+)
+
+// Provider performs the provider specific steps of the authorization code flow with PKCE.
+type Provider interface {
+	// AuthCodeUrl returns the url of the provider's login page. loginHint is optional.
+	AuthCodeUrl(ctx context.Context, state string, nonce string, codeChallenge string, loginHint string) (string, error)
+	// Exchange redeems the authorization code and returns the identity from the verified ID token.
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*Identity, error)
+}
+
+type OidcService interface {
+	Providers() []string
+	Authorize(ctx context.Context, provider string, loginHint string) (authUrl string, state string, oe *OidcError)
+	// Login finishes the flow started by Authorize and returns the user linked to the identity. On first login the
+	// identity is linked to the user with the same verified email, or a new user is created. Users with two-factor
+	// authentication also need totpCode.
+	Login(ctx context.Context, provider string, code string, state string, totpCode string) (*user.User, *OidcError)
+	// Link finishes the flow started by Authorize and links the identity to the user in c.
+	Link(ctx context.Context, c *domain.Claims, provider string, code string, state string) (*Link, *OidcError)
+	ListLinks(ctx context.Context, c *domain.Claims) ([]*Link, *OidcError)
+	Unlink(ctx context.Context, c *domain.Claims, provider string) *OidcError
+}
+
+type LinkDbService interface {
+	Create(ctx context.Context, l *Link) (*Link, *domain.DbError)
+	Retrieve(ctx context.Context, provider string, subject string) (*Link, *domain.DbError)
+	RetrieveByUser(ctx context.Context, userId string) ([]*Link, *domain.DbError)
+	Delete(ctx context.Context, userId string, provider string) *domain.DbError
+	DeleteByUser(ctx context.Context, userId string) *domain.DbError
+}
+
+type LoginStateDbService interface {
+	Create(ctx context.Context, s *LoginState) *domain.DbError
+	// Take retrieves and removes the state so that it can not be used again.
+	Take(ctx context.Context, state string) (*LoginState, *domain.DbError)
+}
@@ -0,0 +1,24 @@
+package pat
+
+import "fmt"
+
+const (
+	PatInvalidArgumentsError = iota
+	PatNotFoundError
+	PatUnauthorizedError
+	PatInvalidTokenError
+	PatInternalError
+)
+
+type PatError struct {
+	Type int
+	Err  error
+}
+
+func (e *PatError) Unwrap() error {
+	return e.Err
+}
+
+func (e *PatError) Error() string {
+	return fmt.Sprintf("personal access token error, %s", e.Err)
+}
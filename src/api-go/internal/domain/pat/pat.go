@@ -0,0 +1,66 @@
+package pat
+
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"strings"
+	"time"
+)
+
+// SecretPrefix marks personal access tokens, so that they can be told apart from JWTs without parsing them.
+const SecretPrefix = "hgp_"
+
+// ScopeGeneration is the resource of character generation rule sets, all other resources are warhammer types.
+const ScopeGeneration = "generation"
+
+// Scopes lists all scopes a token can be granted.
+func Scopes() []string {
+	resources := make([]string, 0, len(warhammer.WhApiTypes)+2)
+	for _, t := range warhammer.WhApiTypes {
+		resources = append(resources, string(t))
+	}
+	resources = append(resources, ScopeGeneration, domain.ScopeWildcard)
+
+	scopes := make([]string, 0, 2*len(resources))
+	for _, r := range resources {
+		scopes = append(scopes, r+":"+domain.ScopeRead, r+":"+domain.ScopeWrite)
+	}
+	return scopes
+}
+
+// Token is a long-lived credential of a user for bots and scripts. Only the hash of its secret is stored.
+type Token struct {
+	Id         string
+	UserId     string
+	Name       string
+	Scopes     []string
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time // zero means the token does not expire
+}
+
+func (t Token) Copy() Token {
+	scopes := make([]string, len(t.Scopes))
+	copy(scopes, t.Scopes)
+
+	return Token{
+		Id:         strings.Clone(t.Id),
+		UserId:     strings.Clone(t.UserId),
+		Name:       strings.Clone(t.Name),
+		Scopes:     scopes,
+		TokenHash:  strings.Clone(t.TokenHash),
+		CreatedAt:  t.CreatedAt.UTC(),
+		LastUsedAt: t.LastUsedAt.UTC(),
+		ExpiresAt:  t.ExpiresAt.UTC(),
+	}
+}
+
+func (t Token) PointToCopy() *Token {
+	cpy := t.Copy()
+	return &cpy
+}
+
+func (t Token) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
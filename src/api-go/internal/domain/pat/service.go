@@ -0,0 +1,25 @@
+package pat
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"time"
+)
+
+type PatService interface {
+	// Create returns the stored token together with its secret, which is not kept and can not be retrieved later.
+	Create(ctx context.Context, c *domain.Claims, t *Token) (*Token, string, *PatError)
+	List(ctx context.Context, c *domain.Claims) ([]*Token, *PatError)
+	Revoke(ctx context.Context, c *domain.Claims, id string) *PatError
+	// Authenticate returns the claims of the owner of secret restricted to the scopes of the token.
+	Authenticate(ctx context.Context, secret string) (*domain.Claims, *PatError)
+}
+
+type PatDbService interface {
+	Create(ctx context.Context, t *Token) (*Token, *domain.DbError)
+	RetrieveByHash(ctx context.Context, tokenHash string) (*Token, *domain.DbError)
+	RetrieveByUser(ctx context.Context, userId string) ([]*Token, *domain.DbError)
+	Touch(ctx context.Context, id string, lastUsedAt time.Time) *domain.DbError
+	Delete(ctx context.Context, userId string, id string) *domain.DbError
+	DeleteByUser(ctx context.Context, userId string) *domain.DbError
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "golang.org/x/exp/slices"
+
+const (
+	RoleAdmin     = "admin"
+	RoleEditor    = "editor"
+	RoleModerator = "moderator"
+)
+
+const (
+	// PermissionManageUsers allows reading other users, changing their roles and reading the audit log.
+	PermissionManageUsers = "manage_users"
+	// PermissionEditOfficial allows maintaining official content, which is owned by "admin" and visible to everyone.
+	PermissionEditOfficial = "edit_official"
+	// PermissionModerate allows hiding content that other users share with their linked accounts.
+	PermissionModerate = "moderate"
+)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin:     {PermissionManageUsers, PermissionEditOfficial, PermissionModerate},
+	RoleEditor:    {PermissionEditOfficial},
+	RoleModerator: {PermissionModerate},
+}
+
+// Roles lists all roles that can be granted to users.
+func Roles() []string {
+	return []string{RoleAdmin, RoleEditor, RoleModerator}
+}
+
+// Can reports whether any of the roles of the claims grants permission.
+func (c *Claims) Can(permission string) bool {
+	for _, role := range c.Roles {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package session
+
+import "fmt"
+
+const (
+	SessionInvalidTokenError = iota
+	SessionReuseDetectedError
+	SessionUnauthorizedError
+	SessionInternalError
+)
+
+type SessionError struct {
+	Type int
+	Err  error
+}
+
+func (e *SessionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SessionError) Error() string {
+	return fmt.Sprintf("session error, %s", e.Err)
+}
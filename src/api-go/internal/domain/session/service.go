@@ -0,0 +1,24 @@
+package session
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"time"
+)
+
+type SessionService interface {
+	Create(ctx context.Context, c *domain.Claims) (*Tokens, *SessionError)
+	Refresh(ctx context.Context, refreshToken string) (*Tokens, *SessionError)
+	Revoke(ctx context.Context, c *domain.Claims, refreshToken string) *SessionError
+}
+
+type SessionDbService interface {
+	Create(ctx context.Context, s *Session) (*Session, *domain.DbError)
+	Retrieve(ctx context.Context, id string) (*Session, *domain.DbError)
+	// Rotate replaces the token hash only if it still equals oldHash and returns DbConflictError otherwise, so that a
+	// refresh token can be exchanged only once even by concurrent requests. oldHash is added to the last
+	// MaxUsedTokenHashes used hashes.
+	Rotate(ctx context.Context, id string, oldHash string, newHash string, refreshedAt time.Time, expiresAt time.Time) (*Session, *domain.DbError)
+	Revoke(ctx context.Context, id string, revokedAt time.Time, reason string) *domain.DbError
+	DeleteByUser(ctx context.Context, userId string) *domain.DbError
+}
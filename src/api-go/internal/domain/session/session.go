@@ -0,0 +1,61 @@
+package session
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxUsedTokenHashes limits how many exchanged tokens are remembered per session, replays of older ones are rejected
+// as invalid without revoking the session.
+const MaxUsedTokenHashes = 100
+
+const (
+	RevokedReasonLogout = "logout"
+	RevokedReasonReuse  = "reuse"
+)
+
+// Session is a family of refresh tokens issued from a single login. Only the latest token can be exchanged, hashes of
+// tokens that were already exchanged are kept to detect replays.
+type Session struct {
+	Id              string
+	UserId          string
+	TokenHash       string
+	UsedTokenHashes []string
+	CreatedAt       time.Time
+	RefreshedAt     time.Time
+	ExpiresAt       time.Time
+	RevokedAt       time.Time
+	RevokedReason   string
+}
+
+func (s Session) Copy() Session {
+	usedTokenHashes := make([]string, len(s.UsedTokenHashes))
+	copy(usedTokenHashes, s.UsedTokenHashes)
+
+	return Session{
+		Id:              strings.Clone(s.Id),
+		UserId:          strings.Clone(s.UserId),
+		TokenHash:       strings.Clone(s.TokenHash),
+		UsedTokenHashes: usedTokenHashes,
+		CreatedAt:       s.CreatedAt.UTC(),
+		RefreshedAt:     s.RefreshedAt.UTC(),
+		ExpiresAt:       s.ExpiresAt.UTC(),
+		RevokedAt:       s.RevokedAt.UTC(),
+		RevokedReason:   strings.Clone(s.RevokedReason),
+	}
+}
+
+func (s Session) PointToCopy() *Session {
+	cpy := s.Copy()
+	return &cpy
+}
+
+func (s Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
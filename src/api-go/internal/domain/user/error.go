@@ -10,6 +10,9 @@ const (
 	UserInvalidArgumentsError
 	UserSendEmailError
 	UserUnauthorizedError
+	UserTotpRequiredError
+	UserIncorrectTotpError
+	UserEmailNotVerifiedError
 )
 
 type UserError struct {
@@ -0,0 +1,16 @@
+package user
+
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"time"
+)
+
+// Export is a complete archive of a user's profile and content. Trash holds deleted objects that have not been purged
+// yet.
+type Export struct {
+	User            *User
+	Wh              map[warhammer.WhType][]*warhammer.Wh
+	Trash           map[warhammer.WhType][]*warhammer.Wh
+	GenerationProps []*warhammer.WhGenerationProps
+	ExportedOn      time.Time
+}
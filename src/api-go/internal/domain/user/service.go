@@ -11,10 +11,26 @@ type UserService interface {
 	Create(ctx context.Context, u *User) (*User, *UserError)
 	Update(ctx context.Context, c *domain.Claims, u *User) (*User, *UserError)
 	UpdateCredentials(ctx context.Context, c *domain.Claims, currentPasswd string, u *User) (*User, *UserError)
-	UpdateClaims(ctx context.Context, c *domain.Claims, u *User) (*User, *UserError)
+	// UpdateClaims sets the roles of u unless they are nil and the TOTP requirement unless totpRequired is nil.
+	UpdateClaims(ctx context.Context, c *domain.Claims, u *User, totpRequired *bool) (*User, *UserError)
+	// Delete removes the user together with the user's content, webhooks and links of other users to the user.
 	Delete(ctx context.Context, c *domain.Claims, id string) *UserError
+	Export(ctx context.Context, c *domain.Claims, id string) (*Export, *UserError)
 	List(ctx context.Context, c *domain.Claims) ([]*User, *UserError)
-	Authenticate(ctx context.Context, username string, password string) (u *User, ue *UserError)
+	// Authenticate checks the password and, if the user enabled it, the TOTP or recovery code in totpCode.
+	Authenticate(ctx context.Context, username string, password string, totpCode string) (u *User, ue *UserError)
+	// AuthenticateTotp applies the second factor check of Authenticate to a user who signed in by other means.
+	AuthenticateTotp(ctx context.Context, u *User, totpCode string) (*User, *UserError)
+	EnrollTotp(ctx context.Context, c *domain.Claims) (*TotpEnrollment, *UserError)
+	// ConfirmTotp enables TOTP after the first valid code and returns new recovery codes, they are not stored in plain.
+	ConfirmTotp(ctx context.Context, c *domain.Claims, totpCode string) ([]string, *UserError)
+	// DisableTotp requires a valid code unless an admin disables TOTP of another user.
+	DisableTotp(ctx context.Context, c *domain.Claims, id string, totpCode string) *UserError
+	// SendVerifyEmail sends a new confirmation link to the pending username or, if there is none, to the unverified
+	// username.
+	SendVerifyEmail(ctx context.Context, c *domain.Claims) *UserError
+	// VerifyEmail confirms the address in token, a pending username replaces the current one.
+	VerifyEmail(ctx context.Context, token string) (*User, *UserError)
 	SendResetPassword(ctx context.Context, username string) *UserError
 	ResetPassword(ctx context.Context, token string, newPassword string) *UserError
 }
@@ -24,5 +40,6 @@ type UserDbService interface {
 	Update(ctx context.Context, user *User) (*User, *domain.DbError)
 	Retrieve(ctx context.Context, fieldName string, fieldValue string) (*User, *domain.DbError)
 	RetrieveAll(ctx context.Context) ([]*User, *domain.DbError)
+	// Delete removes the user and the user's id from shared accounts of other users.
 	Delete(ctx context.Context, id string) *domain.DbError
 }
@@ -5,23 +5,71 @@ import (
 	"time"
 )
 
+// User is identified by Username, which is an email address. EmailVerified is set once the address is confirmed with
+// a link sent to it. A new username is kept in PendingUsername until the new address is confirmed. Roles grant
+// permissions beyond managing the user's own account and content, see domain.Roles.
 type User struct {
 	Id                 string
 	Username           string
-	Admin              bool
+	EmailVerified      bool
+	PendingUsername    string
+	Roles              []string
 	SharedAccountNames []string
 	SharedAccountIds   []string
+	Locale             string
 	Password           string
 	PasswordHash       []byte
 	CreatedOn          time.Time
 	LastAuthOn         time.Time
+	Totp               Totp
+}
+
+// Totp holds the time-based one-time password second factor. Secret is set on enrollment, but codes are required only
+// once the enrollment is confirmed and Enabled is set. Required is set by admins, roles of a user are withheld until
+// Totp is enabled.
+type Totp struct {
+	Secret             string
+	Enabled            bool
+	Required           bool
+	LastStep           int64
+	RecoveryCodeHashes []string
+}
+
+func (t Totp) Copy() Totp {
+	var recoveryCodeHashes []string
+	if t.RecoveryCodeHashes != nil {
+		recoveryCodeHashes = make([]string, len(t.RecoveryCodeHashes))
+		copy(recoveryCodeHashes, t.RecoveryCodeHashes)
+	}
+
+	return Totp{
+		Secret:             strings.Clone(t.Secret),
+		Enabled:            t.Enabled,
+		Required:           t.Required,
+		LastStep:           t.LastStep,
+		RecoveryCodeHashes: recoveryCodeHashes,
+	}
+}
+
+// TotpEnrollment is the secret of a new enrollment together with its otpauth URI, which authenticator apps read from
+// a QR code.
+type TotpEnrollment struct {
+	Secret string
+	Uri    string
 }
 
 func (u User) Copy() User {
 	uCopy := User{}
 	uCopy.Id = strings.Clone(u.Id)
 	uCopy.Username = strings.Clone(u.Username)
-	uCopy.Admin = u.Admin
+	uCopy.EmailVerified = u.EmailVerified
+	uCopy.PendingUsername = strings.Clone(u.PendingUsername)
+	if u.Roles != nil {
+		uCopy.Roles = make([]string, len(u.Roles))
+		copy(uCopy.Roles, u.Roles)
+	} else {
+		uCopy.Roles = nil
+	}
 
 	if u.SharedAccountNames != nil {
 		uCopy.SharedAccountNames = make([]string, len(u.SharedAccountNames))
@@ -37,6 +85,7 @@ func (u User) Copy() User {
 		uCopy.SharedAccountIds = nil
 	}
 
+	uCopy.Locale = strings.Clone(u.Locale)
 	uCopy.Password = strings.Clone(u.Password)
 
 	if u.PasswordHash != nil {
@@ -46,6 +95,7 @@ func (u User) Copy() User {
 
 	uCopy.LastAuthOn = u.LastAuthOn.UTC()
 	uCopy.CreatedOn = u.CreatedOn.UTC()
+	uCopy.Totp = u.Totp.Copy()
 
 	return uCopy
 }
@@ -57,8 +107,17 @@ func (u User) PointToCopy() *User {
 
 func EmptyUser() User {
 	return User{
+		Roles:              make([]string, 0),
 		SharedAccountNames: make([]string, 0),
 		SharedAccountIds:   make([]string, 0),
 		PasswordHash:       make([]byte, 0),
 	}
 }
+
+// EffectiveRoles are the roles carried in the user's tokens, none while required TOTP is not enabled yet.
+func (u User) EffectiveRoles() []string {
+	if u.Totp.Required && !u.Totp.Enabled {
+		return []string{}
+	}
+	return u.Roles
+}
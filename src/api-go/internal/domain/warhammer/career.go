@@ -1,6 +1,7 @@
 package warhammer
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -87,25 +88,13 @@ const (
 	WhCareerClassSeafarer  = 8
 )
 
-func classValues() string {
-	return formatIntegerValues([]WhCareerClass{
-		WhCareerClassAcademic,
-		WhCareerClassBurghers,
-		WhCareerClassCourtier,
-		WhCareerClassPeasant,
-		WhCareerClassRanger,
-		WhCareerClassRiverfolk,
-		WhCareerClassRouge,
-		WhCareerClassWarrior,
-		WhCareerClassSeafarer,
-	})
-}
-
 func (input WhCareerClass) InitAndCopy() WhCareerClass {
 	return input
 }
 
-type WhCareerSpecies int
+// WhCareerSpecies is an id of a species object. Legacy integer values are still accepted and mapped to ids of
+// built-in species by SpeciesIdFromCareerSpecies.
+type WhCareerSpecies string
 
 const (
 	WhCareerSpeciesHuman    = 0
@@ -117,26 +106,35 @@ const (
 	WhCareerSpeciesOgre     = 6
 )
 
-func careerSpeciesValues() string {
-	return formatIntegerValues([]WhCareerSpecies{
-		WhCareerSpeciesHuman,
-		WhCareerSpeciesHalfling,
-		WhCareerSpeciesDwarf,
-		WhCareerSpeciesHighElf,
-		WhCareerSpeciesWoodElf,
-		WhCareerClassRiverfolk,
-		WhCareerSpeciesGnome,
-		WhCareerSpeciesOgre,
-	})
+func (input WhCareerSpecies) InitAndCopy() WhCareerSpecies {
+	if input == "" {
+		return WhCareerSpecies(SpeciesIdFromCode(WhCharacterSpeciesHumanDefault))
+	}
+	return WhCareerSpecies(SpeciesIdFromCode(strings.Clone(string(input))))
 }
 
-func (input WhCareerSpecies) InitAndCopy() WhCareerSpecies {
-	return input
+func (input *WhCareerSpecies) UnmarshalJSON(data []byte) error {
+	var legacy int
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		id, ok := SpeciesIdFromCareerSpecies(legacy)
+		if !ok {
+			return fmt.Errorf("invalid career species %d", legacy)
+		}
+		*input = WhCareerSpecies(id)
+		return nil
+	}
+
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	*input = WhCareerSpecies(id)
+	return nil
 }
 
 type WhCareer struct {
 	Name        string          `json:"name" validate:"name_valid"`
-	Description string          `json:"description" validate:"desc_valid"`
+	Description string          `json:"description" validate:"rich_text_valid"`
 	Class       WhCareerClass   `json:"class" validate:"class_valid"`
 	Species     WhCareerSpecies `json:"species" validate:"career_species_valid"`
 	Level1      WhCareerLevel   `json:"level1"`
@@ -166,11 +164,29 @@ func (c WhCareer) InitAndCopy() WhObject {
 	}
 }
 
+func (c WhCareer) MapRichText(f func(string) string) WhObject {
+	mapped := c.InitAndCopy().(WhCareer)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (c WhCareer) Localize(tr WhTranslation) WhObject {
+	localized := c.InitAndCopy().(WhCareer)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+
+	levels := []*WhCareerLevel{&localized.Level1, &localized.Level2, &localized.Level3, &localized.Level4}
+	for i, name := range tr.LevelNames {
+		levels[i].Name = localizedText(levels[i].Name, name)
+	}
+	return localized
+}
+
 func GetWhCareerValidationAliases() map[string]string {
 	return map[string]string{
 		"status_valid":         fmt.Sprintf("oneof=%s", statusValues()),
 		"standing_valid":       fmt.Sprintf("oneof=%s", standingValues()),
-		"class_valid":          fmt.Sprintf("oneof=%s", classValues()),
-		"career_species_valid": fmt.Sprintf("oneof=%s", careerSpeciesValues()),
+		"class_valid":          "wh_enum=" + WhEnumCareerClass,
+		"career_species_valid": "id_valid",
 	}
 }
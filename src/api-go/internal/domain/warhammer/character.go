@@ -1,10 +1,11 @@
 package warhammer
 
 import (
-	"fmt"
 	"strings"
 )
 
+// WhCharacterSpecies is an id of a species object. Legacy four digit codes are still accepted and mapped to ids of
+// built-in species by SpeciesIdFromCode.
 type WhCharacterSpecies string
 
 const (
@@ -46,51 +47,11 @@ const (
 	WhCharacterSpeciesOgreDefault              = "0600"
 )
 
-func characterSpeciesValues() string {
-	return formatStringValues([]WhCharacterSpecies{
-		WhCharacterSpeciesHumanDefault,
-		WhCharacterSpeciesHumanReikland,
-		WhCharacterSpeciesHumanAltdorfSouthBank,
-		WhCharacterSpeciesHumanAltdorfEastend,
-		WhCharacterSpeciesHumanAltdorfHexxerbezrik,
-		WhCharacterSpeciesHumanAltdorfDocklands,
-		WhCharacterSpeciesHumanMiddenheim,
-		WhCharacterSpeciesHumanMiddenland,
-		WhCharacterSpeciesHumanNordland,
-		WhCharacterSpeciesHumanSalzenmund,
-		WhCharacterSpeciesHumanTilea,
-		WhCharacterSpeciesHumanNorseBjornling,
-		WhCharacterSpeciesHumanNorseSarl,
-		WhCharacterSpeciesHumanNorseSkaeling,
-		WhCharacterSpeciesHalflingDefault,
-		WhCharacterSpeciesHalflingAshfield,
-		WhCharacterSpeciesHalflingBrambledown,
-		WhCharacterSpeciesHalflingBrandysnap,
-		WhCharacterSpeciesHalflingHayfoot,
-		WhCharacterSpeciesHalflingHollyfoot,
-		WhCharacterSpeciesHalflingHayfootHollyfoot,
-		WhCharacterSpeciesHalflingLostpockets,
-		WhCharacterSpeciesHalflingLowhaven,
-		WhCharacterSpeciesHalflingRumster,
-		WhCharacterSpeciesHalflingSkelfsider,
-		WhCharacterSpeciesHalflingThorncobble,
-		WhCharacterSpeciesHalflingTumbleberry,
-		WhCharacterSpeciesDwarfDefault,
-		WhCharacterSpeciesDwarfAltdorf,
-		WhCharacterSpeciesDwarfCragforgeClan,
-		WhCharacterSpeciesDwarfGrumssonClan,
-		WhCharacterSpeciesDwarfNorse,
-		WhCharacterSpeciesHighElfDefault,
-		WhCharacterSpeciesWoodElfDefault,
-		WhCharacterSpeciesGnomeDefault,
-		WhCharacterSpeciesOgreDefault,
-	})
-}
 func (input WhCharacterSpecies) InitAndCopy() WhCharacterSpecies {
 	if input == "" {
-		return WhCharacterSpeciesHumanDefault
+		return WhCharacterSpecies(SpeciesIdFromCode(WhCharacterSpeciesHumanDefault))
 	}
-	return WhCharacterSpecies(strings.Clone(string(input)))
+	return WhCharacterSpecies(SpeciesIdFromCode(strings.Clone(string(input))))
 }
 
 type IdNumber struct {
@@ -115,8 +76,8 @@ func copyArrayIdNumber(input []IdNumber) []IdNumber {
 
 type WhCharacter struct {
 	Name              string             `json:"name" validate:"name_valid"`
-	Description       string             `json:"description" validate:"desc_valid"`
-	Notes             string             `json:"notes" validate:"desc_valid"`
+	Description       string             `json:"description" validate:"rich_text_valid"`
+	Notes             string             `json:"notes" validate:"rich_text_valid"`
 	EquippedItems     []IdNumber         `json:"equippedItems" validate:"dive"`
 	CarriedItems      []IdNumber         `json:"carriedItems" validate:"dive"`
 	StoredItems       []IdNumber         `json:"storedItems" validate:"dive"`
@@ -183,9 +144,23 @@ func (c WhCharacter) InitAndCopy() WhObject {
 	}
 }
 
+func (c WhCharacter) MapRichText(f func(string) string) WhObject {
+	mapped := c.InitAndCopy().(WhCharacter)
+	mapped.Description = f(mapped.Description)
+	mapped.Notes = f(mapped.Notes)
+	return mapped
+}
+
+func (c WhCharacter) Localize(tr WhTranslation) WhObject {
+	localized := c.InitAndCopy().(WhCharacter)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
 func GetWhCharacterValidationAliases() map[string]string {
 	return map[string]string{
-		"character_species_valid": fmt.Sprintf("oneof=%s", characterSpeciesValues()),
+		"character_species_valid": "id_valid",
 	}
 }
 
@@ -347,3 +322,10 @@ func (f WhCharacterFull) InitAndCopy() WhObject {
 		Shared:            f.Shared,
 	}
 }
+
+func (f WhCharacterFull) MapRichText(mapper func(string) string) WhObject {
+	mapped := f.InitAndCopy().(WhCharacterFull)
+	mapped.Description = mapper(mapped.Description)
+	mapped.Notes = mapper(mapped.Notes)
+	return mapped
+}
@@ -9,6 +9,7 @@ func GetWhCommonValidationAliases() map[string]string {
 	return map[string]string{
 		"name_valid":          "min=0,max=200,excludesall=<>",
 		"desc_valid":          "min=0,max=100000,excludesall=<>",
+		"rich_text_valid":     "min=0,max=100000",
 		"shared_valid":        "boolean",
 		"medium_string_valid": "min=0,max=200,excludesall=<>",
 		"id_valid":            "hexadecimal,len=24",
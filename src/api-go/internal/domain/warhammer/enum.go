@@ -0,0 +1,179 @@
+package warhammer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	WhEnumCareerClass     = "careerClass"
+	WhEnumItemMeleeGroup  = "itemMeleeGroup"
+	WhEnumItemRangedGroup = "itemRangedGroup"
+	WhEnumItemArmourGroup = "itemArmourGroup"
+	WhEnumSource          = "source"
+)
+
+var WhEnumNames = []string{
+	WhEnumCareerClass,
+	WhEnumItemMeleeGroup,
+	WhEnumItemRangedGroup,
+	WhEnumItemArmourGroup,
+	WhEnumSource,
+}
+
+// numericEnums back integer typed fields, so their values have to parse as integers.
+var numericEnums = map[string]bool{
+	WhEnumCareerClass:     true,
+	WhEnumItemMeleeGroup:  true,
+	WhEnumItemRangedGroup: true,
+	WhEnumItemArmourGroup: true,
+}
+
+func IsNumericEnum(name string) bool {
+	return numericEnums[name]
+}
+
+type WhEnumValue struct {
+	Value string `json:"value" validate:"min=1,max=50,excludesall=<>"`
+	Label string `json:"label" validate:"min=1,name_valid"`
+}
+
+type WhEnum struct {
+	Name      string        `json:"name" validate:"min=1"`
+	Values    []WhEnumValue `json:"values" validate:"min=1,dive"`
+	Version   int           `json:"version"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	UpdatedBy string        `json:"updatedBy"`
+}
+
+func (e WhEnum) InitAndCopy() WhEnum {
+	values := make([]WhEnumValue, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = WhEnumValue{Value: strings.Clone(v.Value), Label: strings.Clone(v.Label)}
+	}
+
+	return WhEnum{
+		Name:      strings.Clone(e.Name),
+		Values:    values,
+		Version:   e.Version,
+		UpdatedAt: e.UpdatedAt.UTC(),
+		UpdatedBy: strings.Clone(e.UpdatedBy),
+	}
+}
+
+func (e WhEnum) PointToCopy() *WhEnum {
+	cpy := e.InitAndCopy()
+	return &cpy
+}
+
+func intEnumValue[T ~int](value T, label string) WhEnumValue {
+	return WhEnumValue{Value: strconv.Itoa(int(value)), Label: label}
+}
+
+// NewDefaultEnums returns enumerations matching the values supported before they became editable.
+func NewDefaultEnums() []*WhEnum {
+	return []*WhEnum{
+		{Name: WhEnumCareerClass, Values: []WhEnumValue{
+			intEnumValue(WhCareerClassAcademic, "Academic"),
+			intEnumValue(WhCareerClassBurghers, "Burghers"),
+			intEnumValue(WhCareerClassCourtier, "Courtier"),
+			intEnumValue(WhCareerClassPeasant, "Peasant"),
+			intEnumValue(WhCareerClassRanger, "Ranger"),
+			intEnumValue(WhCareerClassRiverfolk, "Riverfolk"),
+			intEnumValue(WhCareerClassRouge, "Rogue"),
+			intEnumValue(WhCareerClassWarrior, "Warrior"),
+			intEnumValue(WhCareerClassSeafarer, "Seafarer"),
+		}},
+		{Name: WhEnumItemMeleeGroup, Values: []WhEnumValue{
+			intEnumValue(WhItemMeleeGroupBasic, "Basic"),
+			intEnumValue(WhItemMeleeGroupCavalry, "Cavalry"),
+			intEnumValue(WhItemMeleeGroupFencing, "Fencing"),
+			intEnumValue(WhItemMeleeGroupBrawling, "Brawling"),
+			intEnumValue(WhItemMeleeGroupFlail, "Flail"),
+			intEnumValue(WhItemMeleeGroupParry, "Parry"),
+			intEnumValue(WhItemMeleeGroupPolearm, "Polearm"),
+			intEnumValue(WhItemMeleeGroupTwoHanded, "Two-Handed"),
+		}},
+		{Name: WhEnumItemRangedGroup, Values: []WhEnumValue{
+			intEnumValue(WhItemRangedGroupBlackpowder, "Blackpowder"),
+			intEnumValue(WhItemRangedGroupBow, "Bow"),
+			intEnumValue(WhItemRangedGroupCrossbow, "Crossbow"),
+			intEnumValue(WhItemRangedGroupEngineering, "Engineering"),
+			intEnumValue(WhItemRangedGroupEntangling, "Entangling"),
+			intEnumValue(WhItemRangedGroupExplosives, "Explosives"),
+			intEnumValue(WhItemRangedGroupSling, "Sling"),
+			intEnumValue(WhItemRangedGroupThrowing, "Throwing"),
+		}},
+		{Name: WhEnumItemArmourGroup, Values: []WhEnumValue{
+			intEnumValue(WhItemArmourGroupSoftLeather, "Soft Leather"),
+			intEnumValue(WhItemArmourGroupBoiledLeather, "Boiled Leather"),
+			intEnumValue(WhItemArmourGroupMail, "Mail"),
+			intEnumValue(WhItemArmourGroupPlate, "Plate"),
+			intEnumValue(WhItemArmourGroupSoftKit, "Soft Kit"),
+			intEnumValue(WhItemArmourGroupBrigandine, "Brigandine"),
+		}},
+		{Name: WhEnumSource, Values: []WhEnumValue{
+			{Value: WhSourceCustom, Label: "Custom"},
+			{Value: WhSourceWFRP, Label: "WFRP"},
+			{Value: WhSourceRoughNightsAndHardDays, Label: "Rough Nights and Hard Days"},
+			{Value: WhSourceArchivesOfTheEmpireVolI, Label: "Archives of the Empire Vol I"},
+			{Value: WhSourceArchivesOfTheEmpireVolII, Label: "Archives of the Empire Vol II"},
+			{Value: WhSourceArchivesOfTheEmpireVolIII, Label: "Archives of the Empire Vol III"},
+			{Value: WhSourceUpInArms, Label: "Up in Arms"},
+			{Value: WhSourceWindsOfMagic, Label: "Winds of Magic"},
+			{Value: WhSourceMiddenheim, Label: "Middenheim"},
+			{Value: WhSourceSalzenmund, Label: "Salzenmund"},
+			{Value: WhSourceSeaOfClaws, Label: "Sea of Claws"},
+			{Value: WhSourceLustria, Label: "Lustria"},
+		}},
+	}
+}
+
+// WhEnumRegistry holds allowed values of editable enumerations. It is read by validators on every request and
+// replaced as a whole when enumerations change.
+type WhEnumRegistry struct {
+	mu     sync.RWMutex
+	values map[string]map[string]bool
+}
+
+func NewWhEnumRegistry() *WhEnumRegistry {
+	r := &WhEnumRegistry{}
+	r.Set(NewDefaultEnums())
+	return r
+}
+
+func (r *WhEnumRegistry) Set(enums []*WhEnum) {
+	values := make(map[string]map[string]bool, len(enums))
+	for _, e := range enums {
+		values[e.Name] = make(map[string]bool, len(e.Values))
+		for _, v := range e.Values {
+			values[e.Name][v.Value] = true
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range values {
+		if r.values == nil {
+			r.values = map[string]map[string]bool{}
+		}
+		r.values[k] = v
+	}
+}
+
+func (r *WhEnumRegistry) Contains(name string, value string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.values[name][value]
+}
+
+func (e WhEnum) ToMap() (map[string]any, error) {
+	enumMap, err := structToMap(e)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("error while mapping enum structure %s", err)
+	}
+	return enumMap, nil
+}
@@ -7,6 +7,8 @@ const (
 	WhNotFoundError
 	WhInternalError
 	WhUnauthorizedError
+	WhConflictError
+	WhGoneError
 )
 
 type WhError struct {
@@ -0,0 +1,84 @@
+package warhammer
+
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"sync"
+)
+
+const (
+	WhEventCreated  = "created"
+	WhEventUpdated  = "updated"
+	WhEventDeleted  = "deleted"
+	WhEventRestored = "restored"
+)
+
+type WhEvent struct {
+	Type   string
+	WhType WhType
+	Wh     *Wh
+}
+
+// WhSubscription receives events about objects with subscribed ids or of subscribed types. Events is closed when the
+// subscription ends, including when the subscriber does not keep up with published events.
+type WhSubscription struct {
+	Claims *domain.Claims
+	Events chan *WhEvent
+	mu     sync.RWMutex
+	ids    map[string]bool
+	types  map[WhType]bool
+}
+
+func NewWhSubscription(c *domain.Claims, bufferSize int) *WhSubscription {
+	return &WhSubscription{
+		Claims: c,
+		Events: make(chan *WhEvent, bufferSize),
+		ids:    make(map[string]bool),
+		types:  make(map[WhType]bool),
+	}
+}
+
+func (s *WhSubscription) Add(ids []string, types []WhType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		s.ids[id] = true
+	}
+	for _, t := range types {
+		s.types[t] = true
+	}
+}
+
+func (s *WhSubscription) Remove(ids []string, types []WhType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.ids, id)
+	}
+	for _, t := range types {
+		delete(s.types, t)
+	}
+}
+
+func (s *WhSubscription) List() ([]string, []WhType) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.ids))
+	for id := range s.ids {
+		ids = append(ids, id)
+	}
+	types := make([]WhType, 0, len(s.types))
+	for t := range s.types {
+		types = append(types, t)
+	}
+	return ids, types
+}
+
+func (s *WhSubscription) Matches(e *WhEvent) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.types[e.WhType] || s.ids[e.Wh.Id]
+}
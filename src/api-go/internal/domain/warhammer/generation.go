@@ -3,8 +3,12 @@ package warhammer
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
+// GenerationPropsName is the name of the admin provided rule set used when no other is requested.
+const GenerationPropsName = "generationProps"
+
 type WhIdNumberMap map[string]int
 
 func (input WhIdNumberMap) InitAndCopy() WhIdNumberMap {
@@ -16,9 +20,19 @@ func (input WhIdNumberMap) InitAndCopy() WhIdNumberMap {
 }
 
 type WhItems struct {
-	Equipped WhIdNumberMap `json:"equipped"`
-	Carried  WhIdNumberMap `json:"carried"`
-	Stored   WhIdNumberMap `json:"stored"`
+	Equipped WhIdNumberMap `json:"equipped" validate:"id_number_map_valid"`
+	Carried  WhIdNumberMap `json:"carried" validate:"id_number_map_valid"`
+	Stored   WhIdNumberMap `json:"stored" validate:"id_number_map_valid"`
+}
+
+func (input WhItems) Ids() []string {
+	ids := make([]string, 0, len(input.Equipped)+len(input.Carried)+len(input.Stored))
+	for _, m := range []WhIdNumberMap{input.Equipped, input.Carried, input.Stored} {
+		for k := range m {
+			ids = append(ids, k)
+		}
+	}
+	return ids
 }
 
 func (input WhItems) InitAndCopy() WhItems {
@@ -30,9 +44,9 @@ func (input WhItems) InitAndCopy() WhItems {
 }
 
 type WhRandomTalent struct {
-	Id      string `json:"id"`
-	MinRoll int    `json:"minRoll"`
-	MaxRoll int    `json:"maxRoll"`
+	Id      string `json:"id" validate:"id_valid"`
+	MinRoll int    `json:"minRoll" validate:"gte=1,lte=1000"`
+	MaxRoll int    `json:"maxRoll" validate:"gte=1,lte=1000,gtefield=MinRoll"`
 }
 
 func (input WhRandomTalent) InitAndCopy() WhRandomTalent {
@@ -44,8 +58,8 @@ func (input WhRandomTalent) InitAndCopy() WhRandomTalent {
 }
 
 type WhSpeciesTalents struct {
-	Single   []string   `json:"single"`
-	Multiple [][]string `json:"multiple"`
+	Single   []string   `json:"single" validate:"dive,id_valid"`
+	Multiple [][]string `json:"multiple" validate:"dive,dive,id_valid"`
 }
 
 func (input WhSpeciesTalents) InitAndCopy() WhSpeciesTalents {
@@ -65,12 +79,27 @@ func (input WhSpeciesTalents) InitAndCopy() WhSpeciesTalents {
 	return WhSpeciesTalents{Single: single, Multiple: multiple}
 }
 
+func (input WhSpeciesTalents) Ids() []string {
+	ids := make([]string, 0, len(input.Single))
+	ids = append(ids, input.Single...)
+	for _, v := range input.Multiple {
+		ids = append(ids, v...)
+	}
+	return ids
+}
+
 type WhGenerationProps struct {
-	Name           string
-	ClassItems     map[WhCareerClass]WhItems               `json:"classItems"`
-	RandomTalents  []WhRandomTalent                        `json:"randomTalents"`
-	SpeciesTalents map[WhCharacterSpecies]WhSpeciesTalents `json:"speciesTalents"`
-	SpeciesSkills  map[WhCharacterSpecies][]string         `json:"speciesSkills"`
+	Name           string                                  `validate:"min=1,name_valid"`
+	OwnerId        string                                  `json:"ownerId"`
+	Shared         bool                                    `json:"shared" validate:"shared_valid"`
+	CanEdit        bool                                    `json:"canEdit" bson:"-"`
+	Version        int                                     `json:"version"`
+	UpdatedAt      time.Time                               `json:"updatedAt"`
+	UpdatedBy      string                                  `json:"updatedBy"`
+	ClassItems     map[WhCareerClass]WhItems               `json:"classItems" validate:"dive,keys,class_valid,endkeys"`
+	RandomTalents  []WhRandomTalent                        `json:"randomTalents" validate:"dive"`
+	SpeciesTalents map[WhCharacterSpecies]WhSpeciesTalents `json:"speciesTalents" validate:"dive,keys,character_species_valid,endkeys"`
+	SpeciesSkills  map[WhCharacterSpecies][]string         `json:"speciesSkills" validate:"dive,keys,character_species_valid,endkeys,dive,id_valid"`
 }
 
 func (gp WhGenerationProps) InitAndCopy() WhGenerationProps {
@@ -86,7 +115,7 @@ func (gp WhGenerationProps) InitAndCopy() WhGenerationProps {
 
 	speciesTalents := make(map[WhCharacterSpecies]WhSpeciesTalents, len(gp.SpeciesTalents))
 	for k, v := range gp.SpeciesTalents {
-		speciesTalents[k] = v.InitAndCopy()
+		speciesTalents[k.InitAndCopy()] = v.InitAndCopy()
 	}
 
 	speciesSkills := make(map[WhCharacterSpecies][]string, len(gp.SpeciesSkills))
@@ -95,11 +124,17 @@ func (gp WhGenerationProps) InitAndCopy() WhGenerationProps {
 		for k2, v2 := range v1 {
 			skills[k2] = strings.Clone(v2)
 		}
-		speciesSkills[k1] = skills
+		speciesSkills[k1.InitAndCopy()] = skills
 	}
 
 	return WhGenerationProps{
 		Name:           strings.Clone(gp.Name),
+		OwnerId:        strings.Clone(gp.OwnerId),
+		Shared:         gp.Shared,
+		CanEdit:        gp.CanEdit,
+		Version:        gp.Version,
+		UpdatedAt:      gp.UpdatedAt.UTC(),
+		UpdatedBy:      strings.Clone(gp.UpdatedBy),
 		ClassItems:     classItems,
 		RandomTalents:  randomTalents,
 		SpeciesTalents: speciesTalents,
@@ -107,6 +142,28 @@ func (gp WhGenerationProps) InitAndCopy() WhGenerationProps {
 	}
 }
 
+// ReferencedIds returns ids of all objects referenced by generation properties, grouped by type.
+func (gp WhGenerationProps) ReferencedIds() map[WhType][]string {
+	ids := map[WhType][]string{WhTypeItem: {}, WhTypeTalent: {}, WhTypeSkill: {}, WhTypeSpecies: {}}
+
+	for _, v := range gp.ClassItems {
+		ids[WhTypeItem] = append(ids[WhTypeItem], v.Ids()...)
+	}
+	for _, v := range gp.RandomTalents {
+		ids[WhTypeTalent] = append(ids[WhTypeTalent], v.Id)
+	}
+	for k, v := range gp.SpeciesTalents {
+		ids[WhTypeSpecies] = append(ids[WhTypeSpecies], string(k))
+		ids[WhTypeTalent] = append(ids[WhTypeTalent], v.Ids()...)
+	}
+	for k, v := range gp.SpeciesSkills {
+		ids[WhTypeSpecies] = append(ids[WhTypeSpecies], string(k))
+		ids[WhTypeSkill] = append(ids[WhTypeSkill], v...)
+	}
+
+	return ids
+}
+
 func (gp WhGenerationProps) PointToCopy() *WhGenerationProps {
 	cpy := gp.InitAndCopy()
 	return &cpy
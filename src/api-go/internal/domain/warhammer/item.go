@@ -94,19 +94,6 @@ const (
 	WhItemMeleeGroupTwoHanded = 7
 )
 
-func itemMeleeGroupValues() string {
-	return formatIntegerValues([]WhItemMeleeGroup{
-		WhItemMeleeGroupBasic,
-		WhItemMeleeGroupCavalry,
-		WhItemMeleeGroupFencing,
-		WhItemMeleeGroupBrawling,
-		WhItemMeleeGroupFlail,
-		WhItemMeleeGroupParry,
-		WhItemMeleeGroupPolearm,
-		WhItemMeleeGroupTwoHanded,
-	})
-}
-
 func (input WhItemMeleeGroup) InitAndCopy() WhItemMeleeGroup {
 	return input
 }
@@ -124,19 +111,6 @@ const (
 	WhItemRangedGroupThrowing    = 7
 )
 
-func ItemRangedGroupValues() string {
-	return formatIntegerValues([]WhItemRangedGroup{
-		WhItemRangedGroupBlackpowder,
-		WhItemRangedGroupBow,
-		WhItemRangedGroupCrossbow,
-		WhItemRangedGroupEngineering,
-		WhItemRangedGroupEntangling,
-		WhItemRangedGroupExplosives,
-		WhItemRangedGroupSling,
-		WhItemRangedGroupThrowing,
-	})
-}
-
 func (input WhItemRangedGroup) InitAndCopy() WhItemRangedGroup {
 	return input
 }
@@ -176,17 +150,6 @@ const (
 	WhItemArmourGroupBrigandine    = 5
 )
 
-func itemArmourGroupValues() string {
-	return formatIntegerValues([]WhItemArmourGroup{
-		WhItemArmourGroupSoftLeather,
-		WhItemArmourGroupBoiledLeather,
-		WhItemArmourGroupMail,
-		WhItemArmourGroupPlate,
-		WhItemArmourGroupSoftKit,
-		WhItemArmourGroupBrigandine,
-	})
-}
-
 func (input WhItemArmourGroup) InitAndCopy() WhItemArmourGroup {
 	return input
 }
@@ -335,7 +298,7 @@ func (input WhItemOther) InitAndCopy() WhItemOther {
 
 type WhItem struct {
 	Name        string      `json:"name" validate:"name_valid"`
-	Description string      `json:"description" validate:"desc_valid"`
+	Description string      `json:"description" validate:"rich_text_valid"`
 	Price       float64     `json:"price" validate:"gte=0,lte=1000000000"`
 	Enc         float64     `json:"enc" validate:"gte=0,lte=1000"`
 	Properties  []string    `json:"properties" validate:"dive,id_valid"`
@@ -377,15 +340,28 @@ func (i WhItem) InitAndCopy() WhObject {
 	}
 }
 
+func (i WhItem) MapRichText(f func(string) string) WhObject {
+	mapped := i.InitAndCopy().(WhItem)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (i WhItem) Localize(tr WhTranslation) WhObject {
+	localized := i.InitAndCopy().(WhItem)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
 func GetWhItemValidationAliases() map[string]string {
 	return map[string]string{
 		"item_type_valid":             fmt.Sprintf("oneof=%s", itemTypeValues()),
 		"item_hands_valid":            fmt.Sprintf("oneof=%s", itemHandsValues()),
 		"item_melee_reach_valid":      fmt.Sprintf("oneof=%s", itemMeleeReachValues()),
-		"item_melee_group_valid":      fmt.Sprintf("oneof=%s", itemMeleeGroupValues()),
-		"item_ranged_group_valid":     fmt.Sprintf("oneof=%s", ItemRangedGroupValues()),
+		"item_melee_group_valid":      "wh_enum=" + WhEnumItemMeleeGroup,
+		"item_ranged_group_valid":     "wh_enum=" + WhEnumItemRangedGroup,
 		"item_ammunition_group_valid": fmt.Sprintf("oneof=%s", itemAmmunitionGroupValues()),
-		"item_armour_group_valid":     fmt.Sprintf("oneof=%s", itemArmourGroupValues()),
+		"item_armour_group_valid":     "wh_enum=" + WhEnumItemArmourGroup,
 		"item_armour_location_valid":  fmt.Sprintf("oneof=%s", itemArmourLocationValues()),
 		"item_carry_type_valid":       fmt.Sprintf("oneof=%s", itemCarryTypeValues()),
 	}
@@ -481,3 +457,9 @@ func (i WhItemFull) InitAndCopy() WhObject {
 		Other:      i.Other.InitAndCopy(),
 	}
 }
+
+func (i WhItemFull) MapRichText(f func(string) string) WhObject {
+	mapped := i.InitAndCopy().(WhItemFull)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
@@ -7,7 +7,7 @@ import (
 
 type WhMutation struct {
 	Name        string         `json:"name" validate:"name_valid"`
-	Description string         `json:"description" validate:"desc_valid"`
+	Description string         `json:"description" validate:"rich_text_valid"`
 	Type        WhMutationType `json:"type" validate:"mutation_type_valid"`
 	Modifiers   WhModifiers    `json:"modifiers"`
 	Shared      bool           `json:"shared" validate:"shared_valid"`
@@ -29,6 +29,19 @@ func (m WhMutation) InitAndCopy() WhObject {
 	}
 }
 
+func (m WhMutation) MapRichText(f func(string) string) WhObject {
+	mapped := m.InitAndCopy().(WhMutation)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (m WhMutation) Localize(tr WhTranslation) WhObject {
+	localized := m.InitAndCopy().(WhMutation)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
 type WhMutationType int
 
 const (
@@ -7,7 +7,7 @@ import (
 
 type WhProperty struct {
 	Name         string         `json:"name" validate:"name_valid"`
-	Description  string         `json:"description" validate:"desc_valid"`
+	Description  string         `json:"description" validate:"rich_text_valid"`
 	Type         WhPropertyType `json:"type" validate:"property_type_valid"`
 	ApplicableTo []WhItemType   `json:"applicableTo" validate:"dive,item_type_valid"`
 	Shared       bool           `json:"shared" validate:"shared_valid"`
@@ -29,6 +29,19 @@ func (p WhProperty) InitAndCopy() WhObject {
 	}
 }
 
+func (p WhProperty) MapRichText(f func(string) string) WhObject {
+	mapped := p.InitAndCopy().(WhProperty)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (p WhProperty) Localize(tr WhTranslation) WhObject {
+	localized := p.InitAndCopy().(WhProperty)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
 func copyApplicableTo(input []WhItemType) []WhItemType {
 	output := make([]WhItemType, len(input))
 	for i, v := range input {
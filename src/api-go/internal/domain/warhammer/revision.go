@@ -0,0 +1,54 @@
+package warhammer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type WhRevision struct {
+	WhType     WhType
+	WhId       string
+	Rev        int
+	OwnerId    string
+	ReplacedOn time.Time
+	ReplacedBy string
+	Object     WhObject
+}
+
+func (r WhRevision) InitAndCopy() WhRevision {
+	return WhRevision{
+		WhType:     WhType(strings.Clone(string(r.WhType))),
+		WhId:       strings.Clone(r.WhId),
+		Rev:        r.Rev,
+		OwnerId:    strings.Clone(r.OwnerId),
+		ReplacedOn: r.ReplacedOn.UTC(),
+		ReplacedBy: strings.Clone(r.ReplacedBy),
+		Object:     r.Object.InitAndCopy(),
+	}
+}
+
+func (r WhRevision) PointToCopy() *WhRevision {
+	cpy := r.InitAndCopy()
+	return &cpy
+}
+
+func (r WhRevision) ToMap(withObject bool) (map[string]any, error) {
+	rMap := map[string]any{
+		"whId":       r.WhId,
+		"rev":        r.Rev,
+		"ownerId":    r.OwnerId,
+		"replacedOn": r.ReplacedOn,
+		"replacedBy": r.ReplacedBy,
+	}
+
+	if withObject {
+		objectMap, err := structToMap(r.Object)
+		if err != nil {
+			return map[string]any{}, fmt.Errorf("error while mapping wh revision structure %s", err)
+		}
+		rMap["object"] = objectMap
+	}
+
+	return rMap, nil
+}
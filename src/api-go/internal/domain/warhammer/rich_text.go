@@ -0,0 +1,62 @@
+package warhammer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WhRichText is implemented by objects with markdown description fields.
+type WhRichText interface {
+	MapRichText(f func(string) string) WhObject
+}
+
+// MapRichText replaces every markdown field of the object with the result of f.
+func (w *Wh) MapRichText(f func(string) string) {
+	if rt, ok := w.Object.(WhRichText); ok {
+		w.Object = rt.MapRichText(f)
+	}
+}
+
+// Raw HTML tags are not part of the supported markdown dialect. Lone angle brackets such as "Dmg < 5" and autolinks
+// such as "<https://example.com>" do not match.
+var htmlTagPattern = regexp.MustCompile(`<!--[\s\S]*?-->|</?[A-Za-z][A-Za-z0-9-]*(\s[^<>]*)?/?>`)
+
+// SanitizeMarkdown removes raw HTML from markdown text.
+func SanitizeMarkdown(text string) string {
+	return htmlTagPattern.ReplaceAllString(text, "")
+}
+
+// WhLink is a cross-link to another object written as [[type:Name]].
+type WhLink struct {
+	Type WhType
+	Name string
+}
+
+var whLinkPattern = regexp.MustCompile(`\[\[([a-z]+):([^\[\]]+)\]\]`)
+
+func FindLinks(text string) []WhLink {
+	matches := whLinkPattern.FindAllStringSubmatch(text, -1)
+	links := make([]WhLink, 0, len(matches))
+	for _, m := range matches {
+		links = append(links, WhLink{Type: WhType(m[1]), Name: strings.TrimSpace(m[2])})
+	}
+	return links
+}
+
+// ReplaceLinks replaces every cross-link in text with the result of f.
+func ReplaceLinks(text string, f func(link WhLink) string) string {
+	return whLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := whLinkPattern.FindStringSubmatch(match)
+		return f(WhLink{Type: WhType(m[1]), Name: strings.TrimSpace(m[2])})
+	})
+}
+
+// Name returns the name of the object.
+func (w Wh) Name() string {
+	objectMap, err := structToMap(w.Object)
+	if err != nil {
+		return ""
+	}
+	name, _ := objectMap["name"].(string)
+	return name
+}
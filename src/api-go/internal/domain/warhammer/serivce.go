@@ -3,6 +3,7 @@ package warhammer
 import (
 	"context"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"time"
 )
 
 type WhService interface {
@@ -10,16 +11,73 @@ type WhService interface {
 	Update(ctx context.Context, t WhType, w *Wh, c *domain.Claims) (*Wh, *WhError)
 	Delete(ctx context.Context, t WhType, whId string, c *domain.Claims) *WhError
 	Get(ctx context.Context, t WhType, c *domain.Claims, full bool, whIds []string) ([]*Wh, *WhError)
+	Render(ctx context.Context, whs []*Wh, c *domain.Claims) *WhError
 
-	GetGenerationProps(ctx context.Context) (*WhGenerationProps, *WhError)
+	GetTrash(ctx context.Context, c *domain.Claims) (map[WhType][]*Wh, *WhError)
+	Restore(ctx context.Context, t WhType, whId string, c *domain.Claims) (*Wh, *WhError)
+	Hide(ctx context.Context, t WhType, whId string, reason string, c *domain.Claims) (*Wh, *WhError)
+	Unhide(ctx context.Context, t WhType, whId string, c *domain.Claims) (*Wh, *WhError)
+	GetChanges(ctx context.Context, c *domain.Claims, since time.Time) (map[WhType]*WhChanges, *WhError)
+
+	GetRevisions(ctx context.Context, t WhType, whId string, c *domain.Claims) ([]*WhRevision, *WhError)
+	GetRevision(ctx context.Context, t WhType, whId string, rev int, c *domain.Claims) (*WhRevision, *WhError)
+	DiffRevision(ctx context.Context, t WhType, whId string, rev int, againstRev int, c *domain.Claims) ([]domain.FieldChange, *WhError)
+	RestoreRevision(ctx context.Context, t WhType, whId string, rev int, c *domain.Claims) (*Wh, *WhError)
+
+	GetGenerationProps(ctx context.Context, name string, c *domain.Claims) (*WhGenerationProps, *WhError)
+	ListGenerationProps(ctx context.Context, c *domain.Claims) ([]*WhGenerationProps, *WhError)
+	CreateGenerationProps(ctx context.Context, gp *WhGenerationProps, c *domain.Claims) (*WhGenerationProps, *WhError)
+	UpdateGenerationProps(ctx context.Context, gp *WhGenerationProps, c *domain.Claims) (*WhGenerationProps, *WhError)
+	DeleteGenerationProps(ctx context.Context, name string, c *domain.Claims) *WhError
+	GetGenerationPropsHistory(ctx context.Context, name string, c *domain.Claims) ([]*WhGenerationProps, *WhError)
+
+	SubmitTranslations(ctx context.Context, translations []*WhTranslationSubmission, c *domain.Claims) ([]string, *WhError)
+
+	GetEnums(ctx context.Context) ([]*WhEnum, *WhError)
+	UpdateEnum(ctx context.Context, e *WhEnum, c *domain.Claims) (*WhEnum, *WhError)
+
+	Subscribe(c *domain.Claims) *WhSubscription
+	Unsubscribe(sub *WhSubscription)
 }
 
 type WhDbService interface {
 	Create(ctx context.Context, t WhType, wh *Wh) (*Wh, *domain.DbError)
-	Update(ctx context.Context, t WhType, wh *Wh, userId string) (*Wh, *domain.DbError)
-	Delete(ctx context.Context, t WhType, whId string, userId string) *domain.DbError
+	Update(ctx context.Context, t WhType, wh *Wh, ownerId string) (*Wh, *domain.DbError)
+	Delete(ctx context.Context, t WhType, whId string, ownerId string, userId string) *domain.DbError
 	Retrieve(ctx context.Context, t WhType, userIds []string, sharedUserIds []string, whIds []string) ([]*Wh, *domain.DbError)
+	RetrieveDeleted(ctx context.Context, t WhType, ownerId string) ([]*Wh, *domain.DbError)
+	RetrieveChanged(ctx context.Context, t WhType, users []string, sharedUsers []string, since time.Time) ([]*Wh, *domain.DbError)
+	Restore(ctx context.Context, t WhType, whId string, ownerId string, userId string) (*Wh, *domain.DbError)
+	UpdateHidden(ctx context.Context, t WhType, whId string, hidden bool, reason string, userId string) (*Wh, *domain.DbError)
+	// Purge permanently removes objects deleted before deletedBefore and forgets objects deleted with their owner
+	// before then.
+	Purge(ctx context.Context, t WhType, deletedBefore time.Time) ([]string, *domain.DbError)
+	// DeleteByOwner permanently removes all objects of ownerId, including the ones in the trash, and returns their ids.
+	// The ids are kept until purged, so that RetrieveRemoved can report them.
+	DeleteByOwner(ctx context.Context, t WhType, ownerId string) ([]string, *domain.DbError)
+	// RetrieveRemoved returns ids of objects that may have disappeared from sight of users sharing them since the given
+	// time: objects of sharedUsers updated since then that are not shared or are hidden, and objects deleted together
+	// with their owner.
+	RetrieveRemoved(ctx context.Context, t WhType, sharedUsers []string, since time.Time) ([]string, *domain.DbError)
+	UpdateTranslation(ctx context.Context, t WhType, whId string, ownerId string, locale string, tr WhTranslation, userId string) *domain.DbError
 
-	RetrieveGenerationProps(ctx context.Context) (*WhGenerationProps, *domain.DbError)
+	RetrieveGenerationProps(ctx context.Context, ownerId string, name string) (*WhGenerationProps, *domain.DbError)
+	RetrieveGenerationPropsList(ctx context.Context, userIds []string, sharedUserIds []string) ([]*WhGenerationProps, *domain.DbError)
 	CreateGenerationProps(ctx context.Context, gp *WhGenerationProps) (*WhGenerationProps, *domain.DbError)
+	UpdateGenerationProps(ctx context.Context, gp *WhGenerationProps) (*WhGenerationProps, *domain.DbError)
+	DeleteGenerationProps(ctx context.Context, ownerId string, name string) *domain.DbError
+	RetrieveGenerationPropsHistory(ctx context.Context, ownerId string, name string) ([]*WhGenerationProps, *domain.DbError)
+	// RetrieveMissingIds returns whIds that do not exist or are not visible to users and sharedUsers, as in Retrieve.
+	RetrieveMissingIds(ctx context.Context, t WhType, users []string, sharedUsers []string, whIds []string) ([]string, *domain.DbError)
+
+	RetrieveEnums(ctx context.Context) ([]*WhEnum, *domain.DbError)
+	UpsertEnum(ctx context.Context, e *WhEnum) (*WhEnum, *domain.DbError)
+}
+
+type WhRevisionDbService interface {
+	Create(ctx context.Context, r *WhRevision) (*WhRevision, *domain.DbError)
+	Retrieve(ctx context.Context, t WhType, whId string) ([]*WhRevision, *domain.DbError)
+	RetrieveOne(ctx context.Context, t WhType, whId string, rev int) (*WhRevision, *domain.DbError)
+	Delete(ctx context.Context, t WhType, whId string, revs []int) *domain.DbError
+	DeleteAll(ctx context.Context, t WhType, whIds []string) *domain.DbError
 }
@@ -27,7 +27,7 @@ func (input WhSkillType) InitAndCopy() WhSkillType {
 
 type WhSkill struct {
 	Name        string      `json:"name" validate:"name_valid"`
-	Description string      `json:"description" validate:"desc_valid"`
+	Description string      `json:"description" validate:"rich_text_valid"`
 	Attribute   WhAttribute `json:"attribute" validate:"att_type_valid"`
 	Type        WhSkillType `json:"type" validate:"skill_type_valid"`
 	IsGroup     bool        `json:"isGroup" validate:"boolean"`
@@ -55,6 +55,19 @@ func (s WhSkill) InitAndCopy() WhObject {
 	}
 }
 
+func (s WhSkill) MapRichText(f func(string) string) WhObject {
+	mapped := s.InitAndCopy().(WhSkill)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (s WhSkill) Localize(tr WhTranslation) WhObject {
+	localized := s.InitAndCopy().(WhSkill)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
 func GetWhSkillValidationAliases() map[string]string {
 	return map[string]string{
 		"skill_type_valid": fmt.Sprintf("oneof=%s", skillTypeValues()),
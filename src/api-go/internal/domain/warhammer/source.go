@@ -1,7 +1,6 @@
 package warhammer
 
 import (
-	"fmt"
 	"strings"
 )
 
@@ -22,23 +21,6 @@ const (
 	WhSourceLustria                   = "11"
 )
 
-func sourceValues() string {
-	return formatStringValues([]WhSource{
-		WhSourceCustom,
-		WhSourceWFRP,
-		WhSourceRoughNightsAndHardDays,
-		WhSourceArchivesOfTheEmpireVolI,
-		WhSourceArchivesOfTheEmpireVolII,
-		WhSourceArchivesOfTheEmpireVolIII,
-		WhSourceUpInArms,
-		WhSourceWindsOfMagic,
-		WhSourceMiddenheim,
-		WhSourceSalzenmund,
-		WhSourceSeaOfClaws,
-		WhSourceLustria,
-	})
-}
-
 type WhSourceMap map[WhSource]string
 
 func (input WhSourceMap) InitAndCopy() WhSourceMap {
@@ -51,6 +33,6 @@ func (input WhSourceMap) InitAndCopy() WhSourceMap {
 
 func GetWhSourceValidationAliases() map[string]string {
 	return map[string]string{
-		"source_valid": fmt.Sprintf("dive,keys,oneof=%s,endkeys,min=0,max=15,excludesall=<>", sourceValues()),
+		"source_valid": "dive,keys,wh_enum=" + WhEnumSource + ",endkeys,min=0,max=15,excludesall=<>",
 	}
 }
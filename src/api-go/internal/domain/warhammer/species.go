@@ -0,0 +1,227 @@
+package warhammer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type WhSize int
+
+const (
+	WhSizeTiny      = 0
+	WhSizeLittle    = 1
+	WhSizeSmall     = 2
+	WhSizeAverage   = 3
+	WhSizeLarge     = 4
+	WhSizeEnormous  = 5
+	WhSizeMonstrous = 6
+)
+
+func sizeValues() string {
+	return formatIntegerValues([]WhSize{
+		WhSizeTiny,
+		WhSizeLittle,
+		WhSizeSmall,
+		WhSizeAverage,
+		WhSizeLarge,
+		WhSizeEnormous,
+		WhSizeMonstrous,
+	})
+}
+
+func (input WhSize) InitAndCopy() WhSize {
+	return input
+}
+
+// WhSpecies describes a playable species. Starting attributes are rolled as AttributeDice d10 plus BaseAttributes.
+type WhSpecies struct {
+	Name           string       `json:"name" validate:"name_valid"`
+	Description    string       `json:"description" validate:"rich_text_valid"`
+	BaseSpecies    string       `json:"baseSpecies" validate:"omitempty,id_valid"`
+	BaseAttributes WhAttributes `json:"baseAttributes"`
+	AttributeDice  int          `json:"attributeDice" validate:"gte=0,lte=10"`
+	Fate           int          `json:"fate" validate:"gte=0,lte=1000"`
+	Resilience     int          `json:"resilience" validate:"gte=0,lte=1000"`
+	ExtraPoints    int          `json:"extraPoints" validate:"gte=0,lte=1000"`
+	Movement       int          `json:"movement" validate:"gte=0,lte=100"`
+	Size           WhSize       `json:"size" validate:"size_valid"`
+	Shared         bool         `json:"shared" validate:"shared_valid"`
+	Source         WhSourceMap  `json:"source" validate:"source_valid"`
+}
+
+func (s WhSpecies) IsShared() bool {
+	return s.Shared
+}
+
+func (s WhSpecies) InitAndCopy() WhObject {
+	return WhSpecies{
+		Name:           strings.Clone(s.Name),
+		Description:    strings.Clone(s.Description),
+		BaseSpecies:    strings.Clone(s.BaseSpecies),
+		BaseAttributes: s.BaseAttributes.InitAndCopy(),
+		AttributeDice:  s.AttributeDice,
+		Fate:           s.Fate,
+		Resilience:     s.Resilience,
+		ExtraPoints:    s.ExtraPoints,
+		Movement:       s.Movement,
+		Size:           s.Size.InitAndCopy(),
+		Shared:         s.Shared,
+		Source:         s.Source.InitAndCopy(),
+	}
+}
+
+func (s WhSpecies) MapRichText(f func(string) string) WhObject {
+	mapped := s.InitAndCopy().(WhSpecies)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (s WhSpecies) Localize(tr WhTranslation) WhObject {
+	localized := s.InitAndCopy().(WhSpecies)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
+
+func GetWhSpeciesValidationAliases() map[string]string {
+	return map[string]string{
+		"size_valid": fmt.Sprintf("oneof=%s", sizeValues()),
+	}
+}
+
+const LegacySpeciesIdPrefix = "ffffffffffffffffffff"
+
+var legacySpeciesCode = regexp.MustCompile(`^[0-9]{4}$`)
+
+// SpeciesIdFromCode maps legacy four digit species codes to ids of the corresponding built-in species. Anything that
+// is not a legacy code is returned unchanged.
+func SpeciesIdFromCode(code string) string {
+	if legacySpeciesCode.MatchString(code) {
+		return LegacySpeciesIdPrefix + code
+	}
+	return code
+}
+
+// careerSpeciesCodes maps legacy career species enum values to codes of the default species variant.
+var careerSpeciesCodes = map[int]string{
+	WhCareerSpeciesHuman:    WhCharacterSpeciesHumanDefault,
+	WhCareerSpeciesHalfling: WhCharacterSpeciesHalflingDefault,
+	WhCareerSpeciesDwarf:    WhCharacterSpeciesDwarfDefault,
+	WhCareerSpeciesHighElf:  WhCharacterSpeciesHighElfDefault,
+	WhCareerSpeciesWoodElf:  WhCharacterSpeciesWoodElfDefault,
+	WhCareerSpeciesGnome:    WhCharacterSpeciesGnomeDefault,
+	WhCareerSpeciesOgre:     WhCharacterSpeciesOgreDefault,
+}
+
+// SpeciesIdFromCareerSpecies maps legacy career species enum values to ids of built-in species.
+func SpeciesIdFromCareerSpecies(careerSpecies int) (string, bool) {
+	code, ok := careerSpeciesCodes[careerSpecies]
+	if !ok {
+		return "", false
+	}
+	return SpeciesIdFromCode(code), true
+}
+
+type legacySpecies struct {
+	code        string
+	name        string
+	attributes  WhAttributes
+	fate        int
+	resilience  int
+	extraPoints int
+	movement    int
+	size        WhSize
+}
+
+var (
+	humanAttributes    = WhAttributes{WS: 20, BS: 20, S: 20, T: 20, I: 20, Ag: 20, Dex: 20, Int: 20, WP: 20, Fel: 20}
+	halflingAttributes = WhAttributes{WS: 10, BS: 30, S: 10, T: 20, I: 20, Ag: 20, Dex: 30, Int: 20, WP: 30, Fel: 30}
+	dwarfAttributes    = WhAttributes{WS: 30, BS: 20, S: 20, T: 30, I: 20, Ag: 10, Dex: 30, Int: 20, WP: 40, Fel: 10}
+	elfAttributes      = WhAttributes{WS: 30, BS: 30, S: 20, T: 20, I: 40, Ag: 30, Dex: 30, Int: 30, WP: 30, Fel: 20}
+	gnomeAttributes    = WhAttributes{WS: 20, BS: 10, S: 10, T: 15, I: 30, Ag: 30, Dex: 30, Int: 30, WP: 40, Fel: 15}
+	ogreAttributes     = WhAttributes{WS: 20, BS: 10, S: 35, T: 35, I: 0, Ag: 15, Dex: 10, Int: 10, WP: 20, Fel: 10}
+)
+
+func human(code string, name string) legacySpecies {
+	return legacySpecies{code, name, humanAttributes, 2, 1, 3, 4, WhSizeAverage}
+}
+
+func halfling(code string, name string) legacySpecies {
+	return legacySpecies{code, name, halflingAttributes, 0, 2, 3, 3, WhSizeSmall}
+}
+
+func dwarf(code string, name string) legacySpecies {
+	return legacySpecies{code, name, dwarfAttributes, 0, 2, 2, 3, WhSizeAverage}
+}
+
+var legacySpeciesList = []legacySpecies{
+	human(WhCharacterSpeciesHumanDefault, "Human"),
+	human(WhCharacterSpeciesHumanReikland, "Human (Reiklander)"),
+	human(WhCharacterSpeciesHumanAltdorfSouthBank, "Human (Altdorfer South Bank)"),
+	human(WhCharacterSpeciesHumanAltdorfEastend, "Human (Altdorfer Eastend)"),
+	human(WhCharacterSpeciesHumanAltdorfHexxerbezrik, "Human (Altdorfer Hexxerbezrik)"),
+	human(WhCharacterSpeciesHumanAltdorfDocklands, "Human (Altdorfer Docklands)"),
+	human(WhCharacterSpeciesHumanMiddenheim, "Human (Middenheimer)"),
+	human(WhCharacterSpeciesHumanMiddenland, "Human (Middenlander)"),
+	human(WhCharacterSpeciesHumanNordland, "Human (Nordlander)"),
+	human(WhCharacterSpeciesHumanSalzenmund, "Human (Salzenmunder)"),
+	human(WhCharacterSpeciesHumanTilea, "Human (Tilean)"),
+	human(WhCharacterSpeciesHumanNorseBjornling, "Human (Norse Bjornling)"),
+	human(WhCharacterSpeciesHumanNorseSarl, "Human (Norse Sarl)"),
+	human(WhCharacterSpeciesHumanNorseSkaeling, "Human (Norse Skaeling)"),
+	halfling(WhCharacterSpeciesHalflingDefault, "Halfling"),
+	halfling(WhCharacterSpeciesHalflingAshfield, "Halfling (Ashfield)"),
+	halfling(WhCharacterSpeciesHalflingBrambledown, "Halfling (Brambledown)"),
+	halfling(WhCharacterSpeciesHalflingBrandysnap, "Halfling (Brandysnap)"),
+	halfling(WhCharacterSpeciesHalflingHayfoot, "Halfling (Hayfoot)"),
+	halfling(WhCharacterSpeciesHalflingHollyfoot, "Halfling (Hollyfoot)"),
+	halfling(WhCharacterSpeciesHalflingHayfootHollyfoot, "Halfling (Hayfoot-Hollyfoot)"),
+	halfling(WhCharacterSpeciesHalflingLostpockets, "Halfling (Lostpockets)"),
+	halfling(WhCharacterSpeciesHalflingLowhaven, "Halfling (Lowhaven)"),
+	halfling(WhCharacterSpeciesHalflingRumster, "Halfling (Rumster)"),
+	halfling(WhCharacterSpeciesHalflingSkelfsider, "Halfling (Skelfsider)"),
+	halfling(WhCharacterSpeciesHalflingThorncobble, "Halfling (Thorncobble)"),
+	halfling(WhCharacterSpeciesHalflingTumbleberry, "Halfling (Tumbleberry)"),
+	dwarf(WhCharacterSpeciesDwarfDefault, "Dwarf"),
+	dwarf(WhCharacterSpeciesDwarfAltdorf, "Dwarf (Altdorfer)"),
+	dwarf(WhCharacterSpeciesDwarfCragforgeClan, "Dwarf (Cragforge Clan)"),
+	dwarf(WhCharacterSpeciesDwarfGrumssonClan, "Dwarf (Grumsson Clan)"),
+	dwarf(WhCharacterSpeciesDwarfNorse, "Dwarf (Norse)"),
+	{WhCharacterSpeciesHighElfDefault, "High Elf", elfAttributes, 0, 0, 2, 5, WhSizeAverage},
+	{WhCharacterSpeciesWoodElfDefault, "Wood Elf", elfAttributes, 0, 0, 2, 5, WhSizeAverage},
+	{WhCharacterSpeciesGnomeDefault, "Gnome", gnomeAttributes, 2, 0, 2, 3, WhSizeSmall},
+	{WhCharacterSpeciesOgreDefault, "Ogre", ogreAttributes, 0, 3, 3, 6, WhSizeLarge},
+}
+
+// NewLegacySpecies returns admin owned species matching the legacy species codes. Regional variants point to the
+// default variant of their species as BaseSpecies.
+func NewLegacySpecies() []*Wh {
+	whs := make([]*Wh, len(legacySpeciesList))
+	for i, v := range legacySpeciesList {
+		baseSpecies := ""
+		if baseCode := v.code[:2] + "00"; baseCode != v.code {
+			baseSpecies = SpeciesIdFromCode(baseCode)
+		}
+
+		whs[i] = &Wh{
+			Id:      SpeciesIdFromCode(v.code),
+			OwnerId: "admin",
+			Version: 1,
+			Object: WhSpecies{
+				Name:           v.name,
+				BaseSpecies:    baseSpecies,
+				BaseAttributes: v.attributes,
+				AttributeDice:  2,
+				Fate:           v.fate,
+				Resilience:     v.resilience,
+				ExtraPoints:    v.extraPoints,
+				Movement:       v.movement,
+				Size:           v.size,
+				Shared:         true,
+				Source:         WhSourceMap{},
+			},
+		}
+	}
+	return whs
+}
@@ -6,7 +6,7 @@ import (
 
 type WhSpell struct {
 	Name        string      `json:"name" validate:"name_valid"`
-	Description string      `json:"description" validate:"desc_valid"`
+	Description string      `json:"description" validate:"rich_text_valid"`
 	Cn          int         `json:"cn" validate:"min=-1,max=99"`
 	Range       string      `json:"range" validate:"medium_string_valid"`
 	Target      string      `json:"target" validate:"medium_string_valid"`
@@ -31,3 +31,16 @@ func (s WhSpell) InitAndCopy() WhObject {
 		Source:      s.Source.InitAndCopy(),
 	}
 }
+
+func (s WhSpell) MapRichText(f func(string) string) WhObject {
+	mapped := s.InitAndCopy().(WhSpell)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (s WhSpell) Localize(tr WhTranslation) WhObject {
+	localized := s.InitAndCopy().(WhSpell)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
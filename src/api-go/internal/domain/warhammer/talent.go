@@ -4,7 +4,7 @@ import "strings"
 
 type WhTalent struct {
 	Name        string      `json:"name" validate:"name_valid"`
-	Description string      `json:"description" validate:"desc_valid"`
+	Description string      `json:"description" validate:"rich_text_valid"`
 	Tests       string      `json:"tests" validate:"medium_string_valid"`
 	MaxRank     int         `json:"maxRank" validate:"gte=0,lte=99"`
 	Attribute   WhAttribute `json:"attribute" validate:"att_type_valid"`
@@ -33,3 +33,16 @@ func (t WhTalent) InitAndCopy() WhObject {
 		Source:      t.Source.InitAndCopy(),
 	}
 }
+
+func (t WhTalent) MapRichText(f func(string) string) WhObject {
+	mapped := t.InitAndCopy().(WhTalent)
+	mapped.Description = f(mapped.Description)
+	return mapped
+}
+
+func (t WhTalent) Localize(tr WhTranslation) WhObject {
+	localized := t.InitAndCopy().(WhTalent)
+	localized.Name = localizedText(localized.Name, tr.Name)
+	localized.Description = localizedText(localized.Description, tr.Description)
+	return localized
+}
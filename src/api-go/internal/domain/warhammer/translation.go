@@ -0,0 +1,90 @@
+package warhammer
+
+import (
+	"strings"
+)
+
+type WhTranslation struct {
+	Name        string   `json:"name" validate:"name_valid"`
+	Description string   `json:"description" validate:"rich_text_valid"`
+	LevelNames  []string `json:"levelNames,omitempty" validate:"max=4,dive,name_valid"`
+}
+
+func (t WhTranslation) InitAndCopy() WhTranslation {
+	return WhTranslation{
+		Name:        strings.Clone(t.Name),
+		Description: strings.Clone(t.Description),
+		LevelNames:  copyStringArray(t.LevelNames),
+	}
+}
+
+func (t WhTranslation) IsEmpty() bool {
+	return t.Name == "" && t.Description == "" && len(t.LevelNames) == 0
+}
+
+// WhTranslations holds translations of an object keyed by BCP 47 language tag.
+type WhTranslations map[string]WhTranslation
+
+func (t WhTranslations) InitAndCopy() WhTranslations {
+	if t == nil {
+		return nil
+	}
+
+	cpy := make(WhTranslations, len(t))
+	for k, v := range t {
+		cpy[strings.Clone(k)] = v.InitAndCopy()
+	}
+	return cpy
+}
+
+// Resolve returns the translation for locale, falling back to its base language, so that "pl-PL" matches "pl".
+func (t WhTranslations) Resolve(locale string) (WhTranslation, bool) {
+	if locale == "" {
+		return WhTranslation{}, false
+	}
+
+	if tr, ok := t[locale]; ok {
+		return tr, true
+	}
+
+	base, _, found := strings.Cut(locale, "-")
+	if !found {
+		return WhTranslation{}, false
+	}
+
+	tr, ok := t[base]
+	return tr, ok
+}
+
+// WhLocalizable is implemented by objects whose text can be replaced with a translation.
+type WhLocalizable interface {
+	Localize(tr WhTranslation) WhObject
+}
+
+// Localize replaces object texts with the translation for locale. Fields missing from the translation keep the
+// default text.
+func (w *Wh) Localize(locale string) {
+	tr, ok := w.Translations.Resolve(locale)
+	if !ok {
+		return
+	}
+
+	if l, ok := w.Object.(WhLocalizable); ok {
+		w.Object = l.Localize(tr)
+	}
+}
+
+func localizedText(text string, translated string) string {
+	if translated == "" {
+		return text
+	}
+	return translated
+}
+
+// WhTranslationSubmission is a single entry of a bulk translation upload.
+type WhTranslationSubmission struct {
+	Type   WhType `json:"type"`
+	Id     string `json:"id" validate:"id_valid"`
+	Locale string `json:"locale" validate:"bcp47_language_tag"`
+	WhTranslation
+}
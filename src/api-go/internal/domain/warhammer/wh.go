@@ -4,13 +4,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Wh struct {
-	Id      string
-	OwnerId string
-	CanEdit bool
-	Object  WhObject
+	Id             string
+	OwnerId        string
+	CanEdit        bool
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LastModifiedBy string
+	DeletedAt      *time.Time
+	// Hidden objects were hidden by a moderator and are visible only to their owner.
+	Hidden       bool
+	HiddenBy     string
+	HiddenReason string
+	Translations WhTranslations
+	Object       WhObject
 }
 
 const (
@@ -22,6 +33,7 @@ const (
 	WhTypeSkill     = "skill"
 	WhTypeCareer    = "career"
 	WhTypeCharacter = "character"
+	WhTypeSpecies   = "species"
 	WhTypeOther     = "other"
 )
 
@@ -36,6 +48,7 @@ var WhApiTypes = []WhType{
 	WhTypeSkill,
 	WhTypeCareer,
 	WhTypeCharacter,
+	WhTypeSpecies,
 }
 
 func NewApiWh(t WhType) (Wh, error) {
@@ -58,6 +71,8 @@ func NewApiWh(t WhType) (Wh, error) {
 		wh.Object = &WhCareer{}
 	case WhTypeCharacter:
 		wh.Object = &WhCharacter{}
+	case WhTypeSpecies:
+		wh.Object = &WhSpecies{}
 	default:
 		return wh, fmt.Errorf("invalid Wh type %s", t)
 	}
@@ -67,28 +82,71 @@ func NewApiWh(t WhType) (Wh, error) {
 
 func (w Wh) InitAndCopy() Wh {
 	return Wh{
-		Id:      strings.Clone(w.Id),
-		OwnerId: strings.Clone(w.OwnerId),
-		CanEdit: w.CanEdit,
-		Object:  w.Object.InitAndCopy(),
+		Id:             strings.Clone(w.Id),
+		OwnerId:        strings.Clone(w.OwnerId),
+		CanEdit:        w.CanEdit,
+		Version:        w.Version,
+		CreatedAt:      w.CreatedAt.UTC(),
+		UpdatedAt:      w.UpdatedAt.UTC(),
+		LastModifiedBy: strings.Clone(w.LastModifiedBy),
+		DeletedAt:      copyTimePointer(w.DeletedAt),
+		Hidden:         w.Hidden,
+		HiddenBy:       strings.Clone(w.HiddenBy),
+		HiddenReason:   strings.Clone(w.HiddenReason),
+		Translations:   w.Translations.InitAndCopy(),
+		Object:         w.Object.InitAndCopy(),
 	}
 }
 
 func (w Wh) CopyHeaders() Wh {
 	return Wh{
-		Id:      strings.Clone(w.Id),
-		OwnerId: strings.Clone(w.OwnerId),
-		CanEdit: w.CanEdit,
+		Id:             strings.Clone(w.Id),
+		OwnerId:        strings.Clone(w.OwnerId),
+		CanEdit:        w.CanEdit,
+		Version:        w.Version,
+		CreatedAt:      w.CreatedAt.UTC(),
+		UpdatedAt:      w.UpdatedAt.UTC(),
+		LastModifiedBy: strings.Clone(w.LastModifiedBy),
+		DeletedAt:      copyTimePointer(w.DeletedAt),
+		Hidden:         w.Hidden,
+		HiddenBy:       strings.Clone(w.HiddenBy),
+		HiddenReason:   strings.Clone(w.HiddenReason),
+		Translations:   w.Translations.InitAndCopy(),
 	}
 }
 
+func copyTimePointer(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	cpy := t.UTC()
+	return &cpy
+}
+
+func (w Wh) IsDeleted() bool {
+	return w.DeletedAt != nil
+}
+
+// ETag returns the version as a strong entity tag. Objects stored before versioning was introduced have version 0.
+func (w Wh) ETag() string {
+	return fmt.Sprintf("\"%d\"", w.Version)
+}
+
 func (w Wh) PointToCopy() *Wh {
 	cpy := w.InitAndCopy()
 	return &cpy
 }
 
+// IsShared reports whether the object is visible to linked users. Hidden objects are never shared.
 func (w Wh) IsShared() bool {
-	return w.Object.IsShared()
+	return w.Object.IsShared() && !w.Hidden
+}
+
+// WhChanges lists objects of one type changed since a point in time, deleted objects included. Removed holds ids of
+// objects the caller may no longer see, because they were un-shared, hidden or deleted together with their owner.
+type WhChanges struct {
+	Changed []*Wh
+	Removed []string
 }
 
 type WhObject interface {
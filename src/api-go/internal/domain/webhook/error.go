@@ -0,0 +1,23 @@
+package webhook
+
+import "fmt"
+
+const (
+	WebhookInvalidArgumentsError = iota
+	WebhookNotFoundError
+	WebhookInternalError
+	WebhookUnauthorizedError
+)
+
+type WebhookError struct {
+	Type int
+	Err  error
+}
+
+func (e *WebhookError) Unwrap() error {
+	return e.Err
+}
+
+func (e *WebhookError) Error() string {
+	return fmt.Sprintf("webhook error, %s", e.Err)
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"time"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, c *domain.Claims, w *Webhook) (*Webhook, *WebhookError)
+	Get(ctx context.Context, c *domain.Claims, id string) (*Webhook, *WebhookError)
+	List(ctx context.Context, c *domain.Claims) ([]*Webhook, *WebhookError)
+	Update(ctx context.Context, c *domain.Claims, w *Webhook) (*Webhook, *WebhookError)
+	Delete(ctx context.Context, c *domain.Claims, id string) *WebhookError
+	ListDeliveries(ctx context.Context, c *domain.Claims, id string, limit int) ([]*Delivery, *WebhookError)
+	Publish(ctx context.Context, e *Event) *WebhookError
+	// DeleteByOwner removes all webhooks of a deleted user together with their deliveries.
+	DeleteByOwner(ctx context.Context, ownerId string) *WebhookError
+}
+
+type WebhookDbService interface {
+	Create(ctx context.Context, w *Webhook) (*Webhook, *domain.DbError)
+	Update(ctx context.Context, w *Webhook) (*Webhook, *domain.DbError)
+	Retrieve(ctx context.Context, id string) (*Webhook, *domain.DbError)
+	RetrieveByOwner(ctx context.Context, ownerId string) ([]*Webhook, *domain.DbError)
+	RetrieveByEventType(ctx context.Context, eventType string) ([]*Webhook, *domain.DbError)
+	Delete(ctx context.Context, id string) *domain.DbError
+}
+
+type DeliveryDbService interface {
+	Create(ctx context.Context, d *Delivery) (*Delivery, *domain.DbError)
+	Update(ctx context.Context, d *Delivery) (*Delivery, *domain.DbError)
+	Retrieve(ctx context.Context, webhookId string, limit int) ([]*Delivery, *domain.DbError)
+	// ClaimDue returns the pending delivery that is due the longest and moves its NextAttemptAt to leaseUntil, so that
+	// other workers skip it while it is being delivered. Returns DbNotFoundError if nothing is due.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*Delivery, *domain.DbError)
+	DeleteAll(ctx context.Context, webhookId string) *domain.DbError
+	DeleteByOwner(ctx context.Context, ownerId string) *domain.DbError
+}
@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"strings"
+	"time"
+)
+
+const (
+	EventTypeCareerAdvanced = "character.career_advanced"
+	EventTypeUserLinked     = "user.linked"
+)
+
+// WhEventType returns the event type of a change of a warhammer object, e.g. wh.item.created.
+func WhEventType(t warhammer.WhType, whEventType string) string {
+	return fmt.Sprintf("wh.%s.%s", t, whEventType)
+}
+
+// EventTypes lists all event types webhooks can subscribe to.
+func EventTypes() []string {
+	whEvents := []string{warhammer.WhEventCreated, warhammer.WhEventUpdated, warhammer.WhEventDeleted, warhammer.WhEventRestored}
+
+	types := make([]string, 0, len(warhammer.WhApiTypes)*len(whEvents)+2)
+	for _, t := range warhammer.WhApiTypes {
+		for _, e := range whEvents {
+			types = append(types, WhEventType(t, e))
+		}
+	}
+	return append(types, EventTypeCareerAdvanced, EventTypeUserLinked)
+}
+
+type Webhook struct {
+	Id         string
+	OwnerId    string
+	Url        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w Webhook) Copy() Webhook {
+	eventTypes := make([]string, len(w.EventTypes))
+	copy(eventTypes, w.EventTypes)
+
+	return Webhook{
+		Id:         strings.Clone(w.Id),
+		OwnerId:    strings.Clone(w.OwnerId),
+		Url:        strings.Clone(w.Url),
+		Secret:     strings.Clone(w.Secret),
+		EventTypes: eventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt.UTC(),
+		UpdatedAt:  w.UpdatedAt.UTC(),
+	}
+}
+
+func (w Webhook) PointToCopy() *Webhook {
+	cpy := w.Copy()
+	return &cpy
+}
+
+func (w Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.EventTypes, eventType)
+}
+
+// Event is delivered to webhooks subscribed to its type whose owners are allowed to see it, following the visibility
+// rules of warhammer objects: events owned by admin are public, shared events are visible to linked accounts.
+type Event struct {
+	Id        string
+	Type      string
+	Timestamp time.Time
+	OwnerId   string
+	Shared    bool
+	Data      any
+}
+
+func (e Event) IsVisibleTo(userId string, sharedAccounts []string) bool {
+	if e.OwnerId == "admin" || e.OwnerId == userId {
+		return true
+	}
+	return e.Shared && slices.Contains(sharedAccounts, e.OwnerId)
+}
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Delivery is a single event queued for a single webhook. Pending deliveries are picked up once NextAttemptAt passes,
+// which makes the delivery table a persistent queue that survives restarts.
+type Delivery struct {
+	Id             string
+	WebhookId      string
+	OwnerId        string
+	EventId        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	ResponseStatus int
+	Error          string
+}
+
+func (d Delivery) Copy() Delivery {
+	return Delivery{
+		Id:             strings.Clone(d.Id),
+		WebhookId:      strings.Clone(d.WebhookId),
+		OwnerId:        strings.Clone(d.OwnerId),
+		EventId:        strings.Clone(d.EventId),
+		EventType:      strings.Clone(d.EventType),
+		Payload:        strings.Clone(d.Payload),
+		Status:         strings.Clone(d.Status),
+		Attempts:       d.Attempts,
+		CreatedAt:      d.CreatedAt.UTC(),
+		NextAttemptAt:  d.NextAttemptAt.UTC(),
+		LastAttemptAt:  d.LastAttemptAt.UTC(),
+		ResponseStatus: d.ResponseStatus,
+		Error:          strings.Clone(d.Error),
+	}
+}
+
+func (d Delivery) PointToCopy() *Delivery {
+	cpy := d.Copy()
+	return &cpy
+}
@@ -0,0 +1,462 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Route struct {
+	Method string
+	Path   string
+}
+
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+}
+
+// Operation documents a single registered route. Request, Form and Response are example values whose Go types are
+// converted to schemas.
+type Operation struct {
+	Summary     string
+	Tag         string
+	Auth        bool
+	Params      []Param
+	Request     any
+	Form        any
+	Response    any
+	RawResponse bool
+}
+
+// Array documents a list of Items.
+type Array struct {
+	Items any
+}
+
+// WithField documents Base with the schema of one of its fields replaced, which is used to narrow interface typed
+// fields such as the object of a Wh.
+type WithField struct {
+	Base  any
+	Field string
+	Value any
+}
+
+// Object documents a free-form object with values of the given type.
+type Object struct {
+	Values any
+}
+
+type generator struct {
+	aliases map[string]string
+	schemas map[string]any
+}
+
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z]+)`)
+
+// Generate builds an OpenAPI 3 document. Every route has to be documented by an operation keyed by method and path,
+// e.g. "GET api/wh/item/:whId", and every operation has to match a route.
+func Generate(title string, version string, routes []Route, ops map[string]*Operation, aliases map[string]string) ([]byte, error) {
+	g := &generator{aliases: aliases, schemas: map[string]any{}}
+
+	g.schemas["Error"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{"type": "string"},
+			"details": map[string]any{"type": "string"},
+		},
+		"required": []string{"message", "details"},
+	}
+
+	documented := map[string]bool{}
+	paths := map[string]map[string]any{}
+	var undocumented []string
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		op, ok := ops[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			continue
+		}
+		documented[key] = true
+
+		path := "/" + pathParamPattern.ReplaceAllString(r.Path, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(r.Method)] = g.operation(r, op)
+	}
+
+	var stale []string
+	for key := range ops {
+		if !documented[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	if len(undocumented) != 0 || len(stale) != 0 {
+		sort.Strings(undocumented)
+		sort.Strings(stale)
+		return nil, fmt.Errorf("undocumented routes: [%s], operations without routes: [%s]",
+			strings.Join(undocumented, ", "), strings.Join(stale, ", "))
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": title, "version": version},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func (g *generator) operation(r Route, op *Operation) map[string]any {
+	operation := map[string]any{
+		"summary":     op.Summary,
+		"operationId": operationId(r),
+		"tags":        []string{op.Tag},
+	}
+
+	if op.Auth {
+		// Routes behind RequireJwt fall back to anonymous access when the token is missing.
+		operation["security"] = []any{map[string]any{}, map[string]any{"bearerAuth": []string{}}}
+	}
+
+	params := make([]any, 0)
+	for _, m := range pathParamPattern.FindAllStringSubmatch(r.Path, -1) {
+		params = append(params, map[string]any{"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+	}
+	for _, p := range op.Params {
+		param := map[string]any{"name": p.Name, "in": p.In, "required": p.Required, "schema": map[string]any{"type": "string"}}
+		if p.Description != "" {
+			param["description"] = p.Description
+		}
+		params = append(params, param)
+	}
+	if len(params) != 0 {
+		operation["parameters"] = params
+	}
+
+	if op.Request != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": g.schemaOfValue(op.Request)}},
+		}
+	} else if op.Form != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/x-www-form-urlencoded": map[string]any{"schema": g.schemaOfValue(op.Form)}},
+		}
+	}
+
+	var okSchema any = map[string]any{}
+	if op.Response != nil {
+		okSchema = g.schemaOfValue(op.Response)
+	}
+	if !op.RawResponse {
+		okSchema = map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"data": okSchema},
+			"required":   []string{"data"},
+		}
+	}
+
+	errorResp := map[string]any{
+		"description": "error",
+		"content":     map[string]any{"application/json": map[string]any{"schema": ref("Error")}},
+	}
+	operation["responses"] = map[string]any{
+		"200": map[string]any{
+			"description": "success",
+			"content":     map[string]any{"application/json": map[string]any{"schema": okSchema}},
+		},
+		"default": errorResp,
+	}
+
+	return operation
+}
+
+func operationId(r Route) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(r.Method))
+	for _, part := range strings.FieldsFunc(r.Path, func(c rune) bool { return c == '/' || c == '_' || c == '.' }) {
+		if part == "api" {
+			continue
+		}
+		if strings.HasPrefix(part, ":") {
+			b.WriteString("By")
+			part = part[1:]
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func (g *generator) schemaOfValue(v any) map[string]any {
+	switch value := v.(type) {
+	case Array:
+		return map[string]any{"type": "array", "items": g.schemaOfValue(value.Items)}
+	case Object:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaOfValue(value.Values)}
+	case WithField:
+		return map[string]any{"allOf": []any{
+			g.schemaOfValue(value.Base),
+			map[string]any{"type": "object", "properties": map[string]any{value.Field: g.schemaOfValue(value.Value)}},
+		}}
+	default:
+		return g.schemaOf(reflect.TypeOf(v), "")
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *generator) schemaOf(t reflect.Type, tag string) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	containerRules, itemTag, keyTag := splitDive(g.expand(tag))
+
+	var schema map[string]any
+	switch {
+	case t == timeType:
+		schema = map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		schema = ref(g.registerStruct(t))
+	case t.Kind() == reflect.Struct:
+		schema = g.structSchema(t)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		schema = map[string]any{"type": "string", "format": "byte"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		schema = map[string]any{"type": "array", "items": g.schemaOf(t.Elem(), itemTag)}
+	case t.Kind() == reflect.Map:
+		schema = map[string]any{"type": "object", "additionalProperties": g.schemaOf(t.Elem(), itemTag)}
+		if keyTag != "" {
+			schema["x-property-names"] = g.schemaOf(t.Key(), keyTag)
+		}
+	case t.Kind() == reflect.Interface:
+		schema = map[string]any{}
+	case t.Kind() == reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema = map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema = map[string]any{"type": "number"}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+
+	if _, isRef := schema["$ref"]; isRef {
+		if nullable {
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		return schema
+	}
+
+	applyRules(schema, containerRules)
+	if nullable {
+		schema["nullable"] = true
+	}
+	return schema
+}
+
+func (g *generator) registerStruct(t reflect.Type) string {
+	name := schemaName(t)
+	if _, ok := g.schemas[name]; ok {
+		return name
+	}
+	// Placeholder guards against infinite recursion on self referencing types.
+	g.schemas[name] = map[string]any{}
+	g.schemas[name] = g.structSchema(t)
+	return name
+}
+
+// schemaName capitalizes names of unexported types used only to document handler responses and drops their Doc
+// suffix.
+func schemaName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "Doc")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func (g *generator) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := make([]string, 0)
+	g.addFields(t, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *generator) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.addFields(f.Type, properties, required)
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		properties[name] = g.schemaOf(f.Type, f.Tag.Get("validate"))
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// expand replaces validator aliases with the rules they stand for.
+func (g *generator) expand(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+
+	rules := make([]string, 0)
+	for _, rule := range strings.Split(tag, ",") {
+		if alias, ok := g.aliases[rule]; ok {
+			rules = append(rules, g.expand(alias)...)
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// splitDive separates rules applying to a container from rules applying to its items and map keys.
+func splitDive(rules []string) ([]string, string, string) {
+	for i, rule := range rules {
+		if rule != "dive" {
+			continue
+		}
+
+		rest := rules[i+1:]
+		var keyRules []string
+		if len(rest) > 0 && rest[0] == "keys" {
+			for j, r := range rest {
+				if r == "endkeys" {
+					keyRules = rest[1:j]
+					rest = rest[j+1:]
+					break
+				}
+			}
+		}
+		return rules[:i], strings.Join(rest, ","), strings.Join(keyRules, ",")
+	}
+	return rules, "", ""
+}
+
+func applyRules(schema map[string]any, rules []string) {
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "min", "gte":
+			setBound(schema, param, "minLength", "minimum", "minItems", "minProperties")
+		case "max", "lte":
+			setBound(schema, param, "maxLength", "maximum", "maxItems", "maxProperties")
+		case "len":
+			setBound(schema, param, "minLength", "minimum", "minItems", "minProperties")
+			setBound(schema, param, "maxLength", "maximum", "maxItems", "maxProperties")
+		case "gt":
+			setBound(schema, param, "minLength", "minimum", "minItems", "minProperties")
+			schema["exclusiveMinimum"] = true
+		case "lt":
+			setBound(schema, param, "maxLength", "maximum", "maxItems", "maxProperties")
+			schema["exclusiveMaximum"] = true
+		case "oneof":
+			schema["enum"] = enumValues(schema, strings.Fields(param))
+		case "hexadecimal":
+			schema["pattern"] = "^[0-9a-fA-F]*$"
+		case "excludesall":
+			schema["pattern"] = "^[^" + regexp.QuoteMeta(param) + "]*$"
+		case "email":
+			schema["format"] = "email"
+		case "bcp47_language_tag":
+			schema["format"] = "bcp47"
+		case "wh_enum":
+			schema["x-wh-enum"] = param
+		}
+	}
+}
+
+func setBound(schema map[string]any, param string, stringKey string, numberKey string, arrayKey string, objectKey string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema["type"] {
+	case "string":
+		schema[stringKey] = int(value)
+	case "integer":
+		schema[numberKey] = int(value)
+	case "number":
+		schema[numberKey] = value
+	case "array":
+		schema[arrayKey] = int(value)
+	case "object":
+		schema[objectKey] = int(value)
+	}
+}
+
+// enumValues sorts values, because some of the oneof rules are built from maps and the specification has to be stable.
+func enumValues(schema map[string]any, values []string) []any {
+	enum := make([]any, len(values))
+	if schema["type"] == "integer" {
+		ints := make([]int, 0, len(values))
+		for _, v := range values {
+			if intValue, err := strconv.Atoi(v); err == nil {
+				ints = append(ints, intValue)
+			}
+		}
+		if len(ints) == len(values) {
+			sort.Ints(ints)
+			for i, v := range ints {
+				enum[i] = v
+			}
+			return enum
+		}
+	}
+
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	for i, v := range sorted {
+		enum[i] = v
+	}
+	return enum
+}
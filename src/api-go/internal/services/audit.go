@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"github.com/rs/xid"
+	"log"
+	"time"
+)
+
+const auditMaxLimit = 1000
+
+type AuditService struct {
+	AuditDbService audit.AuditDbService
+}
+
+func NewAuditService(db audit.AuditDbService) *AuditService {
+	return &AuditService{AuditDbService: db}
+}
+
+func (s *AuditService) Record(ctx context.Context, e *audit.Event) *audit.AuditError {
+	newEvent := e.Copy()
+	newEvent.Id = hex.EncodeToString(xid.New().Bytes())
+	if newEvent.Timestamp.IsZero() {
+		newEvent.Timestamp = time.Now()
+	}
+
+	if _, dbErr := s.AuditDbService.Create(ctx, &newEvent); dbErr != nil {
+		return &audit.AuditError{Type: audit.AuditInternalError, Err: dbErr}
+	}
+
+	return nil
+}
+
+func (s *AuditService) Get(ctx context.Context, c *domain.Claims, q *audit.Query) ([]*audit.Event, *audit.AuditError) {
+	if c.Id == "anonymous" {
+		return nil, &audit.AuditError{Type: audit.AuditUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	query := *q
+	if !c.Can(domain.PermissionManageUsers) {
+		ownerId := whOwner(c)
+		if query.OwnerId != "" && query.OwnerId != c.Id && query.OwnerId != ownerId {
+			return nil, &audit.AuditError{Type: audit.AuditUnauthorizedError, Err: errors.New("unauthorized")}
+		}
+		if query.OwnerId == "" {
+			query.OwnerId = ownerId
+		}
+	}
+
+	if query.Limit <= 0 || query.Limit > auditMaxLimit {
+		query.Limit = auditMaxLimit
+	}
+
+	events, dbErr := s.AuditDbService.Retrieve(ctx, &query)
+	if dbErr != nil {
+		return nil, &audit.AuditError{Type: audit.AuditInternalError, Err: dbErr}
+	}
+
+	return events, nil
+}
+
+func recordAudit(ctx context.Context, as audit.AuditService, actorId string, eventType string, objectType string, objectId string, ownerId string, before any, after any) {
+	if as == nil {
+		return
+	}
+
+	changes, err := domain.Diff(before, after)
+	if err != nil {
+		log.Printf("error computing audit diff for %s %s: %s", objectType, objectId, err)
+		changes = []domain.FieldChange{}
+	}
+
+	e := audit.Event{
+		ActorId:    actorId,
+		Type:       eventType,
+		ObjectType: objectType,
+		ObjectId:   objectId,
+		OwnerId:    ownerId,
+		Changes:    changes,
+	}
+
+	if aErr := as.Record(ctx, &e); aErr != nil {
+		log.Printf("error recording audit event %s for %s %s: %s", eventType, objectType, objectId, aErr)
+	}
+}
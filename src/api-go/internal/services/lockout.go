@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"log"
+	"strings"
+	"time"
+)
+
+type LockoutService struct {
+	AttemptDbService    lockout.AttemptDbService
+	UserDbService       user.UserDbService
+	EmailService        domain.EmailService
+	AccountFreeAttempts int
+	AccountMaxFailures  int
+	AddressFreeAttempts int
+	AddressMaxFailures  int
+	BaseDelay           time.Duration
+	MaxDelay            time.Duration
+	LockoutDuration     time.Duration
+	FailureWindow       time.Duration
+}
+
+func NewLockoutService(cfg *config.Lockout, db lockout.AttemptDbService, udb user.UserDbService, email domain.EmailService) *LockoutService {
+	return &LockoutService{
+		AttemptDbService:    db,
+		UserDbService:       udb,
+		EmailService:        email,
+		AccountFreeAttempts: cfg.AccountFreeAttempts,
+		AccountMaxFailures:  cfg.AccountMaxFailures,
+		AddressFreeAttempts: cfg.AddressFreeAttempts,
+		AddressMaxFailures:  cfg.AddressMaxFailures,
+		BaseDelay:           cfg.BaseDelay,
+		MaxDelay:            cfg.MaxDelay,
+		LockoutDuration:     cfg.LockoutDuration,
+		FailureWindow:       cfg.FailureWindow,
+	}
+}
+
+// backoff is the wait after the last of failures. The first freeAttempts failures are not delayed, each further one
+// doubles the delay up to MaxDelay, and from maxFailures on the key is locked for LockoutDuration.
+func (s *LockoutService) backoff(failures int, freeAttempts int, maxFailures int) time.Duration {
+	switch {
+	case failures >= maxFailures:
+		return s.LockoutDuration
+	case failures < freeAttempts:
+		return 0
+	}
+
+	exponent := failures - freeAttempts
+	if exponent > 30 {
+		return s.MaxDelay
+	}
+	delay := s.BaseDelay << exponent
+	if delay <= 0 || delay > s.MaxDelay {
+		return s.MaxDelay
+	}
+	return delay
+}
+
+func (s *LockoutService) keys(username string, address string) []string {
+	keys := make([]string, 0, 2)
+	if username != "" {
+		keys = append(keys, lockout.AccountKeyPrefix+username)
+	}
+	if address != "" {
+		keys = append(keys, lockout.AddressKeyPrefix+address)
+	}
+	return keys
+}
+
+func (s *LockoutService) thresholds(key string) (int, int) {
+	if strings.HasPrefix(key, lockout.AccountKeyPrefix) {
+		return s.AccountFreeAttempts, s.AccountMaxFailures
+	}
+	return s.AddressFreeAttempts, s.AddressMaxFailures
+}
+
+func (s *LockoutService) Begin(ctx context.Context, username string, address string) (time.Duration, *lockout.LockoutError) {
+	now := time.Now()
+
+	var wait time.Duration
+	recorded := make([]string, 0, 2)
+	for _, key := range s.keys(username, address) {
+		previous, dbErr := s.AttemptDbService.Increment(ctx, key, now, s.FailureWindow)
+		if dbErr != nil {
+			s.takeBack(ctx, recorded)
+			return 0, &lockout.LockoutError{Type: lockout.LockoutInternalError, Err: dbErr}
+		}
+		recorded = append(recorded, key)
+
+		if previous.Failures == 0 {
+			continue
+		}
+
+		// Attempts still being checked count as failures, so a burst of parallel attempts waits like consecutive ones.
+		freeAttempts, maxFailures := s.thresholds(key)
+		if w := previous.LastFailure.Add(s.backoff(previous.Failures, freeAttempts, maxFailures)).Sub(now); w > wait {
+			wait = w
+		}
+	}
+
+	if wait > 0 {
+		s.takeBack(ctx, recorded)
+	}
+
+	return wait, nil
+}
+
+// takeBack removes the failures recorded for keys by Begin, errors are only logged since the failures expire anyway.
+func (s *LockoutService) takeBack(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if dbErr := s.AttemptDbService.Decrement(ctx, key); dbErr != nil {
+			log.Printf("error taking back sign-in attempt: %s", dbErr)
+		}
+	}
+}
+
+func (s *LockoutService) Fail(ctx context.Context, username string, address string) *lockout.LockoutError {
+	for _, key := range s.keys(username, address) {
+		attempts, dbErr := s.AttemptDbService.Retrieve(ctx, key)
+		if dbErr != nil {
+			if dbErr.Type == domain.DbNotFoundError {
+				continue
+			}
+			return &lockout.LockoutError{Type: lockout.LockoutInternalError, Err: dbErr}
+		}
+
+		if _, maxFailures := s.thresholds(key); attempts.Failures != maxFailures {
+			continue
+		}
+
+		if strings.HasPrefix(key, lockout.AccountKeyPrefix) {
+			s.notifyLocked(ctx, username, attempts.Failures)
+		} else {
+			log.Printf("client address %s locked out after %d failed sign-in attempts", address, attempts.Failures)
+		}
+	}
+
+	return nil
+}
+
+// notifyLocked emails the owner of a locked account. Unknown usernames are locked too, but there is nobody to notify.
+func (s *LockoutService) notifyLocked(ctx context.Context, username string, failures int) {
+	u, dbErr := s.UserDbService.Retrieve(ctx, "username", username)
+	if dbErr != nil {
+		if dbErr.Type != domain.DbNotFoundError {
+			log.Printf("error retrieving locked out user: %s", dbErr)
+		}
+		return
+	}
+
+	email := domain.Email{
+		ToAddress: u.Username,
+		Subject:   "Account locked",
+		Content: fmt.Sprintf("Sign-in to your account was locked for %s after %d failed attempts. If this was not you, "+
+			"please consider changing your password.", s.LockoutDuration, failures),
+	}
+
+	if err := s.EmailService.Send(ctx, &email); err != nil {
+		log.Printf("error sending lockout notification to user %s: %s", u.Id, err)
+	}
+}
+
+func (s *LockoutService) Succeed(ctx context.Context, username string, address string) *lockout.LockoutError {
+	if username != "" {
+		if dbErr := s.AttemptDbService.Delete(ctx, lockout.AccountKeyPrefix+username); dbErr != nil {
+			return &lockout.LockoutError{Type: lockout.LockoutInternalError, Err: dbErr}
+		}
+	}
+	return s.Release(ctx, "", address)
+}
+
+func (s *LockoutService) Release(ctx context.Context, username string, address string) *lockout.LockoutError {
+	for _, key := range s.keys(username, address) {
+		if dbErr := s.AttemptDbService.Decrement(ctx, key); dbErr != nil {
+			return &lockout.LockoutError{Type: lockout.LockoutInternalError, Err: dbErr}
+		}
+	}
+	return nil
+}
+
+// StartPruning removes attempts older than FailureWindow every interval until ctx is cancelled.
+func (s *LockoutService) StartPruning(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if dbErr := s.AttemptDbService.DeleteBefore(ctx, time.Now().Add(-s.FailureWindow)); dbErr != nil {
+					log.Printf("error pruning sign-in attempts: %s", dbErr)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/lockout"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLockoutService() *LockoutService {
+	cfg := config.Lockout{AccountFreeAttempts: 3, AccountMaxFailures: 10, AddressFreeAttempts: 20, AddressMaxFailures: 100,
+		BaseDelay: time.Second, MaxDelay: time.Minute, LockoutDuration: time.Hour, FailureWindow: time.Hour}
+	return NewLockoutService(&cfg, memdb.NewAttemptDbService(), memdb.NewUserDbService(), nil)
+}
+
+func TestLockoutLetsOnlyFreeAttemptsOfBurstPass(t *testing.T) {
+	s := newTestLockoutService()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	passed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait, lErr := s.Begin(context.Background(), "user@test.com", "")
+			if lErr != nil {
+				t.Error(lErr)
+				return
+			}
+			if wait == 0 {
+				mu.Lock()
+				passed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if passed != s.AccountFreeAttempts {
+		t.Fatalf("expected %d attempts to pass, got %d", s.AccountFreeAttempts, passed)
+	}
+
+	attempts, dbErr := s.AttemptDbService.Retrieve(context.Background(), lockout.AccountKeyPrefix+"user@test.com")
+	if dbErr != nil {
+		t.Fatal(dbErr)
+	}
+	if attempts.Failures != passed {
+		t.Fatalf("rejected attempts were counted, %d failures", attempts.Failures)
+	}
+}
+
+func TestLockoutTakesBackAttemptsThatDidNotFail(t *testing.T) {
+	s := newTestLockoutService()
+	ctx := context.Background()
+
+	for i := 0; i < s.AccountFreeAttempts+2; i++ {
+		if wait, _ := s.Begin(ctx, "user@test.com", "127.0.0.1"); wait != 0 {
+			t.Fatalf("attempt %d had to wait %s", i, wait)
+		}
+		if lErr := s.Release(ctx, "user@test.com", "127.0.0.1"); lErr != nil {
+			t.Fatal(lErr)
+		}
+	}
+
+	if wait, _ := s.Begin(ctx, "user@test.com", "127.0.0.1"); wait != 0 {
+		t.Fatalf("released attempts were counted, wait %s", wait)
+	}
+	if lErr := s.Succeed(ctx, "user@test.com", "127.0.0.1"); lErr != nil {
+		t.Fatal(lErr)
+	}
+
+	if _, dbErr := s.AttemptDbService.Retrieve(ctx, lockout.AccountKeyPrefix+"user@test.com"); dbErr == nil {
+		t.Errorf("failures of account kept after success")
+	}
+	if attempts, _ := s.AttemptDbService.Retrieve(ctx, lockout.AddressKeyPrefix+"127.0.0.1"); attempts != nil && attempts.Failures != 0 {
+		t.Errorf("attempt of address kept after success, %d failures", attempts.Failures)
+	}
+}
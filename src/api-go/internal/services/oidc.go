@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/exp/slices"
+	"time"
+)
+
+const (
+	oidcRandomBytes        = 32
+	oidcUserPasswordBytes  = 32
+	oidcMaxLoginHintLength = 254
+)
+
+// OidcService signs users in with external identity providers using the authorization code flow with PKCE. The
+// code verifier never leaves the api, the client only relays the authorization code and state.
+type OidcService struct {
+	IdentityProviders   map[string]oidc.Provider
+	LinkDbService       oidc.LinkDbService
+	LoginStateDbService oidc.LoginStateDbService
+	UserDbService       user.UserDbService
+	UserService         user.UserService
+	StateExpiry         time.Duration
+}
+
+func NewOidcService(cfg *config.Oidc, providers map[string]oidc.Provider, ldb oidc.LinkDbService, sdb oidc.LoginStateDbService,
+	udb user.UserDbService, us user.UserService) *OidcService {
+	return &OidcService{
+		IdentityProviders:   providers,
+		LinkDbService:       ldb,
+		LoginStateDbService: sdb,
+		UserDbService:       udb,
+		UserService:         us,
+		StateExpiry:         cfg.StateExpiry,
+	}
+}
+
+func (s *OidcService) Providers() []string {
+	names := make([]string, 0, len(s.IdentityProviders))
+	for name := range s.IdentityProviders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *OidcService) Authorize(ctx context.Context, provider string, loginHint string) (string, string, *oidc.OidcError) {
+	p, ok := s.IdentityProviders[provider]
+	if !ok {
+		return "", "", &oidc.OidcError{Type: oidc.OidcProviderNotFoundError, Err: fmt.Errorf("unknown provider %s", provider)}
+	}
+
+	if len(loginHint) > oidcMaxLoginHintLength {
+		return "", "", &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: errors.New("login hint too long")}
+	}
+
+	values, err := randomUrlSafeStrings(3)
+	if err != nil {
+		return "", "", &oidc.OidcError{Type: oidc.OidcInternalError, Err: err}
+	}
+	state := oidc.LoginState{
+		State:        values[0],
+		Provider:     provider,
+		CodeVerifier: values[1],
+		Nonce:        values[2],
+		ExpiresAt:    time.Now().Add(s.StateExpiry),
+	}
+
+	challenge := sha256.Sum256([]byte(state.CodeVerifier))
+	authUrl, err := p.AuthCodeUrl(ctx, state.State, state.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]), loginHint)
+	if err != nil {
+		return "", "", &oidc.OidcError{Type: oidc.OidcInternalError, Err: err}
+	}
+
+	if dbErr := s.LoginStateDbService.Create(ctx, &state); dbErr != nil {
+		return "", "", &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	return authUrl, state.State, nil
+}
+
+func randomUrlSafeStrings(n int) ([]string, error) {
+	values := make([]string, n)
+	for i := range values {
+		b := make([]byte, oidcRandomBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return values, nil
+}
+
+// exchange consumes the login state and returns the identity asserted by the provider.
+func (s *OidcService) exchange(ctx context.Context, provider string, code string, state string) (*oidc.Identity, *oidc.OidcError) {
+	p, ok := s.IdentityProviders[provider]
+	if !ok {
+		return nil, &oidc.OidcError{Type: oidc.OidcProviderNotFoundError, Err: fmt.Errorf("unknown provider %s", provider)}
+	}
+
+	if code == "" || state == "" {
+		return nil, &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: errors.New("missing code or state")}
+	}
+
+	st, dbErr := s.LoginStateDbService.Take(ctx, state)
+	if dbErr != nil {
+		if dbErr.Type == domain.DbNotFoundError {
+			return nil, &oidc.OidcError{Type: oidc.OidcInvalidStateError, Err: errors.New("unknown state")}
+		}
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	if st.Provider != provider || !time.Now().Before(st.ExpiresAt) {
+		return nil, &oidc.OidcError{Type: oidc.OidcInvalidStateError, Err: errors.New("state expired or issued for another provider")}
+	}
+
+	identity, err := p.Exchange(ctx, code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		return nil, &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: err}
+	}
+	identity.Provider = provider
+
+	return identity, nil
+}
+
+func (s *OidcService) Login(ctx context.Context, provider string, code string, state string, totpCode string) (*user.User, *oidc.OidcError) {
+	identity, oErr := s.exchange(ctx, provider, code, state)
+	if oErr != nil {
+		return nil, oErr
+	}
+
+	u, oErr := s.linkedUser(ctx, identity)
+	if oErr != nil {
+		return nil, oErr
+	}
+
+	if u == nil {
+		if identity.Email == "" || !identity.EmailVerified {
+			return nil, &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: errors.New("provider did not return a verified email")}
+		}
+
+		if u, oErr = s.userByEmail(ctx, identity.Email); oErr != nil {
+			return nil, oErr
+		}
+
+		if _, oErr = s.createLink(ctx, identity, u.Id); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	authenticated, uErr := s.UserService.AuthenticateTotp(ctx, u, totpCode)
+	if uErr != nil {
+		switch uErr.Type {
+		case user.UserTotpRequiredError:
+			return nil, &oidc.OidcError{Type: oidc.OidcTotpRequiredError, Err: uErr}
+		case user.UserIncorrectTotpError:
+			return nil, &oidc.OidcError{Type: oidc.OidcIncorrectTotpError, Err: uErr}
+		default:
+			return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: uErr}
+		}
+	}
+
+	return authenticated, nil
+}
+
+// linkedUser returns the user linked to identity or nil if there is none. Links of deleted users are removed.
+func (s *OidcService) linkedUser(ctx context.Context, identity *oidc.Identity) (*user.User, *oidc.OidcError) {
+	link, dbErr := s.LinkDbService.Retrieve(ctx, identity.Provider, identity.Subject)
+	if dbErr != nil {
+		if dbErr.Type == domain.DbNotFoundError {
+			return nil, nil
+		}
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", link.UserId)
+	if dbErr != nil {
+		if dbErr.Type != domain.DbNotFoundError {
+			return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+		}
+		if dbErr = s.LinkDbService.Delete(ctx, link.UserId, link.Provider); dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+			return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+		}
+		return nil, nil
+	}
+
+	return u, nil
+}
+
+// userByEmail returns the user whose username is email, creating one with a random password if there is none. The
+// password can be set later with the reset password flow. Unverified accounts are not returned, whoever registered
+// one may not own the address and would keep access through its password.
+func (s *OidcService) userByEmail(ctx context.Context, email string) (*user.User, *oidc.OidcError) {
+	u, dbErr := s.UserDbService.Retrieve(ctx, "username", email)
+	if dbErr == nil {
+		if !u.EmailVerified {
+			return nil, &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: errors.New("account with this email is not verified, sign in with password to link it")}
+		}
+		return u, nil
+	}
+	if dbErr.Type != domain.DbNotFoundError {
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	password := make([]byte, oidcUserPasswordBytes)
+	if _, err := rand.Read(password); err != nil {
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: err}
+	}
+
+	newUser := user.EmptyUser()
+	newUser.Username = email
+	newUser.Password = hex.EncodeToString(password)
+	newUser.EmailVerified = true
+
+	created, uErr := s.UserService.Create(ctx, &newUser)
+	if uErr != nil {
+		switch uErr.Type {
+		case user.UserInvalidArgumentsError:
+			return nil, &oidc.OidcError{Type: oidc.OidcInvalidArgumentsError, Err: uErr}
+		default:
+			return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: uErr}
+		}
+	}
+
+	return created, nil
+}
+
+func (s *OidcService) createLink(ctx context.Context, identity *oidc.Identity, userId string) (*oidc.Link, *oidc.OidcError) {
+	link := oidc.Link{
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		UserId:    userId,
+		Email:     identity.Email,
+		CreatedAt: time.Now(),
+	}
+
+	created, dbErr := s.LinkDbService.Create(ctx, &link)
+	if dbErr != nil {
+		if dbErr.Type == domain.DbAlreadyExistsError {
+			return nil, &oidc.OidcError{Type: oidc.OidcAlreadyLinkedError, Err: fmt.Errorf("user already has a linked identity of provider %s", identity.Provider)}
+		}
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	return created, nil
+}
+
+func (s *OidcService) Link(ctx context.Context, c *domain.Claims, provider string, code string, state string) (*oidc.Link, *oidc.OidcError) {
+	if c.Id == "anonymous" {
+		return nil, &oidc.OidcError{Type: oidc.OidcUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	identity, oErr := s.exchange(ctx, provider, code, state)
+	if oErr != nil {
+		return nil, oErr
+	}
+
+	link, dbErr := s.LinkDbService.Retrieve(ctx, identity.Provider, identity.Subject)
+	if dbErr == nil {
+		if link.UserId == c.Id {
+			return link, nil
+		}
+		return nil, &oidc.OidcError{Type: oidc.OidcAlreadyLinkedError, Err: errors.New("identity linked to another user")}
+	}
+	if dbErr.Type != domain.DbNotFoundError {
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	return s.createLink(ctx, identity, c.Id)
+}
+
+func (s *OidcService) ListLinks(ctx context.Context, c *domain.Claims) ([]*oidc.Link, *oidc.OidcError) {
+	if c.Id == "anonymous" {
+		return nil, &oidc.OidcError{Type: oidc.OidcUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	links, dbErr := s.LinkDbService.RetrieveByUser(ctx, c.Id)
+	if dbErr != nil {
+		return nil, &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	return links, nil
+}
+
+func (s *OidcService) Unlink(ctx context.Context, c *domain.Claims, provider string) *oidc.OidcError {
+	if c.Id == "anonymous" {
+		return &oidc.OidcError{Type: oidc.OidcUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	if dbErr := s.LinkDbService.Delete(ctx, c.Id, provider); dbErr != nil {
+		if dbErr.Type == domain.DbNotFoundError {
+			return &oidc.OidcError{Type: oidc.OidcNotFoundError, Err: dbErr}
+		}
+		return &oidc.OidcError{Type: oidc.OidcInternalError, Err: dbErr}
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/gooidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/mockoidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/validator"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/crypto/bcrypt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestOidcService connects the service to a mock provider registered under the names mock and other.
+func newTestOidcService(t *testing.T) *OidcService {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := mockoidc.NewServer(listener.Addr().(*net.TCPAddr).Port, "hammergen", "mock secret")
+	go func() { _ = server.Server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	newProvider := func() oidc.Provider {
+		return gooidc.NewProvider(server.Issuer, server.ClientId, server.ClientSecret, "http://localhost/oidc/callback")
+	}
+
+	udb := memdb.NewUserDbService()
+	v := validator.NewValidator(warhammer.NewWhEnumRegistry())
+	us := NewUserService(&config.UserService{BcryptCost: bcrypt.MinCost}, udb, nil, nil, v, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	return NewOidcService(&config.Oidc{StateExpiry: time.Minute}, map[string]oidc.Provider{"mock": newProvider(), "other": newProvider()},
+		memdb.NewOidcLinkDbService(), memdb.NewOidcLoginStateDbService(), udb, us)
+}
+
+// authorizeTestLogin starts the flow and follows the provider redirect, returning the authorization code and state.
+func authorizeTestLogin(t *testing.T, s *OidcService, email string) (string, string) {
+	authUrl, state, oErr := s.Authorize(context.Background(), "mock", email)
+	if oErr != nil {
+		t.Fatalf("authorizing: %s", oErr)
+	}
+
+	u, err := url.Parse(authUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Query().Get("code_challenge_method") != "S256" || u.Query().Get("code_challenge") == "" {
+		t.Fatalf("authorization url without PKCE challenge: %s", authUrl)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatalf("provider did not redirect: %s", err)
+	}
+	if location.Query().Get("state") != state {
+		t.Fatalf("provider returned state %q instead of %q", location.Query().Get("state"), state)
+	}
+
+	return location.Query().Get("code"), state
+}
+
+func createTestOidcUser(t *testing.T, s *OidcService, email string, verified bool) *user.User {
+	u := user.EmptyUser()
+	u.Username = email
+	u.Password = "password"
+	u.EmailVerified = true
+
+	created, uErr := s.UserService.Create(context.Background(), &u)
+	if uErr != nil {
+		t.Fatal(uErr)
+	}
+	if verified {
+		return created
+	}
+
+	created.EmailVerified = false
+	updated, dbErr := s.UserDbService.Update(context.Background(), created)
+	if dbErr != nil {
+		t.Fatal(dbErr)
+	}
+
+	return updated
+}
+
+func expectOidcError(t *testing.T, oErr *oidc.OidcError, errType int) {
+	t.Helper()
+	if oErr == nil || oErr.Type != errType {
+		t.Fatalf("expected oidc error type %d, got %v", errType, oErr)
+	}
+}
+
+func TestOidcLoginCreatesUserOnFirstLogin(t *testing.T) {
+	s := newTestOidcService(t)
+	ctx := context.Background()
+
+	code, state := authorizeTestLogin(t, s, "new@test.com")
+	u, oErr := s.Login(ctx, "mock", code, state, "")
+	if oErr != nil {
+		t.Fatal(oErr)
+	}
+	if u.Username != "new@test.com" || !u.EmailVerified {
+		t.Fatalf("expected new verified user, got %s verified %t", u.Username, u.EmailVerified)
+	}
+
+	links, _ := s.LinkDbService.RetrieveByUser(ctx, u.Id)
+	if len(links) != 1 || links[0].Provider != "mock" {
+		t.Fatalf("expected link to mock provider, got %v", links)
+	}
+
+	code, state = authorizeTestLogin(t, s, "new@test.com")
+	again, oErr := s.Login(ctx, "mock", code, state, "")
+	if oErr != nil {
+		t.Fatal(oErr)
+	}
+	if again.Id != u.Id {
+		t.Fatalf("second login returned user %s instead of %s", again.Id, u.Id)
+	}
+}
+
+func TestOidcLoginLinksExistingUser(t *testing.T) {
+	s := newTestOidcService(t)
+	ctx := context.Background()
+
+	verified := createTestOidcUser(t, s, "verified@test.com", true)
+	code, state := authorizeTestLogin(t, s, verified.Username)
+	u, oErr := s.Login(ctx, "mock", code, state, "")
+	if oErr != nil {
+		t.Fatal(oErr)
+	}
+	if u.Id != verified.Id {
+		t.Fatalf("expected existing user %s, got %s", verified.Id, u.Id)
+	}
+
+	unverified := createTestOidcUser(t, s, "unverified@test.com", false)
+	code, state = authorizeTestLogin(t, s, unverified.Username)
+	_, oErr = s.Login(ctx, "mock", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcInvalidArgumentsError)
+
+	if links, _ := s.LinkDbService.RetrieveByUser(ctx, unverified.Id); len(links) != 0 {
+		t.Fatalf("unverified user was linked")
+	}
+}
+
+func TestOidcLoginRejectsInvalidState(t *testing.T) {
+	s := newTestOidcService(t)
+	ctx := context.Background()
+
+	code, _ := authorizeTestLogin(t, s, "state@test.com")
+	_, oErr := s.Login(ctx, "mock", code, "unknown", "")
+	expectOidcError(t, oErr, oidc.OidcInvalidStateError)
+
+	code, state := authorizeTestLogin(t, s, "state@test.com")
+	_, oErr = s.Login(ctx, "other", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcInvalidStateError)
+
+	code, state = authorizeTestLogin(t, s, "state@test.com")
+	if _, oErr = s.Login(ctx, "mock", code, state, ""); oErr != nil {
+		t.Fatal(oErr)
+	}
+	_, oErr = s.Login(ctx, "mock", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcInvalidStateError)
+
+	s.StateExpiry = -time.Second
+	code, state = authorizeTestLogin(t, s, "state@test.com")
+	_, oErr = s.Login(ctx, "mock", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcInvalidStateError)
+}
+
+func TestOidcLoginRejectsCodeOfAnotherLogin(t *testing.T) {
+	s := newTestOidcService(t)
+
+	// The code is bound to the challenge of the first login, the verifier kept for the second one does not match it.
+	code, _ := authorizeTestLogin(t, s, "pkce@test.com")
+	_, state := authorizeTestLogin(t, s, "pkce@test.com")
+
+	_, oErr := s.Login(context.Background(), "mock", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcInvalidArgumentsError)
+}
+
+func TestOidcLoginRequiresTotp(t *testing.T) {
+	s := newTestOidcService(t)
+	ctx := context.Background()
+
+	key := []byte("12345678901234567890")
+	u := createTestOidcUser(t, s, "totp@test.com", true)
+	u.Totp = user.Totp{Secret: totpEncoding.EncodeToString(key), Enabled: true}
+	if _, dbErr := s.UserDbService.Update(ctx, u); dbErr != nil {
+		t.Fatal(dbErr)
+	}
+
+	code, state := authorizeTestLogin(t, s, u.Username)
+	_, oErr := s.Login(ctx, "mock", code, state, "")
+	expectOidcError(t, oErr, oidc.OidcTotpRequiredError)
+
+	code, state = authorizeTestLogin(t, s, u.Username)
+	_, oErr = s.Login(ctx, "mock", code, state, hotp(key, time.Now().Unix()/totpPeriod+10))
+	expectOidcError(t, oErr, oidc.OidcIncorrectTotpError)
+
+	code, state = authorizeTestLogin(t, s, u.Username)
+	authenticated, oErr := s.Login(ctx, "mock", code, state, hotp(key, time.Now().Unix()/totpPeriod+1))
+	if oErr != nil {
+		t.Fatal(oErr)
+	}
+	if authenticated.Id != u.Id {
+		t.Fatalf("expected user %s, got %s", u.Id, authenticated.Id)
+	}
+}
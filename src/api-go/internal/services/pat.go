@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"github.com/rs/xid"
+	"golang.org/x/exp/slices"
+	"log"
+	"strings"
+	"time"
+)
+
+const patSecretBytes = 32
+
+type PatService struct {
+	Validator     *validator.Validate
+	PatDbService  pat.PatDbService
+	UserDbService user.UserDbService
+	MaxPerUser    int
+	TouchInterval time.Duration
+}
+
+func NewPatService(cfg *config.Pat, v *validator.Validate, db pat.PatDbService, udb user.UserDbService) *PatService {
+	return &PatService{
+		Validator:     v,
+		PatDbService:  db,
+		UserDbService: udb,
+		MaxPerUser:    cfg.MaxPerUser,
+		TouchInterval: cfg.TouchInterval,
+	}
+}
+
+// canManage allows only users signed in with their own credentials to manage tokens, so that a leaked token can not be
+// used to mint new ones.
+func canManage(c *domain.Claims) bool {
+	return c.Id != "anonymous" && c.Scopes == nil
+}
+
+func (s *PatService) Create(ctx context.Context, c *domain.Claims, t *pat.Token) (*pat.Token, string, *pat.PatError) {
+	if !canManage(c) {
+		return nil, "", &pat.PatError{Type: pat.PatUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	newToken := t.Copy()
+	if err := validatePat(s.Validator, &newToken); err != nil {
+		return nil, "", &pat.PatError{Type: pat.PatInvalidArgumentsError, Err: err}
+	}
+
+	existing, dbErr := s.PatDbService.RetrieveByUser(ctx, c.Id)
+	if dbErr != nil {
+		return nil, "", &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+	}
+	if len(existing) >= s.MaxPerUser {
+		return nil, "", &pat.PatError{Type: pat.PatInvalidArgumentsError, Err: fmt.Errorf("at most %d tokens are allowed", s.MaxPerUser)}
+	}
+
+	secret, err := newPatSecret()
+	if err != nil {
+		return nil, "", &pat.PatError{Type: pat.PatInternalError, Err: err}
+	}
+
+	newToken.Id = hex.EncodeToString(xid.New().Bytes())
+	newToken.UserId = c.Id
+	newToken.TokenHash = hashPatSecret(secret)
+	newToken.CreatedAt = time.Now()
+	newToken.LastUsedAt = time.Time{}
+
+	createdToken, dbErr := s.PatDbService.Create(ctx, &newToken)
+	if dbErr != nil {
+		return nil, "", &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+	}
+
+	return createdToken, secret, nil
+}
+
+func validatePat(v *validator.Validate, t *pat.Token) error {
+	if err := v.Var(t.Name, "required,min=1,max=100"); err != nil {
+		return err
+	}
+
+	if len(t.Scopes) == 0 {
+		return errors.New("at least one scope is required")
+	}
+	scopes := pat.Scopes()
+	for _, scope := range t.Scopes {
+		if !slices.Contains(scopes, scope) {
+			return fmt.Errorf("invalid scope %s", scope)
+		}
+	}
+	slices.Sort(t.Scopes)
+	t.Scopes = slices.Compact(t.Scopes)
+
+	if !t.ExpiresAt.IsZero() && !t.ExpiresAt.After(time.Now()) {
+		return errors.New("expiry must be in the future")
+	}
+
+	return nil
+}
+
+func (s *PatService) List(ctx context.Context, c *domain.Claims) ([]*pat.Token, *pat.PatError) {
+	if !canManage(c) {
+		return nil, &pat.PatError{Type: pat.PatUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	tokens, dbErr := s.PatDbService.RetrieveByUser(ctx, c.Id)
+	if dbErr != nil {
+		return nil, &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+	}
+
+	return tokens, nil
+}
+
+func (s *PatService) Revoke(ctx context.Context, c *domain.Claims, id string) *pat.PatError {
+	if !canManage(c) {
+		return &pat.PatError{Type: pat.PatUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	if dbErr := s.PatDbService.Delete(ctx, c.Id, id); dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &pat.PatError{Type: pat.PatNotFoundError, Err: dbErr}
+		default:
+			return &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+		}
+	}
+
+	return nil
+}
+
+// Authenticate reads the owner of the token on every request, so that changes of shared accounts apply immediately.
+// Tokens never carry roles.
+func (s *PatService) Authenticate(ctx context.Context, secret string) (*domain.Claims, *pat.PatError) {
+	if !strings.HasPrefix(secret, pat.SecretPrefix) {
+		return nil, &pat.PatError{Type: pat.PatInvalidTokenError, Err: errors.New("malformed token")}
+	}
+
+	t, dbErr := s.PatDbService.RetrieveByHash(ctx, hashPatSecret(secret))
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &pat.PatError{Type: pat.PatInvalidTokenError, Err: dbErr}
+		default:
+			return nil, &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+		}
+	}
+
+	now := time.Now()
+	if t.IsExpired(now) {
+		return nil, &pat.PatError{Type: pat.PatInvalidTokenError, Err: errors.New("token expired")}
+	}
+
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", t.UserId)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &pat.PatError{Type: pat.PatInvalidTokenError, Err: dbErr}
+		default:
+			return nil, &pat.PatError{Type: pat.PatInternalError, Err: dbErr}
+		}
+	}
+
+	// Last use is only recorded once per TouchInterval to avoid a write on every request.
+	if now.Sub(t.LastUsedAt) >= s.TouchInterval {
+		if dbErr = s.PatDbService.Touch(ctx, t.Id, now); dbErr != nil {
+			log.Printf("error recording use of personal access token %s: %s", t.Id, dbErr)
+		}
+	}
+
+	return &domain.Claims{Id: u.Id, Roles: []string{}, SharedAccounts: u.SharedAccountIds, Locale: u.Locale, Scopes: t.Scopes, ExpiresAt: t.ExpiresAt}, nil
+}
+
+func newPatSecret() (string, error) {
+	secret := make([]byte, patSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return pat.SecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashPatSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"github.com/rs/xid"
+	"golang.org/x/exp/slices"
+	"log"
+	"strings"
+	"time"
+)
+
+const refreshTokenSecretBytes = 32
+
+// SessionService issues short-lived access tokens together with rotating refresh tokens. It wraps JwtService and is
+// meant to be used in its place, so that RequireJwt rejects access tokens of revoked sessions.
+type SessionService struct {
+	JwtService       domain.JwtService
+	SessionDbService session.SessionDbService
+	UserDbService    user.UserDbService
+	AccessExpiry     time.Duration
+	RefreshExpiry    time.Duration
+}
+
+func NewSessionService(cfg *config.Jwt, jwt domain.JwtService, db session.SessionDbService, udb user.UserDbService) *SessionService {
+	return &SessionService{
+		JwtService:       jwt,
+		SessionDbService: db,
+		UserDbService:    udb,
+		AccessExpiry:     cfg.AccessExpiry,
+		RefreshExpiry:    cfg.RefreshExpiry,
+	}
+}
+
+func (s *SessionService) GenerateAccessToken(claims *domain.Claims) (string, error) {
+	return s.JwtService.GenerateAccessToken(claims)
+}
+
+func (s *SessionService) GenerateResetPasswordToken(claims *domain.Claims) (string, error) {
+	return s.JwtService.GenerateResetPasswordToken(claims)
+}
+
+func (s *SessionService) GenerateVerifyEmailToken(claims *domain.Claims) (string, error) {
+	return s.JwtService.GenerateVerifyEmailToken(claims)
+}
+
+func (s *SessionService) ParseToken(token string) (*domain.Claims, error) {
+	return s.JwtService.ParseToken(token)
+}
+
+// IsRevoked reports whether the session of an access token was revoked or has expired. Tokens issued without a
+// session are only limited by their own expiry.
+func (s *SessionService) IsRevoked(ctx context.Context, c *domain.Claims) bool {
+	if c.SessionId == "" {
+		return false
+	}
+
+	sess, dbErr := s.SessionDbService.Retrieve(ctx, c.SessionId)
+	if dbErr != nil {
+		if dbErr.Type != domain.DbNotFoundError {
+			log.Printf("error retrieving session %s: %s", c.SessionId, dbErr)
+		}
+		return true
+	}
+
+	return !sess.IsActive(time.Now())
+}
+
+// Create starts a new session for the user in c.
+func (s *SessionService) Create(ctx context.Context, c *domain.Claims) (*session.Tokens, *session.SessionError) {
+	if c.Id == "anonymous" {
+		return nil, &session.SessionError{Type: session.SessionUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	sessionId := hex.EncodeToString(xid.New().Bytes())
+	refreshToken, tokenHash, err := newRefreshToken(sessionId)
+	if err != nil {
+		return nil, &session.SessionError{Type: session.SessionInternalError, Err: err}
+	}
+
+	now := time.Now()
+	sess := session.Session{
+		Id:              sessionId,
+		UserId:          c.Id,
+		TokenHash:       tokenHash,
+		UsedTokenHashes: []string{},
+		CreatedAt:       now,
+		RefreshedAt:     now,
+		ExpiresAt:       now.Add(s.RefreshExpiry),
+	}
+	if _, dbErr := s.SessionDbService.Create(ctx, &sess); dbErr != nil {
+		return nil, &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+	}
+
+	return s.tokens(c, sessionId, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens. Each refresh token can be used once, presenting one that
+// was already exchanged means it leaked, so the whole session is revoked.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*session.Tokens, *session.SessionError) {
+	sessionId, _, found := strings.Cut(refreshToken, ".")
+	if !found {
+		return nil, &session.SessionError{Type: session.SessionInvalidTokenError, Err: errors.New("malformed refresh token")}
+	}
+
+	sess, dbErr := s.SessionDbService.Retrieve(ctx, sessionId)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &session.SessionError{Type: session.SessionInvalidTokenError, Err: dbErr}
+		default:
+			return nil, &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+		}
+	}
+
+	now := time.Now()
+	if !sess.IsActive(now) {
+		return nil, &session.SessionError{Type: session.SessionInvalidTokenError, Err: errors.New("session revoked or expired")}
+	}
+
+	tokenHash := hashRefreshToken(refreshToken)
+	if slices.Contains(sess.UsedTokenHashes, tokenHash) {
+		return nil, s.revokeReused(ctx, sess.Id)
+	}
+	if tokenHash != sess.TokenHash {
+		return nil, &session.SessionError{Type: session.SessionInvalidTokenError, Err: errors.New("unknown refresh token")}
+	}
+
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", sess.UserId)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &session.SessionError{Type: session.SessionInvalidTokenError, Err: dbErr}
+		default:
+			return nil, &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+		}
+	}
+
+	newRefreshToken, newTokenHash, err := newRefreshToken(sess.Id)
+	if err != nil {
+		return nil, &session.SessionError{Type: session.SessionInternalError, Err: err}
+	}
+
+	if _, dbErr = s.SessionDbService.Rotate(ctx, sess.Id, tokenHash, newTokenHash, now, now.Add(s.RefreshExpiry)); dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbConflictError:
+			// Another request exchanged the same token in the meantime.
+			return nil, s.revokeReused(ctx, sess.Id)
+		default:
+			return nil, &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+		}
+	}
+
+	// Claims are read again, so that changes of shared accounts or roles apply from the next refresh.
+	claims := domain.Claims{Id: u.Id, Roles: u.EffectiveRoles(), SharedAccounts: u.SharedAccountIds, ResetPassword: false, Locale: u.Locale}
+	return s.tokens(&claims, sess.Id, newRefreshToken)
+}
+
+func (s *SessionService) revokeReused(ctx context.Context, sessionId string) *session.SessionError {
+	if dbErr := s.SessionDbService.Revoke(ctx, sessionId, time.Now(), session.RevokedReasonReuse); dbErr != nil {
+		return &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+	}
+	return &session.SessionError{Type: session.SessionReuseDetectedError, Err: errors.New("refresh token reused, session revoked")}
+}
+
+// Revoke ends the session of the given refresh token or, if it is empty, the session of the access token in c.
+func (s *SessionService) Revoke(ctx context.Context, c *domain.Claims, refreshToken string) *session.SessionError {
+	sessionId := c.SessionId
+	if refreshToken != "" {
+		sessionId, _, _ = strings.Cut(refreshToken, ".")
+	}
+	if sessionId == "" {
+		return &session.SessionError{Type: session.SessionInvalidTokenError, Err: errors.New("no session to revoke")}
+	}
+
+	sess, dbErr := s.SessionDbService.Retrieve(ctx, sessionId)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &session.SessionError{Type: session.SessionInvalidTokenError, Err: dbErr}
+		default:
+			return &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+		}
+	}
+
+	// Holding the refresh token is enough to end its session, otherwise only the owner can do it.
+	if refreshToken != "" {
+		tokenHash := hashRefreshToken(refreshToken)
+		if tokenHash != sess.TokenHash && !slices.Contains(sess.UsedTokenHashes, tokenHash) {
+			return &session.SessionError{Type: session.SessionInvalidTokenError, Err: errors.New("unknown refresh token")}
+		}
+	} else if sess.UserId != c.Id {
+		return &session.SessionError{Type: session.SessionUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	if dbErr = s.SessionDbService.Revoke(ctx, sess.Id, time.Now(), session.RevokedReasonLogout); dbErr != nil {
+		return &session.SessionError{Type: session.SessionInternalError, Err: dbErr}
+	}
+
+	return nil
+}
+
+func (s *SessionService) tokens(c *domain.Claims, sessionId string, refreshToken string) (*session.Tokens, *session.SessionError) {
+	claims := *c
+	claims.SessionId = sessionId
+
+	accessToken, err := s.JwtService.GenerateAccessToken(&claims)
+	if err != nil {
+		return nil, &session.SessionError{Type: session.SessionInternalError, Err: err}
+	}
+
+	return &session.Tokens{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresIn: s.AccessExpiry}, nil
+}
+
+// newRefreshToken returns a token prefixed with its session id and the hash that is stored instead of the token.
+func newRefreshToken(sessionId string) (string, string, error) {
+	secret := make([]byte, refreshTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	token := sessionId + "." + hex.EncodeToString(secret)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -8,37 +8,106 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/oidc"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/session"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
 	"github.com/rs/xid"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/exp/slices"
+	"log"
 	"net/url"
-	"path"
 	"time"
 )
 
 type UserService struct {
-	BcryptCost    int
-	Validator     *validator.Validate
-	UserDbService user.UserDbService
-	EmailService  domain.EmailService
-	JwtService    domain.JwtService
-	FrontEndUrl   *url.URL
+	BcryptCost        int
+	Validator         *validator.Validate
+	UserDbService     user.UserDbService
+	EmailService      domain.EmailService
+	JwtService        domain.JwtService
+	FrontEndUrl       *url.URL
+	AuditService      audit.AuditService
+	WebhookService    webhook.WebhookService
+	TxService         domain.TxService
+	WhDbService       wh.WhDbService
+	RevisionDbService wh.WhRevisionDbService
+	SessionDbService  session.SessionDbService
+	PatDbService      pat.PatDbService
+	LinkDbService     oidc.LinkDbService
+
+	// unknownUserHash is compared against when the user does not exist, so that the response takes as long as for a
+	// wrong password.
+	unknownUserHash []byte
 }
 
-func NewUserService(cfg *config.UserService, db user.UserDbService, email domain.EmailService, jwt domain.JwtService, v *validator.Validate) *UserService {
+func NewUserService(cfg *config.UserService, db user.UserDbService, email domain.EmailService, jwt domain.JwtService, v *validator.Validate, as audit.AuditService, ws webhook.WebhookService, tx domain.TxService, wdb wh.WhDbService, rdb wh.WhRevisionDbService, sdb session.SessionDbService, pdb pat.PatDbService, ldb oidc.LinkDbService) *UserService {
+	unknownUserHash, err := bcrypt.GenerateFromPassword([]byte(xid.New().String()), cfg.BcryptCost)
+	if err != nil {
+		panic(err)
+	}
+
 	return &UserService{
-		BcryptCost:    cfg.BcryptCost,
-		UserDbService: db,
-		EmailService:  email,
-		JwtService:    jwt,
-		Validator:     v,
-		FrontEndUrl:   cfg.FrontEndUrl,
+		BcryptCost:        cfg.BcryptCost,
+		UserDbService:     db,
+		EmailService:      email,
+		JwtService:        jwt,
+		Validator:         v,
+		FrontEndUrl:       cfg.FrontEndUrl,
+		AuditService:      as,
+		WebhookService:    ws,
+		TxService:         tx,
+		WhDbService:       wdb,
+		RevisionDbService: rdb,
+		SessionDbService:  sdb,
+		PatDbService:      pdb,
+		LinkDbService:     ldb,
+		unknownUserHash:   unknownUserHash,
+	}
+
+}
+
+// publishUserLinked notifies both the user and every newly linked account, the user is now able to see the shared
+// content of the linked account.
+func publishUserLinked(ctx context.Context, ws webhook.WebhookService, prevSharedAccountIds []string, u *user.User) {
+	for i, id := range u.SharedAccountIds {
+		if slices.Contains(prevSharedAccountIds, id) {
+			continue
+		}
+
+		data := map[string]any{"userId": u.Id, "username": u.Username, "linkedUserId": id}
+		if len(u.SharedAccountNames) == len(u.SharedAccountIds) {
+			data["linkedUsername"] = u.SharedAccountNames[i]
+		}
+
+		publishWebhookEvent(ctx, ws, webhook.EventTypeUserLinked, u.Id, false, data)
+		publishWebhookEvent(ctx, ws, webhook.EventTypeUserLinked, id, false, data)
+	}
+}
+
+func userAuditView(u *user.User) map[string]any {
+	return map[string]any{
+		"username":        u.Username,
+		"emailVerified":   u.EmailVerified,
+		"pendingUsername": u.PendingUsername,
+		"roles":           u.Roles,
+		"sharedAccounts":  u.SharedAccountNames,
+		"locale":          u.Locale,
+		"totpRequired":    u.Totp.Required,
 	}
+}
 
+func passwordChangedAuditView(u *user.User) map[string]any {
+	view := userAuditView(u)
+	view["password"] = "[changed]"
+	return view
 }
 
 func (s *UserService) Get(ctx context.Context, c *domain.Claims, id string) (*user.User, *user.UserError) {
-	if c.Id == "anonymous" || !(id == c.Id || c.Admin) {
+	if c.Id == "anonymous" || !(id == c.Id || c.Can(domain.PermissionManageUsers)) {
 		return nil, &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
 	}
 
@@ -68,11 +137,12 @@ func (s *UserService) Exists(ctx context.Context, username string) (bool, *user.
 	return true, nil
 }
 
-func (s *UserService) Authenticate(ctx context.Context, username string, password string) (*user.User, *user.UserError) {
+func (s *UserService) Authenticate(ctx context.Context, username string, password string, totpCode string) (*user.User, *user.UserError) {
 	u, dbErr := s.UserDbService.Retrieve(ctx, "username", username)
 	if dbErr != nil {
 		switch dbErr.Type {
 		case domain.DbNotFoundError:
+			_ = bcrypt.CompareHashAndPassword(s.unknownUserHash, []byte(password))
 			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
 		default:
 			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
@@ -83,18 +153,7 @@ func (s *UserService) Authenticate(ctx context.Context, username string, passwor
 		return nil, &user.UserError{Type: user.UserIncorrectPasswordError, Err: errors.New("incorrect password")}
 	}
 
-	u.LastAuthOn = time.Now()
-
-	if _, dbErr = s.UserDbService.Update(ctx, u); dbErr != nil {
-		switch dbErr.Type {
-		case domain.DbNotFoundError:
-			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
-		default:
-			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
-		}
-	}
-
-	return u, nil
+	return s.AuthenticateTotp(ctx, u, totpCode)
 }
 
 func authenticate(u *user.User, password string) (success bool) {
@@ -113,6 +172,10 @@ func (s *UserService) Create(ctx context.Context, u *user.User) (*user.User, *us
 		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: err}
 	}
 
+	if uErr := s.checkSharedAccountsVerified(ctx, u.SharedAccountNames, []string{}); uErr != nil {
+		return nil, uErr
+	}
+
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(u.Password), s.BcryptCost)
 	if err != nil {
 		return nil, &user.UserError{Type: user.UserInternalError, Err: err}
@@ -120,6 +183,7 @@ func (s *UserService) Create(ctx context.Context, u *user.User) (*user.User, *us
 	u.PasswordHash = passwordHash
 	u.Id = hex.EncodeToString(xid.New().Bytes())
 	u.CreatedOn = time.Now()
+	u.PendingUsername = ""
 
 	createdUser, dbErr := s.UserDbService.Create(ctx, u)
 	if dbErr != nil {
@@ -131,6 +195,16 @@ func (s *UserService) Create(ctx context.Context, u *user.User) (*user.User, *us
 		}
 	}
 
+	recordAudit(ctx, s.AuditService, createdUser.Id, audit.EventTypeCreate, audit.ObjectTypeUser, createdUser.Id, createdUser.Id, nil, userAuditView(createdUser))
+	publishUserLinked(ctx, s.WebhookService, []string{}, createdUser)
+
+	// The account is usable before the address is confirmed, a failed email can be sent again with SendVerifyEmail.
+	if !createdUser.EmailVerified {
+		if uErr := s.sendVerifyEmail(ctx, createdUser, createdUser.Username); uErr != nil {
+			log.Printf("error sending verification email to user %s: %s", createdUser.Id, uErr)
+		}
+	}
+
 	return createdUser, nil
 }
 
@@ -166,8 +240,16 @@ func (s *UserService) Update(ctx context.Context, c *domain.Claims, u *user.User
 		}
 	}
 
+	if uErr := s.checkSharedAccountsVerified(ctx, u.SharedAccountNames, currentUser.SharedAccountNames); uErr != nil {
+		return nil, uErr
+	}
+
+	before := userAuditView(currentUser)
+	prevSharedAccountIds := currentUser.SharedAccountIds
+
 	currentUser.SharedAccountNames = make([]string, len(u.SharedAccountNames))
 	copy(currentUser.SharedAccountNames, u.SharedAccountNames)
+	currentUser.Locale = u.Locale
 
 	updatedUser, dbErr := s.UserDbService.Update(ctx, currentUser)
 
@@ -180,6 +262,9 @@ func (s *UserService) Update(ctx context.Context, c *domain.Claims, u *user.User
 		}
 	}
 
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeUpdate, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, before, userAuditView(updatedUser))
+	publishUserLinked(ctx, s.WebhookService, prevSharedAccountIds, updatedUser)
+
 	return updatedUser, nil
 }
 
@@ -187,6 +272,9 @@ func validateUpdateUser(v *validator.Validate, u *user.User) error {
 	if err := v.Var(u.SharedAccountNames, "dive,email,required"); err != nil {
 		return err
 	}
+	if err := v.Var(u.Locale, "omitempty,bcp47_language_tag"); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -213,7 +301,22 @@ func (s *UserService) UpdateCredentials(ctx context.Context, c *domain.Claims, c
 		return nil, &user.UserError{Type: user.UserIncorrectPasswordError, Err: errors.New("incorrect password")}
 	}
 
-	currentUser.Username = u.Username
+	// A new username replaces the current one only after it is confirmed with VerifyEmail.
+	sendVerification := false
+	if u.Username != currentUser.Username {
+		if uErr := s.checkUsernameFree(ctx, u.Username); uErr != nil {
+			return nil, uErr
+		}
+		sendVerification = u.Username != currentUser.PendingUsername
+	}
+
+	before := userAuditView(currentUser)
+
+	if u.Username != currentUser.Username {
+		currentUser.PendingUsername = u.Username
+	} else {
+		currentUser.PendingUsername = ""
+	}
 	currentUser.PasswordHash, _ = bcrypt.GenerateFromPassword([]byte(u.Password), s.BcryptCost)
 
 	updatedUser, dbErr := s.UserDbService.Update(ctx, currentUser)
@@ -226,6 +329,14 @@ func (s *UserService) UpdateCredentials(ctx context.Context, c *domain.Claims, c
 		}
 	}
 
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeUpdateCredentials, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, before, passwordChangedAuditView(updatedUser))
+
+	if sendVerification {
+		if uErr := s.sendVerifyEmail(ctx, updatedUser, updatedUser.PendingUsername); uErr != nil {
+			return nil, uErr
+		}
+	}
+
 	return updatedUser, nil
 }
 
@@ -239,11 +350,16 @@ func validateUpdateCredentials(v *validator.Validate, u *user.User) error {
 	return nil
 }
 
-func (s *UserService) UpdateClaims(ctx context.Context, c *domain.Claims, u *user.User) (*user.User, *user.UserError) {
-	if !c.Admin {
+func (s *UserService) UpdateClaims(ctx context.Context, c *domain.Claims, u *user.User, totpRequired *bool) (*user.User, *user.UserError) {
+	if !c.Can(domain.PermissionManageUsers) {
 		return nil, &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
 	}
 
+	roles, err := validateRoles(u.Roles)
+	if err != nil {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: err}
+	}
+
 	currentUser, dbErr := s.UserDbService.Retrieve(ctx, "id", u.Id)
 	if dbErr != nil {
 		switch dbErr.Type {
@@ -254,7 +370,14 @@ func (s *UserService) UpdateClaims(ctx context.Context, c *domain.Claims, u *use
 		}
 	}
 
-	currentUser.Admin = u.Admin
+	before := userAuditView(currentUser)
+
+	if roles != nil {
+		currentUser.Roles = roles
+	}
+	if totpRequired != nil {
+		currentUser.Totp.Required = *totpRequired
+	}
 
 	updatedUser, dbErr := s.UserDbService.Update(ctx, currentUser)
 	if dbErr != nil {
@@ -266,24 +389,138 @@ func (s *UserService) UpdateClaims(ctx context.Context, c *domain.Claims, u *use
 		}
 	}
 
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeUpdateClaims, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, before, userAuditView(updatedUser))
+
 	return updatedUser, nil
 }
 
+// validateRoles returns sorted roles without duplicates, nil roles are left nil.
+func validateRoles(roles []string) ([]string, error) {
+	if roles == nil {
+		return nil, nil
+	}
+
+	validRoles := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !slices.Contains(domain.Roles(), role) {
+			return nil, fmt.Errorf("invalid role %s", role)
+		}
+		validRoles = append(validRoles, role)
+	}
+	slices.Sort(validRoles)
+
+	return slices.Compact(validRoles), nil
+}
+
 func (s *UserService) Delete(ctx context.Context, c *domain.Claims, id string) *user.UserError {
 	if id != c.Id {
 		return &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
 	}
 
-	if dbErr := s.UserDbService.Delete(ctx, id); dbErr != nil {
+	currentUser, dbErr := s.UserDbService.Retrieve(ctx, "id", id)
+	if dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+		return &user.UserError{Type: user.UserInternalError, Err: dbErr}
+	}
+
+	dbErr = s.TxService.WithTransaction(ctx, func(ctx context.Context) *domain.DbError {
+		return s.deleteUserData(ctx, id)
+	})
+	if dbErr != nil {
 		return &user.UserError{Type: user.UserInternalError, Err: dbErr}
+	}
 
-	} else {
-		return nil
+	if currentUser != nil {
+		recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeDelete, audit.ObjectTypeUser, id, id, userAuditView(currentUser), nil)
 	}
+
+	return nil
+}
+
+// deleteUserData removes the user last, so that a failed deletion can be retried if transactions are not supported.
+// Second factor data is kept on the user and goes with it.
+func (s *UserService) deleteUserData(ctx context.Context, id string) *domain.DbError {
+	for _, t := range wh.WhApiTypes {
+		deletedIds, dbErr := s.WhDbService.DeleteByOwner(ctx, t, id)
+		if dbErr != nil {
+			return dbErr
+		}
+		if dbErr = s.RevisionDbService.DeleteAll(ctx, t, deletedIds); dbErr != nil {
+			return dbErr
+		}
+	}
+
+	generationProps, dbErr := s.WhDbService.RetrieveGenerationPropsList(ctx, []string{id}, nil)
+	if dbErr != nil {
+		return dbErr
+	}
+	for _, gp := range generationProps {
+		if dbErr = s.WhDbService.DeleteGenerationProps(ctx, id, gp.Name); dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+			return dbErr
+		}
+	}
+
+	if s.WebhookService != nil {
+		if wErr := s.WebhookService.DeleteByOwner(ctx, id); wErr != nil {
+			return domain.CreateDbError(domain.DbInternalError, wErr)
+		}
+	}
+
+	// Removing sessions revokes refresh tokens and access tokens of the user at once.
+	if dbErr = s.SessionDbService.DeleteByUser(ctx, id); dbErr != nil {
+		return dbErr
+	}
+	if dbErr = s.PatDbService.DeleteByUser(ctx, id); dbErr != nil {
+		return dbErr
+	}
+	if dbErr = s.LinkDbService.DeleteByUser(ctx, id); dbErr != nil {
+		return dbErr
+	}
+
+	return s.UserDbService.Delete(ctx, id)
+}
+
+// Export returns everything stored for the user, it is available to the user and to users allowed to manage users.
+func (s *UserService) Export(ctx context.Context, c *domain.Claims, id string) (*user.Export, *user.UserError) {
+	u, uErr := s.Get(ctx, c, id)
+	if uErr != nil {
+		return nil, uErr
+	}
+
+	export := user.Export{
+		User:       u,
+		Wh:         make(map[wh.WhType][]*wh.Wh, len(wh.WhApiTypes)),
+		Trash:      make(map[wh.WhType][]*wh.Wh, len(wh.WhApiTypes)),
+		ExportedOn: time.Now(),
+	}
+
+	for _, t := range wh.WhApiTypes {
+		whs, dbErr := s.WhDbService.Retrieve(ctx, t, []string{u.Id}, nil, nil)
+		if dbErr != nil {
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+		if whs == nil {
+			whs = []*wh.Wh{}
+		}
+		export.Wh[t] = whs
+
+		trash, dbErr := s.WhDbService.RetrieveDeleted(ctx, t, u.Id)
+		if dbErr != nil {
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+		export.Trash[t] = trash
+	}
+
+	generationProps, dbErr := s.WhDbService.RetrieveGenerationPropsList(ctx, []string{u.Id}, nil)
+	if dbErr != nil {
+		return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+	}
+	export.GenerationProps = generationProps
+
+	return &export, nil
 }
 
 func (s *UserService) List(ctx context.Context, c *domain.Claims) ([]*user.User, *user.UserError) {
-	if !c.Admin {
+	if !c.Can(domain.PermissionManageUsers) {
 		return nil, &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
 	}
 
@@ -314,22 +551,23 @@ func (s *UserService) SendResetPassword(ctx context.Context, username string) *u
 		return &user.UserError{Type: user.UserNotFoundError, Err: errors.New("user not found")}
 	}
 
-	claims := domain.Claims{Id: u.Id, Admin: false, SharedAccounts: []string{}, ResetPassword: true}
+	if !u.EmailVerified {
+		return &user.UserError{Type: user.UserEmailNotVerifiedError, Err: errors.New("email not verified")}
+	}
+
+	claims := domain.Claims{Id: u.Id, Roles: []string{}, SharedAccounts: []string{}, ResetPassword: true}
 	resetToken, err := s.JwtService.GenerateResetPasswordToken(&claims)
 
 	if err != nil {
 		return &user.UserError{Type: user.UserInternalError, Err: err}
 	}
 
-	clickUrl, err := url.ParseRequestURI(s.FrontEndUrl.String())
+	clickUrl, err := s.frontEndLink(fmt.Sprintf("/resetPassword/%s", resetToken))
 	if err != nil {
 		return &user.UserError{Type: user.UserInternalError, Err: err}
 	}
 
-	resetTokenPath := fmt.Sprintf("/resetPassword/%s", resetToken)
-	clickUrl.Path = path.Join(clickUrl.Path, resetTokenPath)
-
-	emailMessage := fmt.Sprintf("Please reset your password by <a href=%s>clicking here</a>", clickUrl.String())
+	emailMessage := fmt.Sprintf("Please reset your password by <a href=%s>clicking here</a>", clickUrl)
 	email := domain.Email{
 		ToAddress: u.Username,
 		Subject:   "Reset password",
@@ -372,7 +610,7 @@ func (s *UserService) ResetPassword(ctx context.Context, token string, newPasswo
 		return &user.UserError{Type: user.UserInternalError, Err: err}
 	}
 
-	_, dbErr = s.UserDbService.Update(ctx, currentUser)
+	updatedUser, dbErr := s.UserDbService.Update(ctx, currentUser)
 	if dbErr != nil {
 		switch dbErr.Type {
 		case domain.DbNotFoundError:
@@ -382,5 +620,7 @@ func (s *UserService) ResetPassword(ctx context.Context, token string, newPasswo
 		}
 	}
 
+	recordAudit(ctx, s.AuditService, claims.Id, audit.EventTypeResetPassword, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, userAuditView(updatedUser), passwordChangedAuditView(updatedUser))
+
 	return nil
 }
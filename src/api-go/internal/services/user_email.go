@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"golang.org/x/exp/slices"
+	"net/url"
+	"path"
+)
+
+func (s *UserService) frontEndLink(linkPath string) (string, error) {
+	clickUrl, err := url.ParseRequestURI(s.FrontEndUrl.String())
+	if err != nil {
+		return "", err
+	}
+	clickUrl.Path = path.Join(clickUrl.Path, linkPath)
+	return clickUrl.String(), nil
+}
+
+// sendVerifyEmail sends a confirmation link to address, the token carries the address so that a link sent to an
+// address that is no longer pending can not be used.
+func (s *UserService) sendVerifyEmail(ctx context.Context, u *user.User, address string) *user.UserError {
+	claims := domain.Claims{Id: u.Id, Roles: []string{}, SharedAccounts: []string{}, VerifyEmail: address}
+	verifyToken, err := s.JwtService.GenerateVerifyEmailToken(&claims)
+	if err != nil {
+		return &user.UserError{Type: user.UserInternalError, Err: err}
+	}
+
+	clickUrl, err := s.frontEndLink(fmt.Sprintf("/verifyEmail/%s", verifyToken))
+	if err != nil {
+		return &user.UserError{Type: user.UserInternalError, Err: err}
+	}
+
+	email := domain.Email{
+		ToAddress: address,
+		Subject:   "Verify email",
+		Content:   fmt.Sprintf("Please confirm your email address by <a href=%s>clicking here</a>", clickUrl),
+	}
+
+	if err = s.EmailService.Send(ctx, &email); err != nil {
+		return &user.UserError{Type: user.UserSendEmailError, Err: err}
+	}
+
+	return nil
+}
+
+func (s *UserService) SendVerifyEmail(ctx context.Context, c *domain.Claims) *user.UserError {
+	if c.Id == "anonymous" {
+		return &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", c.Id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+
+	switch {
+	case u.PendingUsername != "":
+		return s.sendVerifyEmail(ctx, u, u.PendingUsername)
+	case !u.EmailVerified:
+		return s.sendVerifyEmail(ctx, u, u.Username)
+	default:
+		return &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("email already verified")}
+	}
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, token string) (*user.User, *user.UserError) {
+	if len(token) == 0 {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("missing token")}
+	}
+
+	claims, err := s.JwtService.ParseToken(token)
+	if err != nil || claims.VerifyEmail == "" {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("invalid token")}
+	}
+
+	currentUser, dbErr := s.UserDbService.Retrieve(ctx, "id", claims.Id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+
+	before := userAuditView(currentUser)
+
+	switch claims.VerifyEmail {
+	case currentUser.PendingUsername:
+		if uErr := s.checkUsernameFree(ctx, currentUser.PendingUsername); uErr != nil {
+			return nil, uErr
+		}
+		currentUser.Username = currentUser.PendingUsername
+		currentUser.PendingUsername = ""
+	case currentUser.Username:
+		if currentUser.EmailVerified {
+			return currentUser, nil
+		}
+	default:
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("invalid token")}
+	}
+	currentUser.EmailVerified = true
+
+	updatedUser, dbErr := s.UserDbService.Update(ctx, currentUser)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+
+	recordAudit(ctx, s.AuditService, claims.Id, audit.EventTypeVerifyEmail, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, before, userAuditView(updatedUser))
+
+	return updatedUser, nil
+}
+
+func (s *UserService) checkUsernameFree(ctx context.Context, username string) *user.UserError {
+	exists, uErr := s.Exists(ctx, username)
+	if uErr != nil {
+		return uErr
+	}
+	if exists {
+		return &user.UserError{Type: user.UserAlreadyExistsError, Err: errors.New("user already exists")}
+	}
+	return nil
+}
+
+// checkSharedAccountsVerified rejects accounts that are newly shared with and have not verified their email. Unknown
+// accounts are left to the database, which ignores them.
+func (s *UserService) checkSharedAccountsVerified(ctx context.Context, sharedAccountNames []string, prevSharedAccountNames []string) *user.UserError {
+	for _, name := range sharedAccountNames {
+		if slices.Contains(prevSharedAccountNames, name) {
+			continue
+		}
+
+		u, dbErr := s.UserDbService.Retrieve(ctx, "username", name)
+		if dbErr != nil {
+			if dbErr.Type == domain.DbNotFoundError {
+				continue
+			}
+			return &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+
+		if !u.EmailVerified {
+			return &user.UserError{Type: user.UserEmailNotVerifiedError, Err: fmt.Errorf("account %s has not verified its email", name)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters of RFC 6238 that all authenticator apps support.
+const (
+	totpIssuer            = "Hammergen"
+	totpSecretBytes       = 20
+	totpDigits            = 6
+	totpPeriod            = 30
+	totpSkewSteps         = 1
+	totpRecoveryCodes     = 10
+	totpRecoveryCodeBytes = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (s *UserService) AuthenticateTotp(ctx context.Context, u *user.User, totpCode string) (*user.User, *user.UserError) {
+	if u.Totp.Enabled {
+		if totpCode == "" {
+			return nil, &user.UserError{Type: user.UserTotpRequiredError, Err: errors.New("totp code required")}
+		}
+		if !verifySecondFactor(&u.Totp, totpCode, time.Now()) {
+			return nil, &user.UserError{Type: user.UserIncorrectTotpError, Err: errors.New("incorrect totp code")}
+		}
+	}
+
+	u.LastAuthOn = time.Now()
+
+	updatedUser, dbErr := s.UserDbService.Update(ctx, u)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+
+	return updatedUser, nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before or one of the recovery codes, which is then removed.
+func verifySecondFactor(t *user.Totp, code string, now time.Time) bool {
+	if step, ok := matchTotp(t.Secret, code, now, t.LastStep); ok {
+		t.LastStep = step
+		return true
+	}
+
+	hash := recoveryCodeHash(code)
+	for i, h := range t.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			t.RecoveryCodeHashes = append(t.RecoveryCodeHashes[:i], t.RecoveryCodeHashes[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchTotp returns the time step that code is valid for. Steps up to lastStep are rejected so that a code can not be
+// replayed.
+func matchTotp(secret string, code string, now time.Time, lastStep int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) == 0 || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// hotp is the HMAC-based one-time password of RFC 4226.
+func hotp(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+func recoveryCodeHash(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, totpRecoveryCodes)
+	hashes := make([]string, totpRecoveryCodes)
+	for i := range codes {
+		b := make([]byte, totpRecoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes[i] = fmt.Sprintf("%s-%s-%s-%s", raw[0:4], raw[4:8], raw[8:12], raw[12:16])
+		hashes[i] = recoveryCodeHash(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+func totpUri(secret string, username string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", totpIssuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(totpDigits))
+	values.Set("period", fmt.Sprint(totpPeriod))
+
+	uri := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + totpIssuer + ":" + username, RawQuery: values.Encode()}
+	return uri.String()
+}
+
+func totpAuditView(u *user.User) map[string]any {
+	return map[string]any{"totpEnabled": u.Totp.Enabled, "totpRequired": u.Totp.Required}
+}
+
+func (s *UserService) retrieveForTotp(ctx context.Context, id string) (*user.User, *user.UserError) {
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return nil, &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+	return u, nil
+}
+
+func (s *UserService) updateTotp(ctx context.Context, c *domain.Claims, u *user.User, before map[string]any) *user.UserError {
+	updatedUser, dbErr := s.UserDbService.Update(ctx, u)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &user.UserError{Type: user.UserNotFoundError, Err: dbErr}
+		default:
+			return &user.UserError{Type: user.UserInternalError, Err: dbErr}
+		}
+	}
+
+	after := totpAuditView(updatedUser)
+	if before["totpEnabled"] != after["totpEnabled"] {
+		recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeUpdateTotp, audit.ObjectTypeUser, updatedUser.Id, updatedUser.Id, before, after)
+	}
+
+	return nil
+}
+
+// EnrollTotp starts a new enrollment, replacing one that was not confirmed.
+func (s *UserService) EnrollTotp(ctx context.Context, c *domain.Claims) (*user.TotpEnrollment, *user.UserError) {
+	if c.Id == "anonymous" {
+		return nil, &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	u, uErr := s.retrieveForTotp(ctx, c.Id)
+	if uErr != nil {
+		return nil, uErr
+	}
+
+	if u.Totp.Enabled {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("totp already enabled")}
+	}
+
+	key := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, &user.UserError{Type: user.UserInternalError, Err: err}
+	}
+	u.Totp.Secret = totpEncoding.EncodeToString(key)
+	u.Totp.LastStep = 0
+
+	if uErr = s.updateTotp(ctx, c, u, totpAuditView(u)); uErr != nil {
+		return nil, uErr
+	}
+
+	return &user.TotpEnrollment{Secret: u.Totp.Secret, Uri: totpUri(u.Totp.Secret, u.Username)}, nil
+}
+
+func (s *UserService) ConfirmTotp(ctx context.Context, c *domain.Claims, totpCode string) ([]string, *user.UserError) {
+	if c.Id == "anonymous" {
+		return nil, &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	u, uErr := s.retrieveForTotp(ctx, c.Id)
+	if uErr != nil {
+		return nil, uErr
+	}
+
+	if u.Totp.Enabled {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("totp already enabled")}
+	}
+	if u.Totp.Secret == "" {
+		return nil, &user.UserError{Type: user.UserInvalidArgumentsError, Err: errors.New("totp not enrolled")}
+	}
+
+	step, ok := matchTotp(u.Totp.Secret, totpCode, time.Now(), u.Totp.LastStep)
+	if !ok {
+		return nil, &user.UserError{Type: user.UserIncorrectTotpError, Err: errors.New("incorrect totp code")}
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, &user.UserError{Type: user.UserInternalError, Err: err}
+	}
+
+	before := totpAuditView(u)
+	u.Totp.Enabled = true
+	u.Totp.LastStep = step
+	u.Totp.RecoveryCodeHashes = hashes
+
+	if uErr = s.updateTotp(ctx, c, u, before); uErr != nil {
+		return nil, uErr
+	}
+
+	return codes, nil
+}
+
+func (s *UserService) DisableTotp(ctx context.Context, c *domain.Claims, id string, totpCode string) *user.UserError {
+	if c.Id == "anonymous" || !(id == c.Id || c.Can(domain.PermissionManageUsers)) {
+		return &user.UserError{Type: user.UserUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	u, uErr := s.retrieveForTotp(ctx, id)
+	if uErr != nil {
+		return uErr
+	}
+
+	if u.Totp.Enabled && id == c.Id && !verifySecondFactor(&u.Totp, totpCode, time.Now()) {
+		return &user.UserError{Type: user.UserIncorrectTotpError, Err: errors.New("incorrect totp code")}
+	}
+
+	before := totpAuditView(u)
+	u.Totp = user.Totp{Required: u.Totp.Required}
+
+	return s.updateTotp(ctx, c, u, before)
+}
@@ -0,0 +1,538 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"github.com/rs/xid"
+	"golang.org/x/exp/slices"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookMaxDeliveriesLimit = 1000
+	webhookSecretBytes        = 32
+	webhookMaxErrorLength     = 500
+)
+
+type WebhookService struct {
+	Validator         *validator.Validate
+	WebhookDbService  webhook.WebhookDbService
+	DeliveryDbService webhook.DeliveryDbService
+	UserDbService     user.UserDbService
+	Client            *http.Client
+	MaxAttempts       int
+	RetryBaseDelay    time.Duration
+	RetryMaxDelay     time.Duration
+	DeliveryTimeout   time.Duration
+	// AllowAddress decides which resolved addresses deliveries may connect to, by default only public ones.
+	AllowAddress func(ip net.IP) bool
+	wake         chan struct{}
+}
+
+// nonPublicNetworks are not covered by the checks of net.IP but must not be reachable by deliveries either.
+var nonPublicNetworks = mustParseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4", "64:ff9b::/96")
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// isPublicIP rejects loopback, private, link-local, multicast and reserved addresses, so that webhooks can not be
+// used to reach hosts inside our network.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// newWebhookClient checks every address after DNS resolution, which also covers host names that resolve to internal
+// addresses. Redirects are not followed, they are reported as the response status instead.
+func newWebhookClient(timeout time.Duration, allow func(ip net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return fmt.Errorf("address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func NewWebhookService(cfg *config.WebhookService, v *validator.Validate, db webhook.WebhookDbService, ddb webhook.DeliveryDbService, udb user.UserDbService) *WebhookService {
+	s := &WebhookService{
+		Validator:         v,
+		WebhookDbService:  db,
+		DeliveryDbService: ddb,
+		UserDbService:     udb,
+		MaxAttempts:       cfg.MaxAttempts,
+		RetryBaseDelay:    cfg.RetryBaseDelay,
+		RetryMaxDelay:     cfg.RetryMaxDelay,
+		DeliveryTimeout:   cfg.DeliveryTimeout,
+		AllowAddress:      isPublicIP,
+		wake:              make(chan struct{}, 1),
+	}
+	s.Client = newWebhookClient(cfg.DeliveryTimeout, func(ip net.IP) bool { return s.AllowAddress(ip) })
+
+	return s
+}
+
+func (s *WebhookService) Create(ctx context.Context, c *domain.Claims, w *webhook.Webhook) (*webhook.Webhook, *webhook.WebhookError) {
+	if c.Id == "anonymous" {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	newWebhook := w.Copy()
+	if err := s.validateWebhook(&newWebhook); err != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInvalidArgumentsError, Err: err}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: err}
+	}
+
+	newWebhook.Id = hex.EncodeToString(xid.New().Bytes())
+	newWebhook.OwnerId = c.Id
+	newWebhook.Secret = secret
+	newWebhook.CreatedAt = time.Now()
+	newWebhook.UpdatedAt = newWebhook.CreatedAt
+
+	createdWebhook, dbErr := s.WebhookDbService.Create(ctx, &newWebhook)
+	if dbErr != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	return createdWebhook, nil
+}
+
+// validateWebhook rejects urls with addresses that are not allowed early, host names are checked once they are
+// resolved on delivery.
+func (s *WebhookService) validateWebhook(w *webhook.Webhook) error {
+	if err := s.Validator.Var(w.Url, "required,url,max=2048"); err != nil {
+		return err
+	}
+	u, err := url.Parse(w.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("url must use http or https scheme")
+	}
+	if ip := net.ParseIP(u.Hostname()); (ip != nil && !s.AllowAddress(ip)) || u.Hostname() == "localhost" {
+		return errors.New("url must point to a public address")
+	}
+
+	if len(w.EventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	eventTypes := webhook.EventTypes()
+	for _, t := range w.EventTypes {
+		if !slices.Contains(eventTypes, t) {
+			return fmt.Errorf("invalid event type %s", t)
+		}
+	}
+	slices.Sort(w.EventTypes)
+	w.EventTypes = slices.Compact(w.EventTypes)
+
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, c *domain.Claims, id string) (*webhook.Webhook, *webhook.WebhookError) {
+	if c.Id == "anonymous" {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	w, dbErr := s.WebhookDbService.Retrieve(ctx, id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &webhook.WebhookError{Type: webhook.WebhookNotFoundError, Err: dbErr}
+		default:
+			return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+	}
+
+	// Webhooks of other users are reported as missing, so that their ids cannot be probed.
+	if w.OwnerId != c.Id {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookNotFoundError, Err: errors.New("webhook not found")}
+	}
+
+	return w, nil
+}
+
+func (s *WebhookService) List(ctx context.Context, c *domain.Claims) ([]*webhook.Webhook, *webhook.WebhookError) {
+	if c.Id == "anonymous" {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	webhooks, dbErr := s.WebhookDbService.RetrieveByOwner(ctx, c.Id)
+	if dbErr != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	return webhooks, nil
+}
+
+func (s *WebhookService) Update(ctx context.Context, c *domain.Claims, w *webhook.Webhook) (*webhook.Webhook, *webhook.WebhookError) {
+	currentWebhook, wErr := s.Get(ctx, c, w.Id)
+	if wErr != nil {
+		return nil, wErr
+	}
+
+	newWebhook := w.Copy()
+	if err := s.validateWebhook(&newWebhook); err != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInvalidArgumentsError, Err: err}
+	}
+
+	currentWebhook.Url = newWebhook.Url
+	currentWebhook.EventTypes = newWebhook.EventTypes
+	currentWebhook.Active = newWebhook.Active
+	currentWebhook.UpdatedAt = time.Now()
+
+	updatedWebhook, dbErr := s.WebhookDbService.Update(ctx, currentWebhook)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &webhook.WebhookError{Type: webhook.WebhookNotFoundError, Err: dbErr}
+		default:
+			return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+	}
+
+	return updatedWebhook, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, c *domain.Claims, id string) *webhook.WebhookError {
+	if _, wErr := s.Get(ctx, c, id); wErr != nil {
+		return wErr
+	}
+
+	if dbErr := s.WebhookDbService.Delete(ctx, id); dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &webhook.WebhookError{Type: webhook.WebhookNotFoundError, Err: dbErr}
+		default:
+			return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+	}
+
+	if dbErr := s.DeliveryDbService.DeleteAll(ctx, id); dbErr != nil {
+		return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) DeleteByOwner(ctx context.Context, ownerId string) *webhook.WebhookError {
+	webhooks, dbErr := s.WebhookDbService.RetrieveByOwner(ctx, ownerId)
+	if dbErr != nil {
+		return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	for _, w := range webhooks {
+		if dbErr = s.WebhookDbService.Delete(ctx, w.Id); dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+			return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+	}
+
+	// Deliveries are removed by owner, so that the ones queued for webhooks deleted concurrently go as well.
+	if dbErr = s.DeliveryDbService.DeleteByOwner(ctx, ownerId); dbErr != nil {
+		return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, c *domain.Claims, id string, limit int) ([]*webhook.Delivery, *webhook.WebhookError) {
+	if _, wErr := s.Get(ctx, c, id); wErr != nil {
+		return nil, wErr
+	}
+
+	if limit <= 0 || limit > webhookMaxDeliveriesLimit {
+		limit = webhookMaxDeliveriesLimit
+	}
+
+	deliveries, dbErr := s.DeliveryDbService.Retrieve(ctx, id, limit)
+	if dbErr != nil {
+		return nil, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+
+	return deliveries, nil
+}
+
+// Publish queues e for every active webhook subscribed to its type whose owner is allowed to see it. Deliveries are
+// sent asynchronously by the delivery worker.
+func (s *WebhookService) Publish(ctx context.Context, e *webhook.Event) *webhook.WebhookError {
+	webhooks, dbErr := s.WebhookDbService.RetrieveByEventType(ctx, e.Type)
+	if dbErr != nil {
+		return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]any{"id": e.Id, "type": e.Type, "timestamp": e.Timestamp, "data": e.Data})
+	if err != nil {
+		return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: err}
+	}
+
+	sharedAccounts := make(map[string][]string)
+	queued := false
+	for _, w := range webhooks {
+		if _, ok := sharedAccounts[w.OwnerId]; !ok && e.Shared {
+			sharedAccounts[w.OwnerId] = s.sharedAccountIds(ctx, w.OwnerId)
+		}
+		if !e.IsVisibleTo(w.OwnerId, sharedAccounts[w.OwnerId]) {
+			continue
+		}
+
+		d := webhook.Delivery{
+			Id:            hex.EncodeToString(xid.New().Bytes()),
+			WebhookId:     w.Id,
+			OwnerId:       w.OwnerId,
+			EventId:       e.Id,
+			EventType:     e.Type,
+			Payload:       string(payload),
+			Status:        webhook.DeliveryStatusPending,
+			CreatedAt:     e.Timestamp,
+			NextAttemptAt: e.Timestamp,
+		}
+		if _, dbErr = s.DeliveryDbService.Create(ctx, &d); dbErr != nil {
+			return &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+		queued = true
+	}
+
+	if queued {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) sharedAccountIds(ctx context.Context, userId string) []string {
+	u, dbErr := s.UserDbService.Retrieve(ctx, "id", userId)
+	if dbErr != nil {
+		if dbErr.Type != domain.DbNotFoundError {
+			log.Printf("error retrieving webhook owner %s: %s", userId, dbErr)
+		}
+		return []string{}
+	}
+	return u.SharedAccountIds
+}
+
+// ProcessDeliveries sends all deliveries that are due and returns how many were attempted.
+func (s *WebhookService) ProcessDeliveries(ctx context.Context) (int, *webhook.WebhookError) {
+	attempted := 0
+	for {
+		now := time.Now()
+		d, dbErr := s.DeliveryDbService.ClaimDue(ctx, now, now.Add(2*s.DeliveryTimeout))
+		if dbErr != nil {
+			if dbErr.Type == domain.DbNotFoundError {
+				return attempted, nil
+			}
+			return attempted, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+
+		s.deliver(ctx, d)
+		attempted++
+
+		if _, dbErr = s.DeliveryDbService.Update(ctx, d); dbErr != nil {
+			return attempted, &webhook.WebhookError{Type: webhook.WebhookInternalError, Err: dbErr}
+		}
+	}
+}
+
+func (s *WebhookService) deliver(ctx context.Context, d *webhook.Delivery) {
+	d.Attempts++
+	d.LastAttemptAt = time.Now()
+	d.ResponseStatus = 0
+	d.Error = ""
+
+	w, dbErr := s.WebhookDbService.Retrieve(ctx, d.WebhookId)
+	if dbErr != nil && dbErr.Type != domain.DbNotFoundError {
+		log.Printf("error retrieving webhook %s: %s", d.WebhookId, dbErr)
+		s.retryLater(d, "internal error")
+		return
+	}
+	if w == nil || !w.Active {
+		d.Status = webhook.DeliveryStatusFailed
+		d.Error = "webhook deleted or disabled"
+		return
+	}
+
+	// Transport errors are not stored, they would reveal details of the network the delivery was sent from.
+	status, err := s.send(ctx, w, d)
+	d.ResponseStatus = status
+	if err != nil {
+		if status != 0 {
+			s.retryLater(d, fmt.Sprintf("unexpected response status %d", status))
+		} else {
+			s.retryLater(d, "connection failed")
+		}
+		return
+	}
+
+	d.Status = webhook.DeliveryStatusSucceeded
+}
+
+func (s *WebhookService) send(ctx context.Context, w *webhook.Webhook, d *webhook.Delivery) (int, error) {
+	timestamp := strconv.FormatInt(d.LastAttemptAt.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Url, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Hammergen-Webhook")
+	req.Header.Set("X-Hammergen-Event", d.EventType)
+	req.Header.Set("X-Hammergen-Delivery", d.Id)
+	req.Header.Set("X-Hammergen-Timestamp", timestamp)
+	req.Header.Set("X-Hammergen-Signature", "sha256="+SignWebhookPayload(w.Secret, timestamp, d.Payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of timestamp and payload joined with a dot. Receivers should
+// compute the same value with their secret, compare it to the X-Hammergen-Signature header and reject old timestamps
+// to prevent replays.
+func SignWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) retryLater(d *webhook.Delivery, reason string) {
+	if len(reason) > webhookMaxErrorLength {
+		reason = reason[:webhookMaxErrorLength]
+	}
+	d.Error = reason
+
+	if d.Attempts >= s.MaxAttempts {
+		d.Status = webhook.DeliveryStatusFailed
+		return
+	}
+
+	d.Status = webhook.DeliveryStatusPending
+	d.NextAttemptAt = d.LastAttemptAt.Add(s.retryDelay(d.Attempts))
+}
+
+// retryDelay doubles RetryBaseDelay with every failed attempt, up to RetryMaxDelay.
+func (s *WebhookService) retryDelay(attempts int) time.Duration {
+	delay := s.RetryBaseDelay
+	for i := 1; i < attempts && delay < s.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.RetryMaxDelay {
+		delay = s.RetryMaxDelay
+	}
+	return delay
+}
+
+// StartDeliveries runs ProcessDeliveries every interval and whenever new deliveries are queued, until ctx is cancelled.
+func (s *WebhookService) StartDeliveries(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+
+			if _, wErr := s.ProcessDeliveries(ctx); wErr != nil {
+				log.Printf("error processing webhook deliveries: %s", wErr)
+			}
+		}
+	}()
+}
+
+func publishWebhookEvent(ctx context.Context, ws webhook.WebhookService, eventType string, ownerId string, shared bool, data any) {
+	if ws == nil {
+		return
+	}
+
+	e := webhook.Event{
+		Id:        hex.EncodeToString(xid.New().Bytes()),
+		Type:      eventType,
+		Timestamp: time.Now(),
+		OwnerId:   ownerId,
+		Shared:    shared,
+		Data:      data,
+	}
+
+	if wErr := ws.Publish(ctx, &e); wErr != nil {
+		log.Printf("error publishing webhook event %s: %s", eventType, wErr)
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/memdb"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/dependencies/validator"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	status   int
+}
+
+func newWebhookReceiver(t *testing.T, status int) (*webhookReceiver, *httptest.Server) {
+	r := &webhookReceiver{status: status}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, string(body))
+		r.mu.Unlock()
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func newTestWebhookService(maxAttempts int, baseDelay time.Duration) *WebhookService {
+	cfg := config.WebhookService{MaxAttempts: maxAttempts, RetryBaseDelay: baseDelay, RetryMaxDelay: 4 * baseDelay, DeliveryTimeout: 2 * time.Second}
+	v := validator.NewValidator(warhammer.NewWhEnumRegistry())
+	return NewWebhookService(&cfg, v, memdb.NewWebhookDbService(), memdb.NewWebhookDeliveryDbService(), memdb.NewUserDbService())
+}
+
+// allowLoopback lets deliveries reach the httptest receiver, which listens on a loopback address.
+func allowLoopback(ip net.IP) bool {
+	return ip.IsLoopback()
+}
+
+const testWebhookEvent = "wh.item.created"
+
+func createTestWebhook(t *testing.T, s *WebhookService, c *domain.Claims, url string) *webhook.Webhook {
+	w, wErr := s.Create(context.Background(), c, &webhook.Webhook{Url: url, EventTypes: []string{testWebhookEvent}, Active: true})
+	if wErr != nil {
+		t.Fatalf("creating webhook: %s", wErr)
+	}
+	return w
+}
+
+func publishTestEvent(t *testing.T, s *WebhookService, ownerId string) {
+	e := webhook.Event{Id: "event1", Type: testWebhookEvent, Timestamp: time.Now(), OwnerId: ownerId, Data: map[string]any{"name": "sword"}}
+	if wErr := s.Publish(context.Background(), &e); wErr != nil {
+		t.Fatalf("publishing event: %s", wErr)
+	}
+}
+
+func listTestDeliveries(t *testing.T, s *WebhookService, c *domain.Claims, webhookId string) []*webhook.Delivery {
+	deliveries, wErr := s.ListDeliveries(context.Background(), c, webhookId, 0)
+	if wErr != nil {
+		t.Fatalf("listing deliveries: %s", wErr)
+	}
+	return deliveries
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	receiver, server := newWebhookReceiver(t, http.StatusNoContent)
+	s := newTestWebhookService(3, time.Minute)
+	s.AllowAddress = allowLoopback
+	c := &domain.Claims{Id: "user1"}
+
+	w := createTestWebhook(t, s, c, server.URL)
+	publishTestEvent(t, s, c.Id)
+
+	if attempted, wErr := s.ProcessDeliveries(context.Background()); wErr != nil || attempted != 1 {
+		t.Fatalf("expected 1 attempted delivery, got %d, %v", attempted, wErr)
+	}
+	if receiver.count() != 1 {
+		t.Fatalf("expected 1 request, got %d", receiver.count())
+	}
+
+	req := receiver.requests[0]
+	if req.Header.Get("X-Hammergen-Event") != testWebhookEvent {
+		t.Errorf("unexpected event header %q", req.Header.Get("X-Hammergen-Event"))
+	}
+	expected := "sha256=" + SignWebhookPayload(w.Secret, req.Header.Get("X-Hammergen-Timestamp"), receiver.bodies[0])
+	if req.Header.Get("X-Hammergen-Signature") != expected {
+		t.Errorf("signature %q does not match %q", req.Header.Get("X-Hammergen-Signature"), expected)
+	}
+
+	deliveries := listTestDeliveries(t, s, c, w.Id)
+	if len(deliveries) != 1 || deliveries[0].Status != webhook.DeliveryStatusSucceeded || deliveries[0].ResponseStatus != http.StatusNoContent {
+		t.Fatalf("unexpected delivery log %+v", deliveries)
+	}
+}
+
+func TestWebhookDeliveryRetriesWithBackoff(t *testing.T) {
+	receiver, server := newWebhookReceiver(t, http.StatusInternalServerError)
+	s := newTestWebhookService(2, 20*time.Millisecond)
+	s.AllowAddress = allowLoopback
+	c := &domain.Claims{Id: "user1"}
+
+	w := createTestWebhook(t, s, c, server.URL)
+	publishTestEvent(t, s, c.Id)
+
+	if _, wErr := s.ProcessDeliveries(context.Background()); wErr != nil {
+		t.Fatal(wErr)
+	}
+	d := listTestDeliveries(t, s, c, w.Id)[0]
+	if d.Status != webhook.DeliveryStatusPending || d.Attempts != 1 || d.ResponseStatus != http.StatusInternalServerError {
+		t.Fatalf("expected pending delivery after first failure, got %+v", d)
+	}
+	if d.Error != "unexpected response status 500" {
+		t.Errorf("unexpected error %q", d.Error)
+	}
+	if delay := d.NextAttemptAt.Sub(d.LastAttemptAt); delay != s.RetryBaseDelay {
+		t.Errorf("expected retry after %s, got %s", s.RetryBaseDelay, delay)
+	}
+
+	// The retry is not due yet.
+	if attempted, _ := s.ProcessDeliveries(context.Background()); attempted != 0 {
+		t.Fatalf("expected no attempt before the retry delay, got %d", attempted)
+	}
+
+	time.Sleep(2 * s.RetryBaseDelay)
+	if attempted, _ := s.ProcessDeliveries(context.Background()); attempted != 1 {
+		t.Fatalf("expected retry once due, got %d attempts", attempted)
+	}
+	d = listTestDeliveries(t, s, c, w.Id)[0]
+	if d.Status != webhook.DeliveryStatusFailed || d.Attempts != 2 {
+		t.Fatalf("expected failed delivery after max attempts, got %+v", d)
+	}
+	if receiver.count() != 2 {
+		t.Errorf("expected 2 requests, got %d", receiver.count())
+	}
+
+	for attempts, expected := range map[int]time.Duration{1: s.RetryBaseDelay, 2: 2 * s.RetryBaseDelay, 3: 4 * s.RetryBaseDelay, 10: s.RetryMaxDelay} {
+		if delay := s.retryDelay(attempts); delay != expected {
+			t.Errorf("retry delay after %d attempts: expected %s, got %s", attempts, expected, delay)
+		}
+	}
+}
+
+func TestWebhookRejectsInternalAddresses(t *testing.T) {
+	receiver, server := newWebhookReceiver(t, http.StatusNoContent)
+	s := newTestWebhookService(3, time.Minute)
+	c := &domain.Claims{Id: "user1"}
+
+	for _, url := range []string{server.URL, "http://10.0.0.1/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://localhost/hook"} {
+		if _, wErr := s.Create(context.Background(), c, &webhook.Webhook{Url: url, EventTypes: []string{testWebhookEvent}}); wErr == nil || wErr.Type != webhook.WebhookInvalidArgumentsError {
+			t.Errorf("expected %s to be rejected, got %v", url, wErr)
+		}
+	}
+
+	// Host names are checked after resolution, by the dialer.
+	w := createTestWebhook(t, s, c, strings.Replace(server.URL, "127.0.0.1", "localtest.invalid", 1))
+	w.Url = strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	if _, dbErr := s.WebhookDbService.Update(context.Background(), w); dbErr != nil {
+		t.Fatal(dbErr)
+	}
+	publishTestEvent(t, s, c.Id)
+
+	if _, wErr := s.ProcessDeliveries(context.Background()); wErr != nil {
+		t.Fatal(wErr)
+	}
+	if receiver.count() != 0 {
+		t.Fatalf("delivery reached internal address")
+	}
+	d := listTestDeliveries(t, s, c, w.Id)[0]
+	if d.Error != "connection failed" {
+		t.Errorf("expected generic error, got %q", d.Error)
+	}
+}
+
+func TestWebhookDoesNotFollowRedirects(t *testing.T) {
+	target, targetServer := newWebhookReceiver(t, http.StatusNoContent)
+	redirect := httptest.NewServer(http.RedirectHandler(targetServer.URL, http.StatusFound))
+	t.Cleanup(redirect.Close)
+
+	s := newTestWebhookService(1, time.Minute)
+	s.AllowAddress = allowLoopback
+	c := &domain.Claims{Id: "user1"}
+
+	w := createTestWebhook(t, s, c, redirect.URL)
+	publishTestEvent(t, s, c.Id)
+
+	if _, wErr := s.ProcessDeliveries(context.Background()); wErr != nil {
+		t.Fatal(wErr)
+	}
+	if target.count() != 0 {
+		t.Fatalf("redirect was followed")
+	}
+	d := listTestDeliveries(t, s, c, w.Id)[0]
+	if d.Status != webhook.DeliveryStatusFailed || d.ResponseStatus != http.StatusFound {
+		t.Fatalf("expected failed delivery with status 302, got %+v", d)
+	}
+}
@@ -5,84 +5,142 @@ import (
 	"encoding/hex"
 	"errors"
 	"github.com/go-playground/validator/v10"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/config"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
 	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
 	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
 	"github.com/rs/xid"
-	"golang.org/x/exp/slices"
+	"log"
 	"sync"
+	"time"
 )
 
 type WhService struct {
-	Validator   *validator.Validate
-	WhDbService wh.WhDbService
+	Validator         *validator.Validate
+	WhDbService       wh.WhDbService
+	RevisionDbService wh.WhRevisionDbService
+	AuditService      audit.AuditService
+	RevisionLimit     int
+	RevisionMaxAge    time.Duration
+	TrashRetention    time.Duration
+	EnumRegistry      *wh.WhEnumRegistry
+	MarkdownRenderer  domain.MarkdownRenderer
+	Events            *WhEventBroker
+	WebhookService    webhook.WebhookService
 }
 
-func NewWhService(v *validator.Validate, db wh.WhDbService) *WhService {
-	return &WhService{Validator: v, WhDbService: db}
+func NewWhService(cfg *config.WhService, v *validator.Validate, enums *wh.WhEnumRegistry, md domain.MarkdownRenderer, db wh.WhDbService, rdb wh.WhRevisionDbService, as audit.AuditService, ws webhook.WebhookService) *WhService {
+	return &WhService{
+		Validator:         v,
+		EnumRegistry:      enums,
+		MarkdownRenderer:  md,
+		WhDbService:       db,
+		RevisionDbService: rdb,
+		AuditService:      as,
+		RevisionLimit:     cfg.RevisionLimit,
+		RevisionMaxAge:    cfg.RevisionMaxAge,
+		TrashRetention:    cfg.TrashRetention,
+		Events:            NewWhEventBroker(),
+		WebhookService:    ws,
+	}
 }
 
 func (s *WhService) Create(ctx context.Context, t wh.WhType, w *wh.Wh, c *domain.Claims) (*wh.Wh, *wh.WhError) {
 	if c.Id == "anonymous" {
 		return nil, &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
 	}
+	if whErr := checkScope(c, t, true); whErr != nil {
+		return nil, whErr
+	}
 
 	newWh := w.InitAndCopy()
+	newWh.MapRichText(wh.SanitizeMarkdown)
 
 	if err := s.Validator.Struct(newWh); err != nil {
 		return nil, &wh.WhError{WhType: t, ErrType: wh.WhInvalidArgumentsError, Err: err}
 	}
 
-	if c.Admin {
-		newWh.OwnerId = "admin"
-	} else {
-		newWh.OwnerId = c.Id
+	if whErr := s.validateSpecies(ctx, t, &newWh, c); whErr != nil {
+		return nil, whErr
 	}
+
+	newWh.OwnerId = whOwner(c)
+	newWh.Hidden = false
+	newWh.HiddenBy = ""
+	newWh.HiddenReason = ""
 	newWh.Id = hex.EncodeToString(xid.New().Bytes())
+	newWh.Version = 1
+	newWh.CreatedAt = time.Now()
+	newWh.UpdatedAt = newWh.CreatedAt
+	newWh.LastModifiedBy = c.Id
 
 	createdWh, dbErr := s.WhDbService.Create(ctx, t, &newWh)
 	if dbErr != nil {
 		return nil, &wh.WhError{WhType: t, ErrType: user.UserInternalError, Err: dbErr}
 	}
 
-	createdWh.CanEdit = canEdit(createdWh.OwnerId, c.Admin, c.Id, c.SharedAccounts)
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeCreate, string(t), createdWh.Id, createdWh.OwnerId, nil, createdWh.Object)
+	s.Events.Publish(&wh.WhEvent{Type: wh.WhEventCreated, WhType: t, Wh: createdWh})
+	s.publishWebhook(ctx, wh.WhEventCreated, t, createdWh)
+
+	createdWh.CanEdit = canEdit(createdWh.OwnerId, c)
 	return createdWh, nil
 }
 
-func canEdit(ownerId string, isAdmin bool, userId string, sharedAccounts []string) bool {
-	if (ownerId != userId) && slices.Contains(sharedAccounts, ownerId) {
-		return false
+// checkScope rejects claims of personal access tokens that were not granted access to objects of type t.
+func checkScope(c *domain.Claims, t wh.WhType, write bool) *wh.WhError {
+	if !c.Allows(string(t), write) {
+		return &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("token scope does not allow access")}
 	}
+	return nil
+}
 
-	if isAdmin {
-		return true
+// whOwner returns the owner of objects written with claims c. Objects written by users allowed to edit official
+// content are owned by admin.
+func whOwner(c *domain.Claims) string {
+	if c.Can(domain.PermissionEditOfficial) {
+		return "admin"
 	}
+	return c.Id
+}
 
-	if ownerId == userId {
-		return true
-	}
+func canEdit(ownerId string, c *domain.Claims) bool {
+	return ownerId == whOwner(c)
+}
 
-	return false
+// referenceableOwners returns the owners and shared accounts whose objects can be referenced by objects written with
+// claims c. Official objects are visible to everyone, so they can reference only other official objects.
+func referenceableOwners(c *domain.Claims) ([]string, []string) {
+	if whOwner(c) == "admin" {
+		return []string{"admin"}, nil
+	}
+	return []string{"admin", c.Id}, c.SharedAccounts
 }
 
 func (s *WhService) Update(ctx context.Context, t wh.WhType, w *wh.Wh, c *domain.Claims) (*wh.Wh, *wh.WhError) {
 	if c.Id == "anonymous" {
 		return nil, &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
 	}
+	if whErr := checkScope(c, t, true); whErr != nil {
+		return nil, whErr
+	}
 
 	newWh := w.InitAndCopy()
+	newWh.MapRichText(wh.SanitizeMarkdown)
 
 	if err := s.Validator.Struct(newWh); err != nil {
 		return nil, &wh.WhError{WhType: t, ErrType: wh.WhInvalidArgumentsError, Err: err}
 	}
 
-	if c.Admin {
-		newWh.OwnerId = "admin"
-	} else {
-		newWh.OwnerId = c.Id
+	if whErr := s.validateSpecies(ctx, t, &newWh, c); whErr != nil {
+		return nil, whErr
 	}
 
-	updatedWh, dbErr := s.WhDbService.Update(ctx, t, &newWh, c.Id)
+	newWh.OwnerId = whOwner(c)
+
+	currentWh, dbErr := retrieveOne(ctx, s.WhDbService, t, newWh.Id, newWh.OwnerId)
 	if dbErr != nil {
 		switch dbErr.Type {
 		case domain.DbNotFoundError:
@@ -92,7 +150,43 @@ func (s *WhService) Update(ctx context.Context, t wh.WhType, w *wh.Wh, c *domain
 		}
 	}
 
-	updatedWh.CanEdit = canEdit(updatedWh.OwnerId, c.Admin, c.Id, c.SharedAccounts)
+	if currentWh.Version != newWh.Version {
+		return nil, &wh.WhError{ErrType: wh.WhConflictError, WhType: t, Err: errors.New("wh version mismatch")}
+	}
+
+	newWh.CreatedAt = currentWh.CreatedAt
+	newWh.Translations = currentWh.Translations
+	newWh.Hidden = currentWh.Hidden
+	newWh.HiddenBy = currentWh.HiddenBy
+	newWh.HiddenReason = currentWh.HiddenReason
+	newWh.UpdatedAt = time.Now()
+	newWh.LastModifiedBy = c.Id
+
+	updatedWh, dbErr := s.WhDbService.Update(ctx, t, &newWh, newWh.OwnerId)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		case domain.DbConflictError:
+			return nil, &wh.WhError{ErrType: wh.WhConflictError, WhType: t, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	// The update is already stored, a missing revision only shortens the history.
+	if whErr := s.storeRevision(ctx, t, currentWh, updatedWh); whErr != nil {
+		log.Printf("error storing revision of %s %s: %s", t, updatedWh.Id, whErr)
+	}
+
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeUpdate, string(t), updatedWh.Id, updatedWh.OwnerId, currentWh.Object, updatedWh.Object)
+	s.Events.Publish(&wh.WhEvent{Type: wh.WhEventUpdated, WhType: t, Wh: updatedWh})
+	s.publishWebhook(ctx, wh.WhEventUpdated, t, updatedWh)
+	if t == wh.WhTypeCharacter {
+		s.publishCareerAdvanced(ctx, c, currentWh, updatedWh)
+	}
+
+	updatedWh.CanEdit = canEdit(updatedWh.OwnerId, c)
 	return updatedWh, nil
 }
 
@@ -100,26 +194,68 @@ func (s *WhService) Delete(ctx context.Context, t wh.WhType, whId string, c *dom
 	if c.Id == "anonymous" {
 		return &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
 	}
+	if whErr := checkScope(c, t, true); whErr != nil {
+		return whErr
+	}
 
-	dbErr := s.WhDbService.Delete(ctx, t, whId, c.Id)
-	if dbErr != nil {
+	ownerId := whOwner(c)
+	currentWh, dbErr := retrieveOne(ctx, s.WhDbService, t, whId, ownerId)
+	if dbErr != nil && dbErr.Type != domain.DbNotFoundError {
 		return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
 	}
 
+	dbErr = s.WhDbService.Delete(ctx, t, whId, ownerId, c.Id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		default:
+			return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	if currentWh != nil {
+		recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeDelete, string(t), currentWh.Id, currentWh.OwnerId, currentWh.Object, nil)
+		s.Events.Publish(&wh.WhEvent{Type: wh.WhEventDeleted, WhType: t, Wh: currentWh})
+		s.publishWebhook(ctx, wh.WhEventDeleted, t, currentWh)
+	}
+
 	return nil
 }
 
+func retrieveOne(ctx context.Context, db wh.WhDbService, t wh.WhType, whId string, ownerId string) (*wh.Wh, *domain.DbError) {
+	whs, dbErr := db.Retrieve(ctx, t, []string{ownerId}, nil, []string{whId})
+	if dbErr != nil {
+		return nil, dbErr
+	}
+
+	if len(whs) == 0 {
+		return nil, domain.CreateDbError(domain.DbNotFoundError, errors.New("wh not found"))
+	}
+
+	return whs[0], nil
+}
+
 func (s *WhService) Get(ctx context.Context, t wh.WhType, c *domain.Claims, full bool, whIds []string) ([]*wh.Wh, *wh.WhError) {
-	users := []string{"admin", c.Id}
+	if whErr := checkScope(c, t, false); whErr != nil {
+		return nil, whErr
+	}
 
-	whs, dbErr := s.WhDbService.Retrieve(ctx, t, users, c.SharedAccounts, whIds)
+	return s.get(ctx, t, c, full, whIds)
+}
 
-	if dbErr != nil {
-		switch dbErr.Type {
-		case domain.DbNotFoundError:
-			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
-		default:
-			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+// get retrieves objects without checking token scopes, so that full objects include their components even if the
+// token was granted access only to the type of the object itself.
+func (s *WhService) get(ctx context.Context, t wh.WhType, c *domain.Claims, full bool, whIds []string) ([]*wh.Wh, *wh.WhError) {
+	whs, whErr := s.retrieveVisible(ctx, t, c, whIds)
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	// Objects the claims can edit keep their stored text, otherwise saving them would overwrite it with a translation.
+	for _, v := range whs {
+		if !canEdit(v.OwnerId, c) {
+			v.Localize(c.Locale)
 		}
 	}
 
@@ -136,12 +272,68 @@ func (s *WhService) Get(ctx context.Context, t wh.WhType, c *domain.Claims, full
 	}
 
 	for _, v := range whs {
-		v.CanEdit = canEdit(v.OwnerId, c.Admin, c.Id, c.SharedAccounts)
+		v.CanEdit = canEdit(v.OwnerId, c) && c.Allows(string(t), true)
 	}
 
 	return whs, nil
 }
 
+// retrieveVisible returns objects visible to the claims as they are stored, without localization.
+func (s *WhService) retrieveVisible(ctx context.Context, t wh.WhType, c *domain.Claims, whIds []string) ([]*wh.Wh, *wh.WhError) {
+	users := []string{"admin", c.Id}
+
+	whs, dbErr := s.WhDbService.Retrieve(ctx, t, users, c.SharedAccounts, whIds)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	return whs, nil
+}
+
+// GetChanges returns objects of all types created, updated or deleted since the given time. Deleted objects are
+// returned with DeletedAt set until they are purged from the trash, so changes older than TrashRetention can not be
+// listed and the caller has to fetch all objects again. Types the claims are not allowed to read are left out.
+func (s *WhService) GetChanges(ctx context.Context, c *domain.Claims, since time.Time) (map[wh.WhType]*wh.WhChanges, *wh.WhError) {
+	if !since.IsZero() && since.Before(time.Now().Add(-s.TrashRetention)) {
+		return nil, &wh.WhError{ErrType: wh.WhGoneError, Err: errors.New("changes older than trash retention are not kept")}
+	}
+
+	users := []string{"admin", c.Id}
+
+	changes := make(map[wh.WhType]*wh.WhChanges, len(wh.WhApiTypes))
+	for _, t := range wh.WhApiTypes {
+		if !c.Allows(string(t), false) {
+			continue
+		}
+
+		whs, dbErr := s.WhDbService.RetrieveChanged(ctx, t, users, c.SharedAccounts, since)
+		if dbErr != nil {
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+
+		for _, v := range whs {
+			v.CanEdit = canEdit(v.OwnerId, c) && c.Allows(string(t), true)
+		}
+
+		// Nothing has been synced before the first sync, so there is nothing to remove.
+		removedIds := make([]string, 0)
+		if !since.IsZero() {
+			if removedIds, dbErr = s.WhDbService.RetrieveRemoved(ctx, t, c.SharedAccounts, since); dbErr != nil {
+				return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+			}
+		}
+
+		changes[t] = &wh.WhChanges{Changed: whs, Removed: removedIds}
+	}
+
+	return changes, nil
+}
+
 func retrieveFullItems(ctx context.Context, whService *WhService, claims *domain.Claims, items []*wh.Wh) ([]*wh.Wh, *wh.WhError) {
 	allPropertyIds := make([]string, 0)
 	allSpellIds := make([]string, 0)
@@ -161,14 +353,14 @@ func retrieveFullItems(ctx context.Context, whService *WhService, claims *domain
 	var propertyWhErr *wh.WhError
 	go func() {
 		defer wg.Done()
-		allProperties, propertyWhErr = whService.Get(ctx, wh.WhTypeProperty, claims, false, allPropertyIds)
+		allProperties, propertyWhErr = whService.get(ctx, wh.WhTypeProperty, claims, false, allPropertyIds)
 	}()
 
 	var allSpells []*wh.Wh
 	var spellWhErr *wh.WhError
 	go func() {
 		defer wg.Done()
-		allSpells, spellWhErr = whService.Get(ctx, wh.WhTypeSpell, claims, false, allSpellIds)
+		allSpells, spellWhErr = whService.get(ctx, wh.WhTypeSpell, claims, false, allSpellIds)
 	}()
 
 	wg.Wait()
@@ -254,10 +446,10 @@ func retrieveFullCharacters(ctx context.Context, whService *WhService, claims *d
 	}
 
 	for k, v := range components {
-		v.wh, v.err = whService.Get(ctx, k, claims, v.full, v.ids)
+		v.wh, v.err = whService.get(ctx, k, claims, v.full, v.ids)
 		//go func() {
 		//	defer wg.Done()
-		//	v.wh, v.err = whService.Get(ctx, k, claims, v.full, v.ids)
+		//	v.wh, v.err = whService.get(ctx, k, claims, v.full, v.ids)
 		//}()
 	}
 
@@ -308,17 +500,3 @@ func mergeStrAndIdNumberAndRemoveDuplicates(strings []string, structs []wh.IdNum
 
 	return result
 }
-
-func (s *WhService) GetGenerationProps(ctx context.Context) (*wh.WhGenerationProps, *wh.WhError) {
-	generationPropsMap, dbErr := s.WhDbService.RetrieveGenerationProps(ctx)
-	if dbErr != nil {
-		switch dbErr.Type {
-		case domain.DbNotFoundError:
-			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, Err: dbErr}
-		default:
-			return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
-		}
-	}
-
-	return generationPropsMap, nil
-}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"log"
+	"strconv"
+	"time"
+)
+
+func (s *WhService) GetEnums(ctx context.Context) ([]*wh.WhEnum, *wh.WhError) {
+	enums, dbErr := s.WhDbService.RetrieveEnums(ctx)
+	if dbErr != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+	}
+
+	return enums, nil
+}
+
+func (s *WhService) UpdateEnum(ctx context.Context, e *wh.WhEnum, c *domain.Claims) (*wh.WhEnum, *wh.WhError) {
+	if !c.Can(domain.PermissionEditOfficial) {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	newEnum := e.InitAndCopy()
+	if whErr := s.validateEnum(&newEnum); whErr != nil {
+		return nil, whErr
+	}
+
+	newEnum.Version++
+	newEnum.UpdatedAt = time.Now()
+	newEnum.UpdatedBy = c.Id
+
+	updatedEnum, dbErr := s.WhDbService.UpsertEnum(ctx, &newEnum)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbConflictError:
+			return nil, &wh.WhError{ErrType: wh.WhConflictError, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+		}
+	}
+
+	s.EnumRegistry.Set([]*wh.WhEnum{updatedEnum})
+	return updatedEnum, nil
+}
+
+func (s *WhService) validateEnum(e *wh.WhEnum) *wh.WhError {
+	if !slices.Contains(wh.WhEnumNames, e.Name) {
+		return &wh.WhError{ErrType: wh.WhNotFoundError, Err: fmt.Errorf("enum %s not found", e.Name)}
+	}
+
+	if err := s.Validator.Struct(e); err != nil {
+		return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: err}
+	}
+
+	seen := make(map[string]bool, len(e.Values))
+	for _, v := range e.Values {
+		if seen[v.Value] {
+			return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: fmt.Errorf("duplicate enum value %s", v.Value)}
+		}
+		seen[v.Value] = true
+
+		if wh.IsNumericEnum(e.Name) {
+			if _, err := strconv.Atoi(v.Value); err != nil {
+				return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: fmt.Errorf("enum %s requires integer values", e.Name)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadEnums stores default values of enumerations missing from the database and refreshes the registry used by
+// validators.
+func (s *WhService) LoadEnums(ctx context.Context) *wh.WhError {
+	enums, whErr := s.GetEnums(ctx)
+	if whErr != nil {
+		return whErr
+	}
+
+	for _, d := range wh.NewDefaultEnums() {
+		if slices.ContainsFunc(enums, func(e *wh.WhEnum) bool { return e.Name == d.Name }) {
+			continue
+		}
+
+		d.Version = 1
+		d.UpdatedAt = time.Now()
+		d.UpdatedBy = "admin"
+		created, dbErr := s.WhDbService.UpsertEnum(ctx, d)
+		if dbErr != nil && dbErr.Type != domain.DbConflictError {
+			return &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+		}
+		if created != nil {
+			enums = append(enums, created)
+		}
+	}
+
+	s.EnumRegistry.Set(enums)
+	return nil
+}
+
+// StartEnumRefresh runs LoadEnums every interval until ctx is cancelled, so that changes made through other
+// instances reach this one.
+func (s *WhService) StartEnumRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if whErr := s.LoadEnums(ctx); whErr != nil {
+					log.Printf("error refreshing enums: %s", whErr)
+				}
+			}
+		}
+	}()
+}
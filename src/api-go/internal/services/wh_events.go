@@ -0,0 +1,91 @@
+package services
+
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"sync"
+)
+
+const subscriptionBufferSize = 64
+
+// WhEventBroker is an in-process pub/sub of changes made through WhService. Subscribers that fall behind by more than
+// subscriptionBufferSize events are dropped instead of blocking writers, they are expected to reconnect and catch up
+// through GetChanges.
+type WhEventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[*wh.WhSubscription]bool
+}
+
+func NewWhEventBroker() *WhEventBroker {
+	return &WhEventBroker{subscribers: make(map[*wh.WhSubscription]bool)}
+}
+
+func (b *WhEventBroker) Subscribe(c *domain.Claims) *wh.WhSubscription {
+	sub := wh.NewWhSubscription(c, subscriptionBufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[sub] = true
+	return sub
+}
+
+func (b *WhEventBroker) Unsubscribe(sub *wh.WhSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[sub] {
+		delete(b.subscribers, sub)
+		close(sub.Events)
+	}
+}
+
+// Publish delivers e to subscribers allowed to see the object, using the same rules as WhDbService.Retrieve, and whose
+// token scopes cover its type. Every subscriber gets its own copy localized and with CanEdit set for its claims.
+func (b *WhEventBroker) Publish(e *wh.WhEvent) {
+	slow := make([]*wh.WhSubscription, 0)
+
+	b.mu.RLock()
+	for sub := range b.subscribers {
+		if !sub.Matches(e) || !isVisible(e.Wh, sub.Claims) || !sub.Claims.Allows(string(e.WhType), false) {
+			continue
+		}
+
+		select {
+		case sub.Events <- subscriberCopy(e, sub.Claims):
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range slow {
+		b.Unsubscribe(sub)
+	}
+}
+
+func isVisible(w *wh.Wh, c *domain.Claims) bool {
+	if w.OwnerId == "admin" || w.OwnerId == c.Id {
+		return true
+	}
+	return slices.Contains(c.SharedAccounts, w.OwnerId) && w.IsShared()
+}
+
+func subscriberCopy(e *wh.WhEvent, c *domain.Claims) *wh.WhEvent {
+	cpy := e.Wh.InitAndCopy()
+	if !canEdit(cpy.OwnerId, c) {
+		cpy.Localize(c.Locale)
+	}
+	cpy.CanEdit = canEdit(cpy.OwnerId, c) && c.Allows(string(e.WhType), true)
+
+	return &wh.WhEvent{Type: e.Type, WhType: e.WhType, Wh: &cpy}
+}
+
+func (s *WhService) Subscribe(c *domain.Claims) *wh.WhSubscription {
+	return s.Events.Subscribe(c)
+}
+
+func (s *WhService) Unsubscribe(sub *wh.WhSubscription) {
+	s.Events.Unsubscribe(sub)
+}
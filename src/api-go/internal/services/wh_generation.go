@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/pat"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"strings"
+	"time"
+)
+
+func checkGenerationScope(c *domain.Claims, write bool) *wh.WhError {
+	if !c.Allows(pat.ScopeGeneration, write) {
+		return &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("token scope does not allow access")}
+	}
+	return nil
+}
+
+func (s *WhService) ListGenerationProps(ctx context.Context, c *domain.Claims) ([]*wh.WhGenerationProps, *wh.WhError) {
+	if whErr := checkGenerationScope(c, false); whErr != nil {
+		return nil, whErr
+	}
+
+	users := []string{"admin", c.Id}
+
+	list, dbErr := s.WhDbService.RetrieveGenerationPropsList(ctx, users, c.SharedAccounts)
+	if dbErr != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+	}
+
+	for _, v := range list {
+		v.CanEdit = canEdit(v.OwnerId, c) && c.Allows(pat.ScopeGeneration, true)
+	}
+
+	return list, nil
+}
+
+// GetGenerationProps returns the rule set with the given name, preferring the user's own over admin provided and
+// admin provided over shared ones. An empty name selects the default admin rule set.
+func (s *WhService) GetGenerationProps(ctx context.Context, name string, c *domain.Claims) (*wh.WhGenerationProps, *wh.WhError) {
+	if name == "" {
+		name = wh.GenerationPropsName
+	}
+
+	list, whErr := s.ListGenerationProps(ctx, c)
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	var found *wh.WhGenerationProps
+	for _, v := range list {
+		if v.Name != name {
+			continue
+		}
+		if found == nil || ownerPriority(v.OwnerId, c.Id) < ownerPriority(found.OwnerId, c.Id) {
+			found = v
+		}
+	}
+
+	if found == nil {
+		return nil, &wh.WhError{ErrType: wh.WhNotFoundError, Err: fmt.Errorf("generation props %s not found", name)}
+	}
+
+	return found, nil
+}
+
+func ownerPriority(ownerId string, userId string) int {
+	switch ownerId {
+	case userId:
+		return 0
+	case "admin":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (s *WhService) CreateGenerationProps(ctx context.Context, gp *wh.WhGenerationProps, c *domain.Claims) (*wh.WhGenerationProps, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+	if whErr := checkGenerationScope(c, true); whErr != nil {
+		return nil, whErr
+	}
+
+	newGp := gp.InitAndCopy()
+	if whErr := s.validateGenerationProps(ctx, &newGp, c); whErr != nil {
+		return nil, whErr
+	}
+
+	newGp.OwnerId = whOwner(c)
+	newGp.Version = 1
+	newGp.UpdatedAt = time.Now()
+	newGp.UpdatedBy = c.Id
+
+	createdGp, dbErr := s.WhDbService.CreateGenerationProps(ctx, &newGp)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbAlreadyExistsError:
+			return nil, &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: fmt.Errorf("generation props %s already exists", newGp.Name)}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+		}
+	}
+
+	createdGp.CanEdit = true
+	return createdGp, nil
+}
+
+func (s *WhService) UpdateGenerationProps(ctx context.Context, gp *wh.WhGenerationProps, c *domain.Claims) (*wh.WhGenerationProps, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+	if whErr := checkGenerationScope(c, true); whErr != nil {
+		return nil, whErr
+	}
+
+	newGp := gp.InitAndCopy()
+	if newGp.Name == "" {
+		newGp.Name = wh.GenerationPropsName
+	}
+
+	if whErr := s.validateGenerationProps(ctx, &newGp, c); whErr != nil {
+		return nil, whErr
+	}
+
+	newGp.OwnerId = whOwner(c)
+	newGp.Version++
+	newGp.UpdatedAt = time.Now()
+	newGp.UpdatedBy = c.Id
+
+	updatedGp, dbErr := s.WhDbService.UpdateGenerationProps(ctx, &newGp)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, Err: dbErr}
+		case domain.DbConflictError:
+			return nil, &wh.WhError{ErrType: wh.WhConflictError, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+		}
+	}
+
+	updatedGp.CanEdit = true
+	return updatedGp, nil
+}
+
+// validateGenerationProps checks that referenced objects exist and are visible to the owner of gp.
+func (s *WhService) validateGenerationProps(ctx context.Context, gp *wh.WhGenerationProps, c *domain.Claims) *wh.WhError {
+	if err := s.Validator.Struct(gp); err != nil {
+		return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: err}
+	}
+
+	users, sharedUsers := referenceableOwners(c)
+	for t, ids := range gp.ReferencedIds() {
+		missing, dbErr := s.WhDbService.RetrieveMissingIds(ctx, t, users, sharedUsers, mergeStrAndRemoveDuplicates(ids, nil))
+		if dbErr != nil {
+			return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+		if len(missing) != 0 {
+			err := fmt.Errorf("%s ids do not exist: %s", t, strings.Join(missing, ", "))
+			return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, WhType: t, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func (s *WhService) DeleteGenerationProps(ctx context.Context, name string, c *domain.Claims) *wh.WhError {
+	if c.Id == "anonymous" {
+		return &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+	if whErr := checkGenerationScope(c, true); whErr != nil {
+		return whErr
+	}
+
+	if name == "" || name == wh.GenerationPropsName {
+		return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: errors.New("default generation props can not be deleted")}
+	}
+
+	if dbErr := s.WhDbService.DeleteGenerationProps(ctx, whOwner(c), name); dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return &wh.WhError{ErrType: wh.WhNotFoundError, Err: dbErr}
+		default:
+			return &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+		}
+	}
+
+	return nil
+}
+
+func (s *WhService) GetGenerationPropsHistory(ctx context.Context, name string, c *domain.Claims) ([]*wh.WhGenerationProps, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+	if whErr := checkGenerationScope(c, false); whErr != nil {
+		return nil, whErr
+	}
+
+	if name == "" {
+		name = wh.GenerationPropsName
+	}
+
+	history, dbErr := s.WhDbService.RetrieveGenerationPropsHistory(ctx, whOwner(c), name)
+	if dbErr != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, Err: dbErr}
+	}
+
+	return history, nil
+}
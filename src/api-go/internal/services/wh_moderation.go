@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+const hiddenReasonMaxLength = 500
+
+// Hide stops an object from being shared with linked users of its owner, the owner still sees it together with the
+// reason. Objects owned by admin can not be hidden.
+func (s *WhService) Hide(ctx context.Context, t wh.WhType, whId string, reason string, c *domain.Claims) (*wh.Wh, *wh.WhError) {
+	if len(reason) > hiddenReasonMaxLength {
+		return nil, &wh.WhError{WhType: t, ErrType: wh.WhInvalidArgumentsError, Err: errors.New("reason is too long")}
+	}
+	return s.updateHidden(ctx, t, whId, true, reason, c)
+}
+
+func (s *WhService) Unhide(ctx context.Context, t wh.WhType, whId string, c *domain.Claims) (*wh.Wh, *wh.WhError) {
+	return s.updateHidden(ctx, t, whId, false, "", c)
+}
+
+func (s *WhService) updateHidden(ctx context.Context, t wh.WhType, whId string, hidden bool, reason string, c *domain.Claims) (*wh.Wh, *wh.WhError) {
+	if !c.Can(domain.PermissionModerate) {
+		return nil, &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	updatedWh, dbErr := s.WhDbService.UpdateHidden(ctx, t, whId, hidden, reason, c.Id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	eventType := audit.EventTypeUnhide
+	if hidden {
+		eventType = audit.EventTypeHide
+	}
+	recordAudit(ctx, s.AuditService, c.Id, eventType, string(t), updatedWh.Id, updatedWh.OwnerId, nil, map[string]any{"reason": reason})
+	s.Events.Publish(&wh.WhEvent{Type: wh.WhEventUpdated, WhType: t, Wh: updatedWh})
+	s.publishWebhook(ctx, wh.WhEventUpdated, t, updatedWh)
+
+	updatedWh.CanEdit = canEdit(updatedWh.OwnerId, c)
+	return updatedWh, nil
+}
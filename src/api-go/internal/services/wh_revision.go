@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"time"
+)
+
+// storeRevision keeps the object replaced by updated under the version number of updated. It is called once the update
+// succeeded, the version check of the update lets only one concurrent update through, so the number is unique.
+func (s *WhService) storeRevision(ctx context.Context, t wh.WhType, replaced *wh.Wh, updated *wh.Wh) *wh.WhError {
+	revision := wh.WhRevision{
+		WhType:     t,
+		WhId:       replaced.Id,
+		Rev:        updated.Version,
+		OwnerId:    replaced.OwnerId,
+		ReplacedOn: updated.UpdatedAt,
+		ReplacedBy: updated.LastModifiedBy,
+		Object:     replaced.Object,
+	}
+
+	if _, dbErr := s.RevisionDbService.Create(ctx, &revision); dbErr != nil {
+		return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	revisions, dbErr := s.RevisionDbService.Retrieve(ctx, t, replaced.Id)
+	if dbErr != nil {
+		return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	if dbErr = s.RevisionDbService.Delete(ctx, t, replaced.Id, expiredRevisions(revisions, s.RevisionLimit, s.RevisionMaxAge)); dbErr != nil {
+		return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	return nil
+}
+
+// expiredRevisions expects revisions sorted from newest to oldest. A zero limit or max age disables that check.
+func expiredRevisions(revisions []*wh.WhRevision, limit int, maxAge time.Duration) []int {
+	expired := make([]int, 0)
+	cutoff := time.Now().Add(-maxAge)
+
+	for i, r := range revisions {
+		if (limit > 0 && i >= limit) || (maxAge > 0 && r.ReplacedOn.Before(cutoff)) {
+			expired = append(expired, r.Rev)
+		}
+	}
+
+	return expired
+}
+
+func (s *WhService) GetRevisions(ctx context.Context, t wh.WhType, whId string, c *domain.Claims) ([]*wh.WhRevision, *wh.WhError) {
+	if _, whErr := s.Get(ctx, t, c, false, []string{whId}); whErr != nil {
+		return nil, whErr
+	}
+
+	revisions, dbErr := s.RevisionDbService.Retrieve(ctx, t, whId)
+	if dbErr != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	return revisions, nil
+}
+
+func (s *WhService) GetRevision(ctx context.Context, t wh.WhType, whId string, rev int, c *domain.Claims) (*wh.WhRevision, *wh.WhError) {
+	if _, whErr := s.Get(ctx, t, c, false, []string{whId}); whErr != nil {
+		return nil, whErr
+	}
+
+	revision, dbErr := s.RevisionDbService.RetrieveOne(ctx, t, whId, rev)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	return revision, nil
+}
+
+// DiffRevision returns changes needed to go from revision rev to revision againstRev. When againstRev is 0 the
+// revision is compared with the current version of the object.
+func (s *WhService) DiffRevision(ctx context.Context, t wh.WhType, whId string, rev int, againstRev int, c *domain.Claims) ([]domain.FieldChange, *wh.WhError) {
+	revision, whErr := s.GetRevision(ctx, t, whId, rev, c)
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	var against wh.WhObject
+	if againstRev == 0 {
+		// Localized text would show up as changes, so the revision is compared with the stored object.
+		current, whErr := s.retrieveVisible(ctx, t, c, []string{whId})
+		if whErr != nil {
+			return nil, whErr
+		}
+		against = current[0].Object
+	} else {
+		againstRevision, whErr := s.GetRevision(ctx, t, whId, againstRev, c)
+		if whErr != nil {
+			return nil, whErr
+		}
+		against = againstRevision.Object
+	}
+
+	changes, err := domain.Diff(revision.Object, against)
+	if err != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: err}
+	}
+
+	return changes, nil
+}
+
+func (s *WhService) RestoreRevision(ctx context.Context, t wh.WhType, whId string, rev int, c *domain.Claims) (*wh.Wh, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	revision, whErr := s.GetRevision(ctx, t, whId, rev, c)
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	current, whErr := s.Get(ctx, t, c, false, []string{whId})
+	if whErr != nil {
+		return nil, whErr
+	}
+
+	restored := wh.Wh{Id: whId, Version: current[0].Version, Object: revision.Object}
+	return s.Update(ctx, t, &restored, c)
+}
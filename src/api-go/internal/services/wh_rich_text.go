@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+// Render replaces markdown fields of whs with sanitized HTML. Cross-links are resolved to objects visible to the
+// user, preferring the user's own objects over admin provided and admin provided over shared ones. Links that can
+// not be resolved, or point to types the claims are not allowed to read, are rendered as plain text.
+func (s *WhService) Render(ctx context.Context, whs []*wh.Wh, c *domain.Claims) *wh.WhError {
+	linkedNames := map[wh.WhType][]string{}
+	for _, v := range whs {
+		v.MapRichText(func(text string) string {
+			for _, link := range wh.FindLinks(text) {
+				linkedNames[link.Type] = append(linkedNames[link.Type], strings.ToLower(link.Name))
+			}
+			return text
+		})
+	}
+
+	linkedIds := map[wh.WhType]map[string]string{}
+	for t, names := range linkedNames {
+		if !slices.Contains(wh.WhApiTypes, t) || !c.Allows(string(t), false) {
+			continue
+		}
+
+		ids, whErr := s.resolveNames(ctx, t, names, c)
+		if whErr != nil {
+			return whErr
+		}
+		linkedIds[t] = ids
+	}
+
+	for _, v := range whs {
+		v.MapRichText(func(text string) string {
+			text = wh.ReplaceLinks(text, func(link wh.WhLink) string {
+				if id, ok := linkedIds[link.Type][strings.ToLower(link.Name)]; ok {
+					return fmt.Sprintf("[%s](/api/wh/%s/%s)", link.Name, link.Type, id)
+				}
+				return link.Name
+			})
+			return s.MarkdownRenderer.Render(text)
+		})
+	}
+
+	return nil
+}
+
+func (s *WhService) resolveNames(ctx context.Context, t wh.WhType, names []string, c *domain.Claims) (map[string]string, *wh.WhError) {
+	candidates, dbErr := s.WhDbService.Retrieve(ctx, t, []string{"admin", c.Id}, c.SharedAccounts, nil)
+	if dbErr != nil {
+		return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	ids := map[string]string{}
+	owners := map[string]string{}
+	for _, v := range candidates {
+		name := strings.ToLower(v.Name())
+		if !slices.Contains(names, name) {
+			continue
+		}
+
+		if current, ok := owners[name]; ok && ownerPriority(current, c.Id) <= ownerPriority(v.OwnerId, c.Id) {
+			continue
+		}
+		ids[name] = v.Id
+		owners[name] = v.OwnerId
+	}
+
+	return ids, nil
+}
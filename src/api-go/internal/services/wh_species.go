@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+)
+
+// validateSpecies checks that species referenced by characters, careers and other species exist and are visible to
+// the owner of w.
+func (s *WhService) validateSpecies(ctx context.Context, t wh.WhType, w *wh.Wh, c *domain.Claims) *wh.WhError {
+	var speciesId string
+	switch o := w.Object.(type) {
+	case wh.WhCharacter:
+		speciesId = string(o.Species)
+	case wh.WhCareer:
+		speciesId = string(o.Species)
+	case wh.WhSpecies:
+		speciesId = o.BaseSpecies
+	}
+
+	if speciesId == "" {
+		return nil
+	}
+
+	users, sharedUsers := referenceableOwners(c)
+	missing, dbErr := s.WhDbService.RetrieveMissingIds(ctx, wh.WhTypeSpecies, users, sharedUsers, []string{speciesId})
+	if dbErr != nil {
+		return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+	}
+
+	if len(missing) != 0 {
+		return &wh.WhError{ErrType: wh.WhInvalidArgumentsError, WhType: t, Err: fmt.Errorf("species %s does not exist", speciesId)}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+)
+
+// SubmitTranslations stores translations of admin provided objects. All entries are validated before any is stored.
+// Ids of objects that do not exist are returned, the remaining entries are applied.
+func (s *WhService) SubmitTranslations(ctx context.Context, translations []*wh.WhTranslationSubmission, c *domain.Claims) ([]string, *wh.WhError) {
+	if !c.Can(domain.PermissionEditOfficial) {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	valid := make([]*wh.WhTranslationSubmission, len(translations))
+	for i, v := range translations {
+		if !slices.Contains(wh.WhApiTypes, v.Type) {
+			return nil, &wh.WhError{ErrType: wh.WhInvalidArgumentsError, Err: fmt.Errorf("translation %d: invalid type %s", i, v.Type)}
+		}
+
+		if err := s.Validator.Struct(v); err != nil {
+			return nil, &wh.WhError{ErrType: wh.WhInvalidArgumentsError, WhType: v.Type, Err: fmt.Errorf("translation %d: %s", i, err)}
+		}
+
+		if v.Type != wh.WhTypeCareer && len(v.LevelNames) != 0 {
+			return nil, &wh.WhError{ErrType: wh.WhInvalidArgumentsError, WhType: v.Type, Err: fmt.Errorf("translation %d: level names are only allowed for careers", i)}
+		}
+
+		tag, err := language.Parse(v.Locale)
+		if err != nil {
+			return nil, &wh.WhError{ErrType: wh.WhInvalidArgumentsError, WhType: v.Type, Err: fmt.Errorf("translation %d: %s", i, err)}
+		}
+
+		valid[i] = &wh.WhTranslationSubmission{Type: v.Type, Id: v.Id, Locale: tag.String(), WhTranslation: v.WhTranslation.InitAndCopy()}
+		valid[i].Description = wh.SanitizeMarkdown(valid[i].Description)
+	}
+
+	notFound := make([]string, 0)
+	for _, v := range valid {
+		dbErr := s.WhDbService.UpdateTranslation(ctx, v.Type, v.Id, "admin", v.Locale, v.WhTranslation, c.Id)
+		if dbErr != nil {
+			switch dbErr.Type {
+			case domain.DbNotFoundError:
+				notFound = append(notFound, v.Id)
+			default:
+				return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: v.Type, Err: dbErr}
+			}
+		}
+	}
+
+	return notFound, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/audit"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"log"
+	"time"
+)
+
+func (s *WhService) GetTrash(ctx context.Context, c *domain.Claims) (map[wh.WhType][]*wh.Wh, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+
+	trash := make(map[wh.WhType][]*wh.Wh, len(wh.WhApiTypes))
+	for _, t := range wh.WhApiTypes {
+		if !c.Allows(string(t), false) {
+			continue
+		}
+
+		whs, dbErr := s.WhDbService.RetrieveDeleted(ctx, t, whOwner(c))
+		if dbErr != nil {
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+
+		for _, v := range whs {
+			v.CanEdit = canEdit(v.OwnerId, c) && c.Allows(string(t), true)
+		}
+		trash[t] = whs
+	}
+
+	return trash, nil
+}
+
+func (s *WhService) Restore(ctx context.Context, t wh.WhType, whId string, c *domain.Claims) (*wh.Wh, *wh.WhError) {
+	if c.Id == "anonymous" {
+		return nil, &wh.WhError{WhType: t, ErrType: wh.WhUnauthorizedError, Err: errors.New("unauthorized")}
+	}
+	if whErr := checkScope(c, t, true); whErr != nil {
+		return nil, whErr
+	}
+
+	restoredWh, dbErr := s.WhDbService.Restore(ctx, t, whId, whOwner(c), c.Id)
+	if dbErr != nil {
+		switch dbErr.Type {
+		case domain.DbNotFoundError:
+			return nil, &wh.WhError{ErrType: wh.WhNotFoundError, WhType: t, Err: dbErr}
+		default:
+			return nil, &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	recordAudit(ctx, s.AuditService, c.Id, audit.EventTypeRestore, string(t), restoredWh.Id, restoredWh.OwnerId, nil, restoredWh.Object)
+	s.Events.Publish(&wh.WhEvent{Type: wh.WhEventRestored, WhType: t, Wh: restoredWh})
+	s.publishWebhook(ctx, wh.WhEventRestored, t, restoredWh)
+
+	restoredWh.CanEdit = canEdit(restoredWh.OwnerId, c)
+	return restoredWh, nil
+}
+
+// PurgeTrash permanently removes objects that have been in the trash longer than TrashRetention, together with their
+// revisions.
+func (s *WhService) PurgeTrash(ctx context.Context) *wh.WhError {
+	deletedBefore := time.Now().Add(-s.TrashRetention)
+
+	for _, t := range wh.WhApiTypes {
+		purgedIds, dbErr := s.WhDbService.Purge(ctx, t, deletedBefore)
+		if dbErr != nil {
+			return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+
+		if dbErr = s.RevisionDbService.DeleteAll(ctx, t, purgedIds); dbErr != nil {
+			return &wh.WhError{ErrType: wh.WhInternalError, WhType: t, Err: dbErr}
+		}
+	}
+
+	return nil
+}
+
+// StartTrashPurge runs PurgeTrash every interval until ctx is cancelled.
+func (s *WhService) StartTrashPurge(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if whErr := s.PurgeTrash(ctx); whErr != nil {
+					log.Printf("error purging trash: %s", whErr)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	wh "github.com/jmilosze/wfrp-hammergen-go/internal/domain/warhammer"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/webhook"
+	"log"
+)
+
+func (s *WhService) publishWebhook(ctx context.Context, whEventType string, t wh.WhType, w *wh.Wh) {
+	if s.WebhookService == nil {
+		return
+	}
+
+	whMap, err := w.ToMap()
+	if err != nil {
+		log.Printf("error converting %s %s for webhook: %s", t, w.Id, err)
+		return
+	}
+	delete(whMap, "CanEdit")
+
+	publishWebhookEvent(ctx, s.WebhookService, webhook.WhEventType(t, whEventType), w.OwnerId, w.IsShared(), whMap)
+}
+
+// publishCareerAdvanced publishes an event when a character enters a new career or reaches a higher rank in its
+// current one. Characters do not store their rank, it is the level of the career matching their status and standing.
+func (s *WhService) publishCareerAdvanced(ctx context.Context, c *domain.Claims, before *wh.Wh, after *wh.Wh) {
+	if s.WebhookService == nil {
+		return
+	}
+
+	prevCharacter, ok := before.Object.(wh.WhCharacter)
+	if !ok {
+		return
+	}
+	character, ok := after.Object.(wh.WhCharacter)
+	if !ok {
+		return
+	}
+
+	if prevCharacter.Career == character.Career && prevCharacter.Status == character.Status && prevCharacter.Standing == character.Standing {
+		return
+	}
+
+	career := s.retrieveCareer(ctx, c, character.Career)
+	rank, levelName := careerRank(career, character.Status, character.Standing)
+
+	prevRank := 0
+	if prevCharacter.Career == character.Career {
+		prevRank, _ = careerRank(career, prevCharacter.Status, prevCharacter.Standing)
+		if rank <= prevRank {
+			return
+		}
+	}
+
+	careerName := ""
+	if career != nil {
+		careerName = career.Name
+	}
+
+	data := map[string]any{
+		"characterId":      after.Id,
+		"characterName":    character.Name,
+		"careerId":         character.Career,
+		"careerName":       careerName,
+		"rank":             rank,
+		"levelName":        levelName,
+		"previousCareerId": prevCharacter.Career,
+		"previousRank":     prevRank,
+	}
+
+	publishWebhookEvent(ctx, s.WebhookService, webhook.EventTypeCareerAdvanced, after.OwnerId, after.IsShared(), data)
+}
+
+func (s *WhService) retrieveCareer(ctx context.Context, c *domain.Claims, id string) *wh.WhCareer {
+	careers, dbErr := s.WhDbService.Retrieve(ctx, wh.WhTypeCareer, []string{"admin", c.Id}, c.SharedAccounts, []string{id})
+	if dbErr != nil || len(careers) == 0 {
+		return nil
+	}
+
+	career, ok := careers[0].Object.(wh.WhCareer)
+	if !ok {
+		return nil
+	}
+	return &career
+}
+
+// careerRank returns the number and name of the first career level with the given status and standing, or 0 if
+// there is none.
+func careerRank(career *wh.WhCareer, status wh.WhStatus, standing wh.WhStanding) (int, string) {
+	if career == nil {
+		return 0, ""
+	}
+
+	for i, level := range []wh.WhCareerLevel{career.Level1, career.Level2, career.Level3, career.Level4} {
+		if level.Status == status && level.Standing == standing {
+			return i + 1, level.Name
+		}
+	}
+	return 0, ""
+}
@@ -12,7 +12,7 @@ var career0 = warhammer.Wh{
 		Name:        "career 0",
 		Description: fmt.Sprintf("owned by %s", user1.Username),
 		Class:       warhammer.WhCareerClassRanger,
-		Species:     warhammer.WhCareerSpeciesDwarf,
+		Species:     warhammer.WhCareerSpecies(warhammer.SpeciesIdFromCode(warhammer.WhCharacterSpeciesDwarfDefault)),
 		Level1: warhammer.WhCareerLevel{
 			Name:       "career 0 level 1",
 			Status:     warhammer.WhStatusGold,
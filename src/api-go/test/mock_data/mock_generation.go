@@ -6,7 +6,8 @@ import (
 
 func NewMockGenProps() *warhammer.WhGenerationProps {
 	return &warhammer.WhGenerationProps{
-		Name: "generationProps",
+		Name:    warhammer.GenerationPropsName,
+		OwnerId: "admin",
 		ClassItems: map[warhammer.WhCareerClass]warhammer.WhItems{
 			warhammer.WhCareerClassBurghers: {
 				Equipped: warhammer.WhIdNumberMap{itemMelee.Id: 1, itemArmour.Id: 1},
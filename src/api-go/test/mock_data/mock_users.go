@@ -1,44 +1,52 @@
 package mock_data
 
-import "github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+import (
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain"
+	"github.com/jmilosze/wfrp-hammergen-go/internal/domain/user"
+)
 
 var user0 = user.User{
 	Id:                 "000000000000000000000000",
 	Username:           "user0@test.com",
+	EmailVerified:      true,
 	Password:           "123456",
-	Admin:              true,
+	Roles:              []string{domain.RoleAdmin},
 	SharedAccountNames: []string{},
 }
 
 var user1 = user.User{
 	Id:                 "000000000000000000000001",
 	Username:           "user1@test.com",
+	EmailVerified:      true,
 	Password:           "111111",
-	Admin:              false,
+	Roles:              []string{},
 	SharedAccountNames: []string{"user0@test.com"},
 }
 
 var user2 = user.User{
 	Id:                 "000000000000000000000002",
 	Username:           "user2@test.com",
+	EmailVerified:      true,
 	Password:           "111111",
-	Admin:              false,
+	Roles:              []string{},
 	SharedAccountNames: []string{"user1@test.com"},
 }
 
 var user3 = user.User{
 	Id:                 "000000000000000000000003",
 	Username:           "user3@test.com",
+	EmailVerified:      true,
 	Password:           "111111",
-	Admin:              false,
+	Roles:              []string{domain.RoleEditor},
 	SharedAccountNames: []string{"user1@test.com", "user2@test.com"},
 }
 
 var user4 = user.User{
 	Id:                 "000000000000000000000004",
 	Username:           "user4@test.com",
+	EmailVerified:      true,
 	Password:           "111111",
-	Admin:              false,
+	Roles:              []string{domain.RoleModerator},
 	SharedAccountNames: []string{},
 }
 
@@ -42,6 +42,7 @@ func seedGenProps(ctx context.Context, db warhammer.WhDbService, genProps *warha
 }
 
 func InitWh(ctx context.Context, db warhammer.WhDbService) {
+	seedWh(ctx, db, warhammer.WhTypeSpecies, warhammer.NewLegacySpecies())
 	seedWh(ctx, db, warhammer.WhTypeMutation, NewMockMutations())
 	seedWh(ctx, db, warhammer.WhTypeSpell, NewMockSpells())
 	seedWh(ctx, db, warhammer.WhTypeProperty, NewMockProperties())